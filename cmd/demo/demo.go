@@ -1,21 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
 	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
 	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
 	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+	"github.com/Benzogang-Tape/Reddit/pkg/linkpreview"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
 )
 
-var port = flag.Int("port", 8081, "HTTP port")
+var (
+	port      = flag.Int("port", 8081, "HTTP port")
+	mediaRoot = flag.String("media", "./media", "Directory for uploaded media")
+)
 
 func init() {
 	os.Setenv("JWT_SECRET", "super secret key")
@@ -63,11 +71,57 @@ func main() {
 	userHandler := service.NewUserHandler(userStorage)
 	u := rest.NewUserHandler(userHandler, sessionHandler, logger)
 
+	communityStorage := inmem.NewCommunityRepo()
+	communityHandler := service.NewCommunityHandler(communityStorage)
+	c := rest.NewCommunityHandler(communityHandler, logger)
+
+	blobStore, err := storage.NewBlobStoreFS(*mediaRoot)
+	if err != nil {
+		panic(err)
+	}
+	mediaHandler := service.NewMediaHandler(blobStore)
+	m := rest.NewMediaHandler(mediaHandler, logger)
+
 	postStorage := inmem.NewPostRepo()
-	postHandler := service.NewPostHandler(postStorage, postStorage)
+	previewWorker := service.NewLinkPreviewWorker(
+		linkpreview.NewHTTPFetcher(linkpreview.Config{}),
+		postStorage,
+		logger,
+		service.LinkPreviewConfig{},
+	)
+	go previewWorker.Run(context.Background())
+
+	viewTracker := service.NewViewTracker(inmem.NewViewCounter(), postStorage, logger, service.ViewConfig{})
+	go viewTracker.Run(context.Background())
+
+	statsRecorder := service.NewStatsRecorder(inmem.NewStatsRepo(90*24*time.Hour), logger, service.StatsConfig{})
+	go statsRecorder.Run(context.Background())
+
+	scheduledStorage := inmem.NewScheduledRepo()
+	postHandler := service.NewPostHandler(
+		postStorage,
+		postStorage,
+		communityStorage,
+		service.WithMedia(mediaHandler),
+		service.WithLinkPreviews(previewWorker),
+		service.WithSavedItems(inmem.NewSavedRepo()),
+		service.WithPreferences(userStorage),
+		service.WithScheduledPosts(scheduledStorage),
+		service.WithDrafts(inmem.NewDraftRepo()),
+		service.WithPins(inmem.NewPinRepo()),
+		service.WithAdmins("admin"),
+		service.WithArchiveAfter(180*24*time.Hour),
+		service.WithRepostPolicy(service.RepostPolicy{Window: 72 * time.Hour}),
+		service.WithMarkdown(markdown.NewRenderer(markdown.DefaultCacheSize)),
+		service.WithViewTracker(viewTracker),
+		service.WithStats(statsRecorder),
+	)
 	p := rest.NewPostHandler(postHandler, logger)
 
-	router := rest.NewAppRouter(u, p).InitRouter(logger)
+	scheduler := service.NewPostScheduler(postHandler, scheduledStorage, logger, service.SchedulerConfig{})
+	go scheduler.Run(context.Background())
+
+	router := rest.NewAppRouter(u, p, c, m).InitRouter(logger)
 
 	addr := fmt.Sprintf(":%d", *port)
 	logger.Infow(fmt.Sprintf("Starting server on %s", addr))
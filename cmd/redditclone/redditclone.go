@@ -6,18 +6,24 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-redis/redis"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/spf13/viper"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 
 	"github.com/Benzogang-Tape/Reddit/internal/config"
 	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
 	"github.com/Benzogang-Tape/Reddit/internal/service"
 	"github.com/Benzogang-Tape/Reddit/internal/storage"
 	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+	"github.com/Benzogang-Tape/Reddit/pkg/linkpreview"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
 )
 
 //	@title			Reddit-Clone API
@@ -77,13 +83,18 @@ func main() {
 		"%s%s",
 		v.GetString("mongo.uri"),
 		v.GetString("mongo.host"),
-	)))
+	)).SetRegistry(storage.NewRegistry()))
 
 	if err != nil {
 		panic(err)
 	}
 
 	postsDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.posts"))
+	commentsDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.comments"))
+	scheduledDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.scheduled"))
+	draftsDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.drafts"))
+	statsDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.stats"))
+	mentionsDB := sess.Database(v.GetString("mongo.initdb.database")).Collection(v.GetString("mongo.collection.mentions"))
 
 	sessionDB := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", v.GetString("redis.host"), v.GetString("redis.port")),
@@ -109,14 +120,152 @@ func main() {
 	userHandler := service.NewUserHandler(userStorage)
 	u := rest.NewUserHandler(userHandler, sessionHandler, logger)
 
+	communityStorage := storage.NewCommunityRepoMySQL(usersDB)
+	communityHandler := service.NewCommunityHandler(communityStorage)
+	c := rest.NewCommunityHandler(communityHandler, logger)
+
+	blobStore, err := newBlobStore(v)
+	if err != nil {
+		panic(err)
+	}
+	mediaHandler := service.NewMediaHandler(blobStore)
+	m := rest.NewMediaHandler(mediaHandler, logger)
+
 	mongoAbstraction := storage.NewMongoCollection(postsDB)
-	postStorage := storage.NewPostRepoMongoDB(mongoAbstraction)
-	postHandler := service.NewPostHandler(postStorage, postStorage)
+	postStorage := storage.NewPostRepoMongoDB(mongoAbstraction, storage.NewMongoCollection(commentsDB))
+	if err = postStorage.CreateIndexes(ctx); err != nil {
+		panic(err)
+	}
+	migrated, unparsed, err := postStorage.MigrateCreated(ctx)
+	switch {
+	case err != nil:
+		logger.Errorw("Failed to convert the timestamps of posts to dates", "err", err)
+	case migrated != 0:
+		logger.Infow("Converted the timestamps of posts to dates", "posts", migrated)
+	}
+	if unparsed != 0 {
+		logger.Warnw("Posts left with timestamps that are not dates", "posts", unparsed)
+	}
+	if migrated, err = postStorage.MigrateComments(ctx); err != nil {
+		panic(err)
+	}
+	if migrated != 0 {
+		logger.Infow("Moved the comments of posts to their own collection", "posts", migrated)
+	}
+	previewWorker := service.NewLinkPreviewWorker(
+		linkpreview.NewHTTPFetcher(linkpreview.Config{
+			Timeout:     v.GetDuration("link_preview.fetch_timeout"),
+			MaxBodySize: v.GetInt64("link_preview.max_body_size"),
+		}),
+		postStorage,
+		logger,
+		service.LinkPreviewConfig{
+			Workers:   v.GetInt("link_preview.workers"),
+			QueueSize: v.GetInt("link_preview.queue_size"),
+			Timeout:   v.GetDuration("link_preview.timeout"),
+		},
+	)
+	go previewWorker.Run(ctx)
+
+	scheduledStorage := storage.NewScheduledRepoMongoDB(storage.NewMongoCollection(scheduledDB))
+	if err = scheduledStorage.CreateIndexes(ctx); err != nil {
+		panic(err)
+	}
+
+	draftStorage := storage.NewDraftRepoMongoDB(storage.NewMongoCollection(draftsDB))
+	if err = draftStorage.CreateIndexes(ctx); err != nil {
+		panic(err)
+	}
+
+	viewTracker := service.NewViewTracker(
+		storage.NewViewCounterRedis(sessionDB),
+		postStorage,
+		logger,
+		service.ViewConfig{
+			Window:        v.GetDuration("views.window"),
+			FlushInterval: v.GetDuration("views.flush_interval"),
+		},
+	)
+	go viewTracker.Run(ctx)
+
+	statsStorage := storage.NewStatsRepoMongoDB(storage.NewMongoCollection(statsDB), v.GetDuration("stats.retention"))
+	if err = statsStorage.CreateIndexes(ctx); err != nil {
+		panic(err)
+	}
+	statsRecorder := service.NewStatsRecorder(statsStorage, logger, service.StatsConfig{
+		FlushInterval: v.GetDuration("stats.flush_interval"),
+	})
+	go statsRecorder.Run(ctx)
+
+	mentionStorage := storage.NewMentionRepoMongoDB(storage.NewMongoCollection(mentionsDB))
+	if err = mentionStorage.CreateIndexes(ctx); err != nil {
+		panic(err)
+	}
+
+	postHandler := service.NewPostHandler(
+		postStorage,
+		postStorage,
+		communityStorage,
+		service.WithMedia(mediaHandler),
+		service.WithLinkPreviews(previewWorker),
+		service.WithSavedItems(storage.NewSavedRepoMySQL(usersDB)),
+		service.WithPreferences(userStorage),
+		service.WithScheduledPosts(scheduledStorage),
+		service.WithDrafts(draftStorage),
+		service.WithPins(storage.NewPinRepoMySQL(usersDB)),
+		service.WithAdmins(admins(v)...),
+		service.WithArchiveAfter(v.GetDuration("posts.archive_after")),
+		service.WithRepostPolicy(service.RepostPolicy{
+			Window: v.GetDuration("posts.repost_window"),
+			Reject: v.GetBool("posts.reject_reposts"),
+		}),
+		service.WithMarkdown(markdown.NewRenderer(v.GetInt("posts.markdown_cache_size"))),
+		service.WithViewTracker(viewTracker),
+		service.WithStats(statsRecorder),
+		service.WithCommentDepth(v.GetInt("posts.comment_depth")),
+		service.WithMentions(userStorage, mentionStorage),
+	)
 	p := rest.NewPostHandler(postHandler, logger)
 
-	router := rest.NewAppRouter(u, p).InitRouter(logger)
+	scheduler := service.NewPostScheduler(
+		postHandler,
+		scheduledStorage,
+		logger,
+		service.SchedulerConfig{
+			Interval:  v.GetDuration("scheduler.interval"),
+			BatchSize: v.GetInt("scheduler.batch_size"),
+			Lease:     v.GetDuration("scheduler.lease"),
+		},
+	)
+	go scheduler.Run(ctx)
+
+	router := rest.NewAppRouter(u, p, c, m).InitRouter(logger)
 
 	addr := fmt.Sprintf(":%s", v.GetString("app.port"))
 	logger.Infow(fmt.Sprintf("Starting server on %s", addr))
 	log.Panic(http.ListenAndServe(addr, router))
 }
+
+func newBlobStore(v *viper.Viper) (service.BlobStore, error) {
+	if v.GetString("media.storage") == "s3" {
+		return storage.NewBlobStoreS3(storage.S3Config{
+			Endpoint:  v.GetString("media.s3.endpoint"),
+			Region:    v.GetString("media.s3.region"),
+			Bucket:    v.GetString("media.s3.bucket"),
+			AccessKey: v.GetString("media.s3.access_key"),
+			SecretKey: v.GetString("media.s3.secret_key"),
+		}, &http.Client{Timeout: 30 * time.Second}), nil
+	}
+
+	return storage.NewBlobStoreFS(v.GetString("media.root"))
+}
+
+func admins(v *viper.Viper) []users.Username {
+	logins := strings.Fields(v.GetString("app.admins"))
+	admins := make([]users.Username, 0, len(logins))
+	for _, login := range logins {
+		admins = append(admins, users.Username(login))
+	}
+
+	return admins
+}
@@ -0,0 +1,63 @@
+SET NAMES utf8;
+SET time_zone = '+00:00';
+SET foreign_key_checks = 0;
+SET sql_mode = 'NO_AUTO_VALUE_ON_ZERO';
+
+DROP TABLE IF EXISTS `communities`;
+CREATE TABLE `communities` (
+  `id` int(8) NOT NULL AUTO_INCREMENT,
+  `uuid` varchar(37) UNIQUE NOT NULL,
+  `name` varchar(21) UNIQUE NOT NULL,
+  `description` varchar(511) NOT NULL DEFAULT '',
+  `creator_uuid` varchar(37) NOT NULL,
+  `creator_login` varchar(127) NOT NULL,
+  `nsfw` boolean NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+DROP TABLE IF EXISTS `community_moderators`;
+CREATE TABLE `community_moderators` (
+  `community_uuid` varchar(37) NOT NULL,
+  `user_uuid` varchar(37) NOT NULL,
+  `user_login` varchar(127) NOT NULL,
+  PRIMARY KEY (`community_uuid`, `user_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+DROP TABLE IF EXISTS `community_rules`;
+CREATE TABLE `community_rules` (
+  `id` int(8) NOT NULL AUTO_INCREMENT,
+  `community_uuid` varchar(37) NOT NULL,
+  `position` int(4) NOT NULL,
+  `title` varchar(127) NOT NULL,
+  `description` varchar(511) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `community_uuid` (`community_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+DROP TABLE IF EXISTS `community_flairs`;
+CREATE TABLE `community_flairs` (
+  `id` int(8) NOT NULL AUTO_INCREMENT,
+  `uuid` varchar(37) UNIQUE NOT NULL,
+  `community_uuid` varchar(37) NOT NULL,
+  `text` varchar(255) NOT NULL,
+  `color` varchar(7) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `community_uuid` (`community_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- The categories that used to be hardcoded in the app are seeded as communities moderated by admin
+INSERT INTO `communities` (`id`, `uuid`, `name`, `description`, `creator_uuid`, `creator_login`) VALUES
+(1,	'00000000-0000-0000-0000-000000000001',	'music',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+(2,	'00000000-0000-0000-0000-000000000002',	'funny',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+(3,	'00000000-0000-0000-0000-000000000003',	'videos',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+(4,	'00000000-0000-0000-0000-000000000004',	'programming',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+(5,	'00000000-0000-0000-0000-000000000005',	'news',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+(6,	'00000000-0000-0000-0000-000000000006',	'fashion',	'',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin');
+
+INSERT INTO `community_moderators` (`community_uuid`, `user_uuid`, `user_login`) VALUES
+('00000000-0000-0000-0000-000000000001',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+('00000000-0000-0000-0000-000000000002',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+('00000000-0000-0000-0000-000000000003',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+('00000000-0000-0000-0000-000000000004',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+('00000000-0000-0000-0000-000000000005',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin'),
+('00000000-0000-0000-0000-000000000006',	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin');
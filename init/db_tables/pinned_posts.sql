@@ -0,0 +1,15 @@
+SET NAMES utf8;
+SET time_zone = '+00:00';
+SET foreign_key_checks = 0;
+SET sql_mode = 'NO_AUTO_VALUE_ON_ZERO';
+
+DROP TABLE IF EXISTS `pinned_posts`;
+CREATE TABLE `pinned_posts` (
+  `scope` varchar(64) NOT NULL,
+  `post_uuid` varchar(37) NOT NULL,
+  `position` int(11) NOT NULL,
+  `pinned_at` bigint(20) NOT NULL,
+  `expires_at` bigint(20) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`scope`, `post_uuid`),
+  KEY `scope_position` (`scope`, `position`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,17 @@
+SET NAMES utf8;
+SET time_zone = '+00:00';
+SET foreign_key_checks = 0;
+SET sql_mode = 'NO_AUTO_VALUE_ON_ZERO';
+
+DROP TABLE IF EXISTS `saved_items`;
+CREATE TABLE `saved_items` (
+  `user_uuid` varchar(37) NOT NULL,
+  `item_uuid` varchar(37) NOT NULL,
+  `item_type` varchar(15) NOT NULL,
+  `post_uuid` varchar(37) NOT NULL,
+  `saved_at` bigint(20) NOT NULL,
+  PRIMARY KEY (`user_uuid`, `item_uuid`),
+  KEY `user_saved_at` (`user_uuid`, `saved_at`),
+  KEY `item_uuid` (`item_uuid`),
+  KEY `post_uuid` (`post_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
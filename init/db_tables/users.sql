@@ -12,6 +12,13 @@ CREATE TABLE `users` (
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+DROP TABLE IF EXISTS `user_preferences`;
+CREATE TABLE `user_preferences` (
+  `user_uuid` varchar(37) NOT NULL,
+  `show_nsfw` boolean NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (`user_uuid`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 INSERT INTO `users` (`id`, `uuid`, `login`, `password`) VALUES
 (1,	'ffffffff-ffff-ffff-ffff-ffffffffffff',	'admin',	'rootroot'),
 (2,	'12345678-9abc-def1-2345-6789abcdef12',	'test_user',	'useruser');
@@ -0,0 +1,132 @@
+package communities
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Community model info
+//
+// @Description Community is a user-created thread to which posts belong
+type Community struct {
+	ID          users.ID           `json:"id" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Name        string             `json:"name" example:"music" minLength:"3" maxLength:"21"`
+	Description string             `json:"description" example:"Everything about music"`
+	Creator     jwt.TokenPayload   `json:"creator"`              // User who created the Community
+	Moderators  []jwt.TokenPayload `json:"moderators"`           // Users allowed to manage the Community, the creator is always among them
+	Rules       []Rule             `json:"rules"`                // Rules that the Community members must follow
+	Flairs      []Flair            `json:"flairs"`               // Flairs the authors may pick for their posts
+	NSFW        bool               `json:"nsfw" example:"false"` // Posts of the Community are NSFW by default
+}
+
+// Rule model info
+//
+// @Description Rule is a single rule of the Community
+type Rule struct {
+	Title       string `json:"title" example:"Be nice"`
+	Description string `json:"description,omitempty" example:"No insults or harassment"`
+}
+
+// Flair model info
+//
+// @Description Flair is a template of a tag the authors may put on their posts in the Community
+type Flair struct {
+	ID    users.ID `json:"id" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Text  string   `json:"text" example:"Discussion" maxLength:"64"`
+	Color string   `json:"color" example:"#ff4500"` // Background color of the flair
+}
+
+// FlairPayload model info
+//
+// @Description FlairPayload contains the necessary information to create a Flair
+type FlairPayload struct {
+	Text  string `json:"text" example:"Discussion" maxLength:"64"`
+	Color string `json:"color" example:"#ff4500"` // Hex color in the #rrggbb form
+}
+
+// CommunityPayload model info
+//
+// @Description CommunityPayload contains the necessary information to create a community
+type CommunityPayload struct {
+	Name        string `json:"name" example:"music" minLength:"3" maxLength:"21"`
+	Description string `json:"description" example:"Everything about music" maxLength:"500"`
+	Rules       []Rule `json:"rules"`
+	NSFW        bool   `json:"nsfw,omitempty" example:"false"` // Mark all the posts of the community as NSFW by default
+}
+
+const (
+	MaxDescriptionLength int = 500
+	MaxRulesCount        int = 15
+	MaxRuleTitleLength   int = 100
+	MaxFlairsCount       int = 50
+	MaxFlairTextLength   int = 64
+)
+
+var (
+	NameTemplate  = regexp.MustCompile(`^[0-9a-zA-Z_-]{3,21}$`)
+	ColorTemplate = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+func NewCommunity(creator jwt.TokenPayload, payload CommunityPayload) *Community {
+	rules := payload.Rules
+	if rules == nil {
+		rules = make([]Rule, 0)
+	}
+
+	return &Community{
+		ID:          users.ID(uuid.New().String()),
+		Name:        payload.Name,
+		Description: payload.Description,
+		Creator:     creator,
+		Moderators:  []jwt.TokenPayload{creator},
+		Rules:       rules,
+		Flairs:      make([]Flair, 0),
+		NSFW:        payload.NSFW,
+	}
+}
+
+// Validate trims the text of the flair and checks the flair can be added to the community
+func (fp *FlairPayload) Validate() error {
+	fp.Text = strings.TrimSpace(fp.Text)
+	if fp.Text == "" || utf8.RuneCountInString(fp.Text) > MaxFlairTextLength || !ColorTemplate.MatchString(fp.Color) {
+		return errs.ErrBadFlair
+	}
+	fp.Color = strings.ToLower(fp.Color)
+
+	return nil
+}
+
+func NewFlair(payload FlairPayload) Flair {
+	return Flair{
+		ID:    users.ID(uuid.New().String()),
+		Text:  payload.Text,
+		Color: payload.Color,
+	}
+}
+
+func (c *Community) GetFlair(flairID users.ID) (Flair, error) {
+	for _, flair := range c.Flairs {
+		if flair.ID == flairID {
+			return flair, nil
+		}
+	}
+
+	return Flair{}, errs.ErrInvalidFlair
+}
+
+func (c *Community) IsModerator(userID users.ID) bool {
+	for _, moderator := range c.Moderators {
+		if moderator.ID == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -8,27 +8,71 @@ import (
 )
 
 var (
-	ErrNoUser              = errors.New("user not found")
-	ErrNoSession           = errors.New("session not found")
-	ErrInternalServerError = errors.New("internal server error")
-	ErrBadPass             = errors.New("invalid password")
-	ErrUserExists          = errors.New("username already exist")
-	ErrBadToken            = errors.New("bad token")
-	ErrNoPayload           = errors.New("no payload")
-	ErrBadPayload          = errors.New("bad payload")
-	ErrInvalidURL          = errors.New("url is invalid")
-	ErrResponseError       = errors.New("response generation error")
-	ErrPostNotFound        = errors.New("post not found")
-	ErrCommentNotFound     = errors.New("comment not found")
-	ErrBadID               = errors.New("bad id")
-	ErrInvalidPostID       = errors.New("invalid post id")
-	ErrInvalidCommentID    = errors.New("invalid comment id")
-	ErrInvalidCategory     = errors.New("invalid category")
-	ErrInvalidPostType     = errors.New("invalid post type")
-	ErrVoteNotFound        = errors.New("no votes from the requested user")
-	ErrBadCommentBody      = errors.New("comment body is required")
-	ErrUnknownPayload      = errors.New("unknown payload")
-	ErrUnknownError        = errors.New("unknown error")
+	ErrNoUser                 = errors.New("user not found")
+	ErrNoSession              = errors.New("session not found")
+	ErrInternalServerError    = errors.New("internal server error")
+	ErrBadPass                = errors.New("invalid password")
+	ErrUserExists             = errors.New("username already exist")
+	ErrBadToken               = errors.New("bad token")
+	ErrNoPayload              = errors.New("no payload")
+	ErrBadPayload             = errors.New("bad payload")
+	ErrInvalidURL             = errors.New("url is invalid")
+	ErrResponseError          = errors.New("response generation error")
+	ErrPostNotFound           = errors.New("post not found")
+	ErrCommentNotFound        = errors.New("comment not found")
+	ErrBadID                  = errors.New("bad id")
+	ErrInvalidPostID          = errors.New("invalid post id")
+	ErrInvalidCommentID       = errors.New("invalid comment id")
+	ErrInvalidCategory        = errors.New("invalid category")
+	ErrInvalidPostType        = errors.New("invalid post type")
+	ErrVoteNotFound           = errors.New("no votes from the requested user")
+	ErrVoteConflict           = errors.New("vote has been changed concurrently")
+	ErrBadCommentBody         = errors.New("comment body is required")
+	ErrUnknownPayload         = errors.New("unknown payload")
+	ErrUnknownError           = errors.New("unknown error")
+	ErrCommunityNotFound      = errors.New("community not found")
+	ErrCommunityExists        = errors.New("community already exists")
+	ErrInvalidCommunity       = errors.New("invalid community name")
+	ErrBadDescription         = errors.New("description is too long")
+	ErrBadRules               = errors.New("invalid community rules")
+	ErrBadSearchQuery         = errors.New("invalid search query")
+	ErrBlobNotFound           = errors.New("media not found")
+	ErrImageTooLarge          = errors.New("image is too large")
+	ErrUnsupportedMedia       = errors.New("unsupported media type")
+	ErrBadImage               = errors.New("image is corrupted")
+	ErrBadPoll                = errors.New("invalid poll")
+	ErrNotAPoll               = errors.New("post is not a poll")
+	ErrPollClosed             = errors.New("poll is closed")
+	ErrBadBallot              = errors.New("invalid poll choice")
+	ErrBallotConflict         = errors.New("ballot has been changed concurrently")
+	ErrBadCrosspost           = errors.New("crosspost must be made to another community")
+	ErrCrosspostSourceRemoved = errors.New("original post has been removed")
+	ErrBadItemType            = errors.New("invalid item type")
+	ErrFeatureDisabled        = errors.New("feature is disabled")
+	ErrBadFlair               = errors.New("flair must have a text of up to 64 characters and a #rrggbb color")
+	ErrInvalidFlair           = errors.New("flair not found in the community")
+	ErrTooManyFlairs          = errors.New("community has too many flairs")
+	ErrNotModerator           = errors.New("user is not a moderator of the community")
+	ErrNotAuthor              = errors.New("user is not the author of the post")
+	ErrBadPublishTime         = errors.New("publish time must be in the future and at most 90 days from now")
+	ErrScheduledPostNotFound  = errors.New("scheduled post not found")
+	ErrDraftNotFound          = errors.New("draft not found")
+	ErrTooManyDrafts          = errors.New("user has too many drafts")
+	ErrBadPin                 = errors.New("pin must have a non-negative position and expire in the future")
+	ErrTooManyPins            = errors.New("too many posts are pinned")
+	ErrPinNotFound            = errors.New("post is not pinned")
+	ErrNotAdmin               = errors.New("user is not an administrator")
+	ErrPostLocked             = errors.New("post is locked")
+	ErrVotingFrozen           = errors.New("voting on the post is frozen")
+	ErrPostArchived           = errors.New("post is archived")
+	ErrRepost                 = errors.New("link has already been posted to the category recently")
+	ErrBadStatsQuery          = errors.New("bad stats query")
+	ErrBadFeedQuery           = errors.New("invalid feed query")
+	ErrBadParentComment       = errors.New("parent comment not found on the post")
+	ErrNotCommentAuthor       = errors.New("user is not the author of the comment")
+	ErrBadCommentQuery        = errors.New("invalid comment listing query")
+	ErrBadCommentCursor       = errors.New("comment to continue after is not a top-level comment of the post")
+	ErrTooManyMentions        = errors.New("too many users are mentioned")
 )
 
 type RespError interface {
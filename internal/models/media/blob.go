@@ -0,0 +1,22 @@
+package media
+
+import (
+	"time"
+)
+
+// BlobInfo describes a stored blob
+type BlobInfo struct {
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+const (
+	MaxImageSize   int64 = 10 << 20   // Bytes
+	MaxImagePixels int   = 40_000_000 // Of all the frames together
+	MaxImageFrames int   = 500
+	ThumbnailSide  int   = 320 // Pixels
+
+	URLPrefix    = "/api/media/"
+	CacheControl = "public, max-age=31536000, immutable"
+)
@@ -0,0 +1,89 @@
+package posts
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// trackingParams are dropped from the links along with all the utm_* parameters
+var trackingParams = []string{"fbclid", "gclid", "dclid", "yclid", "msclkid", "mc_cid", "mc_eid", "igshid", "ref_src"}
+
+// shorteners expand the links of well-known URL shorteners whose targets can be told without a request
+var shorteners = map[string]func(u *url.URL){
+	"youtu.be": func(u *url.URL) {
+		query := u.Query()
+		query.Set("v", strings.Trim(u.Path, "/"))
+		u.Host, u.Path, u.RawQuery = "youtube.com", "/watch", query.Encode()
+	},
+	"redd.it": func(u *url.URL) {
+		u.Host, u.Path = "reddit.com", "/comments/"+strings.Trim(u.Path, "/")
+	},
+	"youtube.com": func(u *url.URL) {
+		if id, ok := strings.CutPrefix(u.Path, "/shorts/"); ok {
+			query := u.Query()
+			query.Set("v", strings.Trim(id, "/"))
+			u.Path, u.RawQuery = "/watch", query.Encode()
+		}
+	},
+	"m.youtube.com": func(u *url.URL) {
+		u.Host = "youtube.com"
+	},
+	"old.reddit.com": func(u *url.URL) {
+		u.Host = "reddit.com"
+	},
+}
+
+// CanonicalURL brings the link to the form shared by all the links to the same page: https, a lowercase host
+// without www and default ports, no tracking parameters, sorted query, no trailing slash or fragment
+func CanonicalURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "", errs.ErrInvalidURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host = net.JoinHostPort(host, port)
+	}
+	u.Scheme, u.Host, u.User, u.Fragment, u.RawFragment = "https", host, nil, "", ""
+	if expand, ok := shorteners[u.Host]; ok {
+		expand(u)
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || slices.Contains(trackingParams, strings.ToLower(key)) {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.Path, u.RawPath = strings.TrimRight(u.Path, "/"), ""
+
+	return u.String(), nil
+}
+
+// Reposts returns the ids of the posts of the list made to the category within the window before now
+func Reposts(postList []*Post, category PostCategory, window time.Duration, now time.Time) []users.ID {
+	reposts := make([]users.ID, 0)
+	for _, post := range postList {
+		if post.Category != category {
+			continue
+		}
+		if now.Sub(post.Created) > window {
+			continue
+		}
+		reposts = append(reposts, post.ID)
+	}
+
+	return reposts
+}
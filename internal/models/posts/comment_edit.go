@@ -0,0 +1,29 @@
+package posts
+
+import (
+	"time"
+)
+
+// CommentRevision model info
+//
+// @Description CommentRevision is a body the comment had before it was edited
+type CommentRevision struct {
+	Body    string    `json:"body" bson:"body" example:"Some comment body example"`
+	Created time.Time `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"` // Date the body was written
+}
+
+// Edit replaces the body of the comment, the body it had is kept as a revision
+func (c *PostComment) Edit(comment Comment, now time.Time) *CommentRevision {
+	revision := &CommentRevision{
+		Body:    c.Body,
+		Created: c.Created,
+	}
+	if !c.Edited.IsZero() {
+		revision.Created = c.Edited
+	}
+
+	c.Revisions = append(c.Revisions, revision)
+	c.Body, c.BodyHTML, c.Mentions, c.Edited = comment.Body, comment.BodyHTML, comment.Mentions, now
+
+	return revision
+}
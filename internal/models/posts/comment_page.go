@@ -0,0 +1,174 @@
+package posts
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// CommentSort type
+//
+// @Description CommentSort is the order of the comments: best, top, new, controversial or old
+type CommentSort string
+
+const (
+	SortBest          CommentSort = "best"
+	SortTop           CommentSort = "top"
+	SortNew           CommentSort = "new"
+	SortControversial CommentSort = "controversial"
+	SortOld           CommentSort = "old"
+)
+
+const (
+	DefaultCommentLimit int = 50
+	MaxCommentLimit     int = 200
+)
+
+// WilsonZ is the z-score of the 80% confidence the "best" order is computed with
+const WilsonZ = 1.281551565545
+
+// CommentQuery selects a page of the top-level comments of a post
+type CommentQuery struct {
+	Sort  CommentSort // Order of the comments and of the replies to them
+	Limit int         // Number of top-level comments on the page
+	After users.ID    // Top-level comment the previous page ended with, empty for the first page
+}
+
+// CommentPage model info
+//
+// @Description CommentPage is a page of the comment trees of a post
+type CommentPage struct {
+	Comments []*PostComment `json:"comments"`                                                                                     // Top-level comments with the replies to them
+	After    users.ID       `json:"after,omitempty" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"` // Cursor of the next page, missing on the last one
+}
+
+// NewCommentQuery returns the query with the order checked and the defaults filled in
+func NewCommentQuery(sort CommentSort, limit int, after users.ID) (CommentQuery, error) {
+	switch sort {
+	case "":
+		sort = SortBest
+	case SortBest, SortTop, SortNew, SortControversial, SortOld:
+	default:
+		return CommentQuery{}, errs.ErrBadCommentQuery
+	}
+	if limit <= 0 {
+		limit = DefaultCommentLimit
+	}
+
+	return CommentQuery{
+		Sort:  sort,
+		Limit: min(limit, MaxCommentLimit),
+		After: after,
+	}, nil
+}
+
+// CommentPage returns the page of the top-level comments followed by all the replies under them, the way the storage
+// hands the comments out. The replies are left unsorted and unnested, CommentTrees puts them in place
+func (p *Post) CommentPage(query CommentQuery) (*CommentPage, error) {
+	children := p.children()
+	top := slices.Clone(children[""])
+	slices.SortStableFunc(top, commentOrder(query.Sort))
+
+	start := 0
+	if query.After != "" {
+		idx := slices.IndexFunc(top, func(comment *PostComment) bool {
+			return comment.ID == query.After
+		})
+		if idx == -1 {
+			return nil, errs.ErrBadCommentCursor
+		}
+		start = idx + 1
+	}
+	end := min(start+query.Limit, len(top))
+
+	page := &CommentPage{Comments: slices.Clone(top[start:end])}
+	for _, comment := range top[start:end] {
+		page.Comments = append(page.Comments, descendants(children, comment.ID)...)
+	}
+	if end < len(top) {
+		page.After = top[end-1].ID
+	}
+
+	return page, nil
+}
+
+// CommentTrees nests the replies under the top-level comments, at most maxDepth levels deep. The top-level comments
+// keep their order, the replies are sorted in the order given
+func (p *Post) CommentTrees(sort CommentSort, maxDepth int) []*PostComment {
+	children := p.children()
+	for parentID, siblings := range children {
+		if parentID != "" {
+			slices.SortStableFunc(siblings, commentOrder(sort))
+		}
+	}
+
+	trees := make([]*PostComment, 0, len(children[""]))
+	for _, comment := range children[""] {
+		trees = append(trees, nest(children, comment, maxDepth))
+	}
+
+	return trees
+}
+
+// commentOrder returns the comparison of the comments in the order. Ties keep the order the comments were left in
+func commentOrder(sort CommentSort) func(a, b *PostComment) int {
+	byScore := func(score func(*PostComment) float64) func(a, b *PostComment) int {
+		return func(a, b *PostComment) int {
+			return cmp.Compare(score(b), score(a))
+		}
+	}
+
+	switch sort {
+	case SortTop:
+		return func(a, b *PostComment) int { return cmp.Compare(b.Score, a.Score) }
+	case SortNew:
+		return func(a, b *PostComment) int { return b.Created.Compare(a.Created) }
+	case SortOld:
+		return func(a, b *PostComment) int { return a.Created.Compare(b.Created) }
+	case SortControversial:
+		return byScore((*PostComment).controversy)
+	default:
+		return byScore((*PostComment).confidence)
+	}
+}
+
+// tally counts the upvotes and the downvotes of the comment
+func (c *PostComment) tally() (ups, downs float64) {
+	for _, vote := range c.Votes {
+		switch {
+		case vote.Vote > 0:
+			ups++
+		case vote.Vote < 0:
+			downs++
+		}
+	}
+
+	return ups, downs
+}
+
+// confidence is the lower bound of the Wilson score interval of the share of upvotes, the "best" order
+func (c *PostComment) confidence() float64 {
+	ups, downs := c.tally()
+	n := ups + downs
+	if n == 0 {
+		return 0
+	}
+
+	phat := ups / n
+	z2 := WilsonZ * WilsonZ
+
+	return (phat + z2/(2*n) - WilsonZ*math.Sqrt((phat*(1-phat)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// controversy grows with the number of votes and with how evenly they are split
+func (c *PostComment) controversy() float64 {
+	ups, downs := c.tally()
+	if ups == 0 || downs == 0 {
+		return 0
+	}
+
+	return math.Pow(ups+downs, min(ups, downs)/max(ups, downs))
+}
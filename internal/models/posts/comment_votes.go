@@ -0,0 +1,65 @@
+package posts
+
+import (
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Upvote puts the up vote of the user on the comment. Reports whether the user has not voted on it before
+func (c *PostComment) Upvote(userID users.ID) (*PostVote, bool) {
+	return c.vote(userID, upVote)
+}
+
+// Downvote puts the down vote of the user on the comment. Reports whether the user has not voted on it before
+func (c *PostComment) Downvote(userID users.ID) (*PostVote, bool) {
+	return c.vote(userID, downVote)
+}
+
+// Unvote takes the vote of the user back
+func (c *PostComment) Unvote(userID users.ID) error {
+	vote, ok := c.Votes[userID]
+	if !ok {
+		return errs.ErrVoteNotFound
+	}
+
+	c.Score -= int(vote.Vote)
+	delete(c.Votes, userID)
+
+	return nil
+}
+
+// CheckVotable returns an error if the comment may not be voted on. Placeholders of deleted comments are no comments to vote on
+func (c *PostComment) CheckVotable() error {
+	if c.Deleted {
+		return errs.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// VoteOf returns the vote the user has put on the comment, zero if none
+func (c *PostComment) VoteOf(userID users.ID) Vote {
+	if vote, ok := c.Votes[userID]; ok {
+		return vote.Vote
+	}
+
+	return 0
+}
+
+func (c *PostComment) vote(userID users.ID, vote Vote) (*PostVote, bool) {
+	if c.Votes == nil {
+		c.Votes = make(Votes)
+	}
+	current, ok := c.Votes[userID]
+	if !ok {
+		current = NewPostVote(userID, vote)
+		c.Votes[userID] = current
+		c.Score += int(vote)
+		return current, true
+	}
+
+	c.Score += int(vote - current.Vote)
+	current.Vote = vote
+
+	return current, false
+}
@@ -0,0 +1,124 @@
+package posts
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// CrosspostParent model info
+//
+// @Description CrosspostParent is a summary of the Post a crosspost was made from. Only the id is left once the original is deleted
+type CrosspostParent struct {
+	ID       users.ID          `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Title    string            `json:"title,omitempty" bson:"title,omitempty" example:"Awesome title"`
+	Category PostCategory      `json:"category,omitempty" bson:"category,omitempty" example:"music"`
+	Author   *jwt.TokenPayload `json:"author,omitempty" bson:"author,omitempty"`
+	Created  time.Time         `json:"created,omitzero" bson:"created,omitempty" example:"2006-01-02T15:04:05.999Z" format:"date-time"`
+	Removed  bool              `json:"removed" bson:"removed" example:"false"` // The original Post has been deleted
+}
+
+// CrosspostRef model info
+//
+// @Description CrosspostRef points to a crosspost of the Post
+type CrosspostRef struct {
+	ID       users.ID     `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Category PostCategory `json:"category" bson:"category" example:"funny"`
+}
+
+// CrosspostPayload model info
+//
+// @Description CrosspostPayload contains the community to crosspost to and an optional new title
+type CrosspostPayload struct {
+	Category PostCategory `json:"category" example:"funny"`                    // Name of the community to crosspost to
+	Title    string       `json:"title,omitempty" example:"Look at this post"` // Title of the original Post if empty
+}
+
+// NewCrosspost copies the content of the parent into a new post of another community.
+// Polls are not copied, the votes stay with the original: crossposts of polls are text posts
+func NewCrosspost(author jwt.TokenPayload, parent *Post, payload CrosspostPayload) *Post {
+	title := payload.Title
+	if title == "" {
+		title = parent.Title
+	}
+	postType := parent.Type
+	if postType == WithPoll {
+		postType = WithText
+	}
+
+	crosspost := NewPost(author, PostPayload{
+		Type:         postType,
+		Title:        title,
+		URL:          parent.URL,
+		CanonicalURL: parent.CanonicalURL,
+		Image:        parent.Image,
+		Category:     payload.Category,
+		Text:         parent.Text,
+		TextHTML:     parent.TextHTML,
+		Mentions:     parent.Mentions,
+		NSFW:         parent.NSFW,
+		Spoiler:      parent.Spoiler,
+	})
+	crosspost.CrosspostParent = parent.Summary()
+
+	return crosspost
+}
+
+// CrosspostSource returns the post a crosspost of p has to point to: crossposts of crossposts refer to the original
+func (p *Post) CrosspostSource() (users.ID, error) {
+	if p.CrosspostParent == nil {
+		return p.ID, nil
+	}
+	if p.CrosspostParent.Removed {
+		return "", errs.ErrCrosspostSourceRemoved
+	}
+
+	return p.CrosspostParent.ID, nil
+}
+
+func (p *Post) Summary() *CrosspostParent {
+	author := p.Author
+	return &CrosspostParent{
+		ID:       p.ID,
+		Title:    p.Title,
+		Category: p.Category,
+		Author:   &author,
+		Created:  p.Created,
+	}
+}
+
+func (p *Post) AddCrosspost(crosspost *Post) *CrosspostRef {
+	ref := &CrosspostRef{
+		ID:       crosspost.ID,
+		Category: crosspost.Category,
+	}
+	p.Crossposts = append(p.Crossposts, ref)
+	p.CrosspostCount = len(p.Crossposts)
+
+	return ref
+}
+
+func (p *Post) RemoveCrosspost(crosspostID users.ID) {
+	p.Crossposts = slices.DeleteFunc(p.Crossposts, func(ref *CrosspostRef) bool {
+		return ref.ID == crosspostID
+	})
+	p.CrosspostCount = len(p.Crossposts)
+}
+
+// RemoveSource puts the crosspost into the "source removed" state. The copied content belonged to the
+// author of the original, so it goes away together with it
+func (p *Post) RemoveSource() {
+	p.CrosspostParent = RemovedCrosspostParent(p.CrosspostParent.ID)
+	p.Text = ""
+	p.Image = nil
+}
+
+func RemovedCrosspostParent(parentID users.ID) *CrosspostParent {
+	return &CrosspostParent{
+		ID:      parentID,
+		Removed: true,
+	}
+}
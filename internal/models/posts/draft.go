@@ -0,0 +1,48 @@
+package posts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const MaxDrafts = 50
+
+// Draft model info
+//
+// @Description Draft is an unfinished Post autosaved for its author. It is validated only once published
+type Draft struct {
+	ID      users.ID         `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Author  jwt.TokenPayload `json:"author" bson:"author"`
+	Post    PostPayload      `json:"post" bson:"post"` // Content saved so far, any field may be missing
+	Created time.Time        `json:"created" bson:"created" format:"date-time"`
+	Updated time.Time        `json:"updated" bson:"updated" format:"date-time"` // When the Draft was last saved
+}
+
+func NewDraft(author jwt.TokenPayload, payload PostPayload) *Draft {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return &Draft{
+		ID:      users.ID(uuid.New().String()),
+		Author:  author,
+		Post:    draftContent(payload),
+		Created: now,
+		Updated: now,
+	}
+}
+
+// Update replaces the content of the draft
+func (d *Draft) Update(payload PostPayload) {
+	d.Post = draftContent(payload)
+	d.Updated = time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// draftContent drops the fields that are set by the app rather than by the author
+func draftContent(payload PostPayload) PostPayload {
+	payload.Image = nil
+	payload.Flair = nil
+	payload.PublishAt = nil
+	return payload
+}
@@ -0,0 +1,64 @@
+package posts
+
+import (
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Flair model info
+//
+// @Description Flair is the tag of the Post, copied from the template of the community when picked
+type Flair struct {
+	ID    users.ID `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Text  string   `json:"text" bson:"text" example:"Discussion"`
+	Color string   `json:"color" bson:"color" example:"#ff4500"`
+}
+
+// FlairPayload model info
+//
+// @Description FlairPayload contains the flair to put on the Post, an empty id removes the flair
+type FlairPayload struct {
+	FlairID users.ID `json:"flairId" example:"12345678-9abc-def1-2345-6789abcdef12"`
+}
+
+// FlagsPayload model info
+//
+// @Description FlagsPayload contains the flags to put on the Post
+type FlagsPayload struct {
+	NSFW    bool `json:"nsfw" example:"false"`
+	Spoiler bool `json:"spoiler" example:"true"`
+}
+
+// FeedQuery narrows down a feed of posts
+type FeedQuery struct {
+	Flair string    // Exact text of the flair, any post if empty
+	NSFW  bool      // Whether NSFW posts are included, set from the preferences of the viewer
+	From  time.Time // Created not earlier than, no limit if zero
+	To    time.Time // Created not later than, no limit if zero
+}
+
+// Matches reports whether the post passes all the filters of the query
+func (q FeedQuery) Matches(post *Post) bool {
+	return (q.Flair == "" || (post.Flair != nil && post.Flair.Text == q.Flair)) &&
+		(q.NSFW || !post.NSFW) &&
+		post.CreatedWithin(q.From, q.To)
+}
+
+// CreatedWithin reports whether the post has been created between from and to. A zero bound is no bound
+func (p *Post) CreatedWithin(from, to time.Time) bool {
+	return (from.IsZero() || !p.Created.Before(from)) && (to.IsZero() || !p.Created.After(to))
+}
+
+// WithoutSpoiler returns the post with the text and the users mentioned in it withheld if it is a spoiler.
+// The post is copied only if the text is withheld
+func (p *Post) WithoutSpoiler() *Post {
+	if !p.Spoiler || p.Text == "" {
+		return p
+	}
+
+	view := *p
+	view.Text, view.TextHTML, view.Mentions = "", "", nil
+
+	return &view
+}
@@ -0,0 +1,31 @@
+package posts
+
+import (
+	"slices"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Hide removes the post from the feeds of the user. It reports whether the post was visible before
+func (p *Post) Hide(userID users.ID) bool {
+	if slices.Contains(p.HiddenBy, userID) {
+		return false
+	}
+	p.HiddenBy = append(p.HiddenBy, userID)
+
+	return true
+}
+
+// Unhide brings the post back to the feeds of the user. It reports whether the post was hidden before
+func (p *Post) Unhide(userID users.ID) bool {
+	hiddenBefore := len(p.HiddenBy)
+	p.HiddenBy = slices.DeleteFunc(p.HiddenBy, func(id users.ID) bool {
+		return id == userID
+	})
+
+	return hiddenBefore != len(p.HiddenBy)
+}
+
+func (p *Post) IsHiddenBy(userID users.ID) bool {
+	return userID != "" && slices.Contains(p.HiddenBy, userID)
+}
@@ -0,0 +1,24 @@
+package posts
+
+import (
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Karma sums up the votes other users have put on the posts and the comments of the user.
+// The votes of the author on his/her own posts and comments do not count
+func Karma(login users.Username, postList []*Post, comments []*PostComment) users.Karma {
+	karma := users.Karma{}
+	for _, post := range postList {
+		if post.Author.Login == login {
+			karma.Post += post.Score - int(post.VoteOf(post.Author.ID))
+		}
+	}
+	for _, comment := range comments {
+		if comment.Author.Login == login {
+			karma.Comment += comment.Score - int(comment.VoteOf(comment.Author.ID))
+		}
+	}
+	karma.Total = karma.Post + karma.Comment
+
+	return karma
+}
@@ -0,0 +1,54 @@
+package posts
+
+import (
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+)
+
+// LockPayload model info
+//
+// @Description LockPayload locks a post against new comments and, optionally, votes
+type LockPayload struct {
+	FreezeVotes bool `json:"freezeVotes,omitempty" example:"false"` // Reject votes as well
+}
+
+// IsArchived tells whether the post is older than the age. Posts are never archived if the age is not positive
+func (p *Post) IsArchived(age time.Duration, now time.Time) bool {
+	if age <= 0 {
+		return false
+	}
+
+	return now.Sub(p.Created) > age
+}
+
+// AsArchived returns a copy of the post flagged as archived
+func (p *Post) AsArchived() *Post {
+	view := *p
+	view.Archived = true
+	return &view
+}
+
+// CheckCommentable returns an error if no comments may be added to the post
+func (p *Post) CheckCommentable() error {
+	switch {
+	case p.Archived:
+		return errs.ErrPostArchived
+	case p.Locked:
+		return errs.ErrPostLocked
+	}
+
+	return nil
+}
+
+// CheckVotable returns an error if the votes on the post may not be changed
+func (p *Post) CheckVotable() error {
+	switch {
+	case p.Archived:
+		return errs.ErrPostArchived
+	case p.VotesFrozen:
+		return errs.ErrVotingFrozen
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package posts
+
+// WithHTML returns the Post with the HTML of the text and of the comments rendered where it is missing,
+// as for the posts stored before rendering was introduced. The Post itself is never modified
+func (p *Post) WithHTML(render func(source string) string) *Post {
+	missing := p.Text != "" && p.TextHTML == ""
+	for _, comment := range p.Comments {
+		missing = missing || comment.Body != "" && comment.BodyHTML == ""
+	}
+	if !missing {
+		return p
+	}
+
+	view := *p
+	if view.Text != "" && view.TextHTML == "" {
+		view.TextHTML = render(view.Text)
+	}
+	view.Comments = make([]*PostComment, len(p.Comments))
+	for i, comment := range p.Comments {
+		view.Comments[i] = comment
+		if comment.Body != "" && comment.BodyHTML == "" {
+			rendered := *comment
+			rendered.BodyHTML = render(comment.Body)
+			view.Comments[i] = &rendered
+		}
+	}
+
+	return &view
+}
@@ -0,0 +1,75 @@
+package posts
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// MaxMentions is the number of distinct users a Post or a comment may mention
+const MaxMentions int = 10
+
+// MentionTemplate matches the @username and /u/username references that do not continue a word, a path or an address
+var MentionTemplate = regexp.MustCompile(`(?:^|[^0-9A-Za-z_/@-])(?:@|/u/)([0-9A-Za-z_-]+)`)
+
+// MentionEvent model info
+//
+// @Description MentionEvent tells a user he/she was mentioned in a Post or in a comment on it
+type MentionEvent struct {
+	ID        users.ID         `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	UserID    users.ID         `json:"-" bson:"user"`        // User mentioned
+	Author    jwt.TokenPayload `json:"author" bson:"author"` // User who mentioned
+	PostID    users.ID         `json:"postId" bson:"postId" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	CommentID users.ID         `json:"commentId,omitempty" bson:"commentId,omitempty" example:"12345678-9abc-def1-2345-6789abcdef12"` // Missing for mentions in the text of the Post
+	Created   time.Time        `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"`
+}
+
+// ParseMentions returns the distinct usernames referenced in the text in the order of their first reference.
+// Usernames differing only in case are the same user, the first spelling is kept
+func ParseMentions(text string) ([]users.Username, error) {
+	usernames := make([]users.Username, 0)
+	for _, match := range MentionTemplate.FindAllStringSubmatch(text, -1) {
+		username := users.Username(match[1])
+		sameUser := func(u users.Username) bool {
+			return strings.EqualFold(string(u), string(username))
+		}
+		if slices.ContainsFunc(usernames, sameUser) {
+			continue
+		}
+		if len(usernames) == MaxMentions {
+			return nil, errs.ErrTooManyMentions
+		}
+		usernames = append(usernames, username)
+	}
+
+	return usernames, nil
+}
+
+// NewMentionEvents returns an event for each of the mentioned users the author has not mentioned before.
+// Authors are never told about mentioning themselves
+func NewMentionEvents(author jwt.TokenPayload, postID, commentID users.ID, mentioned, before []jwt.TokenPayload) []*MentionEvent {
+	events := make([]*MentionEvent, 0, len(mentioned))
+	now := Now()
+	for _, user := range mentioned {
+		if user.ID == author.ID || slices.Contains(before, user) {
+			continue
+		}
+		events = append(events, &MentionEvent{
+			ID:        users.ID(uuid.New().String()),
+			UserID:    user.ID,
+			Author:    author,
+			PostID:    postID,
+			CommentID: commentID,
+			Created:   now,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,126 @@
+package posts
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const (
+	MaxPins = 3 // Per category and on the front page
+
+	// FrontPage is the scope of the pins shown on top of the posts of all the categories
+	FrontPage PostCategory = ""
+)
+
+// Pin puts a post on top of a feed. The scope of the pin is either a category or the FrontPage
+type Pin struct {
+	PostID  users.ID
+	Scope   PostCategory
+	Pinned  time.Time
+	Expires *time.Time // The pin stays until removed if not set
+}
+
+// Pins of a single scope in the order they are shown
+type Pins []Pin
+
+// PinPayload model info
+//
+// @Description PinPayload pins a post or moves an already pinned one
+type PinPayload struct {
+	FrontPage bool       `json:"frontPage,omitempty" example:"false"`        // Pin on the front page (admins) instead of the category of the post (moderators)
+	Position  int        `json:"position,omitempty" example:"1" minimum:"0"` // Place among the pins starting from 1, the post goes last if not set
+	Expires   *time.Time `json:"expires,omitempty" format:"date-time"`       // The post is unpinned automatically at this time
+}
+
+func NewPin(postID users.ID, scope PostCategory, payload PinPayload) Pin {
+	pin := Pin{
+		PostID: postID,
+		Scope:  scope,
+		Pinned: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if payload.Expires != nil {
+		expires := payload.Expires.UTC().Truncate(time.Millisecond)
+		pin.Expires = &expires
+	}
+
+	return pin
+}
+
+func (p PinPayload) Validate(now time.Time) error {
+	if p.Position < 0 || (p.Expires != nil && !p.Expires.After(now)) {
+		return errs.ErrBadPin
+	}
+
+	return nil
+}
+
+func (p Pin) Active(now time.Time) bool {
+	return p.Expires == nil || p.Expires.After(now)
+}
+
+// Active returns the pins that have not expired by now
+func (pins Pins) Active(now time.Time) Pins {
+	return slices.DeleteFunc(slices.Clone(pins), func(pin Pin) bool {
+		return !pin.Active(now)
+	})
+}
+
+func (pins Pins) Contains(postID users.ID) bool {
+	return slices.ContainsFunc(pins, func(pin Pin) bool {
+		return pin.PostID == postID
+	})
+}
+
+// Place puts the pin at the position starting from 1, replacing the previous pin of the same post.
+// Out of range positions put the pin last
+func (pins Pins) Place(pin Pin, position int) Pins {
+	placed, _ := pins.Remove(pin.PostID)
+	if position < 1 || position > len(placed) {
+		return append(placed, pin)
+	}
+
+	return slices.Insert(placed, position-1, pin)
+}
+
+// Remove returns the pins without the pin of the post and whether the post has been pinned
+func (pins Pins) Remove(postID users.ID) (Pins, bool) {
+	rest := slices.DeleteFunc(slices.Clone(pins), func(pin Pin) bool {
+		return pin.PostID == postID
+	})
+
+	return rest, len(rest) != len(pins)
+}
+
+// PinFirst moves the pinned posts of the list to its top in the order of the pins and flags them.
+// Pins of the posts missing from the list are skipped
+func PinFirst(postList []*Post, pins Pins) []*Post {
+	if len(pins) == 0 || len(postList) == 0 {
+		return postList
+	}
+
+	pinned := make([]*Post, 0, len(pins))
+	for _, pin := range pins {
+		idx := slices.IndexFunc(postList, func(post *Post) bool {
+			return post.ID == pin.PostID
+		})
+		if idx == -1 {
+			continue
+		}
+		pinned = append(pinned, postList[idx].AsPinned())
+	}
+	rest := slices.DeleteFunc(slices.Clone(postList), func(post *Post) bool {
+		return pins.Contains(post.ID)
+	})
+
+	return append(pinned, rest...)
+}
+
+// AsPinned returns a copy of the post flagged as pinned
+func (p *Post) AsPinned() *Post {
+	view := *p
+	view.Pinned = true
+	return &view
+}
@@ -0,0 +1,201 @@
+package posts
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const (
+	MinPollOptions      = 2
+	MaxPollOptions      = 10
+	MaxPollOptionLength = 100
+	MinPollDuration     = 5 * time.Minute
+	MaxPollDuration     = 365 * 24 * time.Hour
+)
+
+// Poll model info
+//
+// @Description Poll contains the options of a poll Post. Vote counts stay hidden until the viewer votes or the poll closes
+type Poll struct {
+	Options        []*PollOption `json:"options" bson:"options"`
+	MultipleChoice bool          `json:"multipleChoice" bson:"multipleChoice" example:"false"`            // Whether a ballot may contain several options
+	ClosesAt       *time.Time    `json:"closesAt,omitempty" bson:"closesAt,omitempty" format:"date-time"` // The poll never closes if not set
+	Ballots        []*Ballot     `json:"-" bson:"ballots"`
+	TotalVoters    int           `json:"totalVoters" bson:"-" example:"42"`
+	Closed         bool          `json:"closed" bson:"-" example:"false"`
+	ResultsVisible bool          `json:"resultsVisible" bson:"-" example:"true"` // Votes of the options are zeroed when false
+	Choice         []int         `json:"choice,omitempty" bson:"-"`              // Options picked by the viewer
+}
+
+// PollOption model info
+//
+// @Description PollOption is one of the answers of a Poll
+type PollOption struct {
+	ID    int    `json:"id" bson:"id" example:"0"`
+	Text  string `json:"text" bson:"text" example:"Rock"`
+	Votes int    `json:"votes" bson:"votes" example:"12"`
+}
+
+// Ballot is the choice of a single user
+type Ballot struct {
+	UserID  users.ID `bson:"user"`
+	Options []int    `bson:"options"`
+}
+
+// PollPayload model info
+//
+// @Description PollPayload contains the necessary information to create a Poll
+type PollPayload struct {
+	Options        []string   `json:"options" example:"Rock,Jazz"` // From 2 to 10 options
+	MultipleChoice bool       `json:"multipleChoice" example:"false"`
+	ClosesAt       *time.Time `json:"closesAt,omitempty" format:"date-time"`
+}
+
+// BallotPayload model info
+//
+// @Description BallotPayload contains the ids of the options picked by the user
+type BallotPayload struct {
+	Choice []int `json:"choice" example:"0"`
+}
+
+// Validate trims the options and checks the poll can be created at the given moment
+func (pp *PollPayload) Validate(now time.Time) error {
+	if len(pp.Options) < MinPollOptions || len(pp.Options) > MaxPollOptions {
+		return errs.ErrBadPoll
+	}
+
+	seen := make(map[string]struct{}, len(pp.Options))
+	for i, option := range pp.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || utf8.RuneCountInString(option) > MaxPollOptionLength {
+			return errs.ErrBadPoll
+		}
+		if _, ok := seen[strings.ToLower(option)]; ok {
+			return errs.ErrBadPoll
+		}
+		seen[strings.ToLower(option)] = struct{}{}
+		pp.Options[i] = option
+	}
+
+	if pp.ClosesAt != nil {
+		lifetime := pp.ClosesAt.Sub(now)
+		if lifetime < MinPollDuration || lifetime > MaxPollDuration {
+			return errs.ErrBadPoll
+		}
+	}
+
+	return nil
+}
+
+func NewPoll(payload *PollPayload) *Poll {
+	poll := &Poll{
+		Options:        make([]*PollOption, 0, len(payload.Options)),
+		MultipleChoice: payload.MultipleChoice,
+		Ballots:        make([]*Ballot, 0),
+	}
+	for i, option := range payload.Options {
+		poll.Options = append(poll.Options, &PollOption{
+			ID:   i,
+			Text: option,
+		})
+	}
+	if payload.ClosesAt != nil {
+		closesAt := payload.ClosesAt.UTC().Truncate(time.Millisecond)
+		poll.ClosesAt = &closesAt
+	}
+
+	return poll
+}
+
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosesAt != nil && !now.Before(*p.ClosesAt)
+}
+
+// Cast records the ballot of the user, replacing the previous one. The choice is sorted in place.
+// It returns the options of the replaced ballot, or nil if the user votes for the first time
+func (p *Poll) Cast(userID users.ID, choice []int, now time.Time) ([]int, error) {
+	if p.IsClosed(now) {
+		return nil, errs.ErrPollClosed
+	}
+	if len(choice) == 0 || (!p.MultipleChoice && len(choice) > 1) {
+		return nil, errs.ErrBadBallot
+	}
+	slices.Sort(choice)
+	for i, optionID := range choice {
+		if optionID < 0 || optionID >= len(p.Options) || (i > 0 && choice[i-1] == optionID) {
+			return nil, errs.ErrBadBallot
+		}
+	}
+
+	var previous []int
+	ballotIdx := slices.IndexFunc(p.Ballots, func(ballot *Ballot) bool {
+		return ballot.UserID == userID
+	})
+	if ballotIdx == -1 {
+		p.Ballots = append(p.Ballots, &Ballot{UserID: userID, Options: slices.Clone(choice)})
+	} else {
+		previous = p.Ballots[ballotIdx].Options
+		p.Ballots[ballotIdx].Options = slices.Clone(choice)
+	}
+
+	added, removed := BallotDiff(previous, choice)
+	for _, optionID := range added {
+		p.Options[optionID].Votes++
+	}
+	for _, optionID := range removed {
+		p.Options[optionID].Votes--
+	}
+
+	return previous, nil
+}
+
+// BallotDiff returns the options that have to gain and lose a vote when the ballot changes
+func BallotDiff(previous, choice []int) (added, removed []int) {
+	for _, optionID := range choice {
+		if !slices.Contains(previous, optionID) {
+			added = append(added, optionID)
+		}
+	}
+	for _, optionID := range previous {
+		if !slices.Contains(choice, optionID) {
+			removed = append(removed, optionID)
+		}
+	}
+
+	return added, removed
+}
+
+// View returns a copy of the poll as the viewer may see it
+func (p *Poll) View(viewerID users.ID, now time.Time) *Poll {
+	view := &Poll{
+		Options:        make([]*PollOption, 0, len(p.Options)),
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt,
+		TotalVoters:    len(p.Ballots),
+		Closed:         p.IsClosed(now),
+	}
+	if viewerID != "" {
+		ballotIdx := slices.IndexFunc(p.Ballots, func(ballot *Ballot) bool {
+			return ballot.UserID == viewerID
+		})
+		if ballotIdx != -1 {
+			view.Choice = slices.Clone(p.Ballots[ballotIdx].Options)
+		}
+	}
+	view.ResultsVisible = view.Closed || view.Choice != nil
+
+	for _, option := range p.Options {
+		optionView := *option
+		if !view.ResultsVisible {
+			optionView.Votes = 0
+		}
+		view.Options = append(view.Options, &optionView)
+	}
+
+	return view
+}
@@ -15,19 +15,40 @@ import (
 //
 // @Description Post Contains all the information about a particular post in the app
 type Post struct {
-	ID               users.ID         `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
-	Score            int              `json:"score" bson:"score" example:"-1"` // The overall balance of the post's votes
-	Views            uint             `json:"views" bson:"views" example:"1"`  // How many times the post has been viewed by users
-	Type             PostType         `json:"type" bson:"type" example:"1"`    // Post with text(1) or with a link(0)
-	Title            string           `json:"title" bson:"title" example:"Awesome title"`
-	URL              string           `json:"url,omitempty" bson:"url,omitempty" example:"http://localhost:8080/"`
-	Author           jwt.TokenPayload `json:"author" bson:"author"`                                                            // User who created the Post
-	Category         PostCategory     `json:"category" bson:"category" example:"0"`                                            // Number of the category to which the Post belongs
-	Text             string           `json:"text,omitempty" bson:"text,omitempty" example:"Awesome text" minLength:"4"`       // Content of the Post
-	Votes            Votes            `json:"votes" bson:"votes"`                                                              // List of all the votes put by users on the post
-	Comments         []*PostComment   `json:"comments" bson:"comments"`                                                        // List of all comments left by users under the post
-	Created          string           `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"`    // Date the Post was created
-	UpvotePercentage int              `json:"upvotePercentage" bson:"upvotePercentage" example:"75" minimum:"0" maximum:"100"` // Percentage of positive Votes to Post
+	ID               users.ID           `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Score            int                `json:"score" bson:"score" example:"-1"` // The overall balance of the post's votes
+	Views            uint               `json:"views" bson:"views" example:"1"`  // How many times the post has been viewed by users
+	Type             PostType           `json:"type" bson:"type" example:"1"`    // Post with text(1), with a link(0), with an image(2) or with a poll(3)
+	Title            string             `json:"title" bson:"title" example:"Awesome title"`
+	URL              string             `json:"url,omitempty" bson:"url,omitempty" example:"http://localhost:8080/"`
+	CanonicalURL     string             `json:"-" bson:"canonicalUrl,omitempty"` // The URL in the form shared by all the links to the same page
+	Reposts          []users.ID         `json:"reposts,omitempty" bson:"-"`      // Recent posts of the same link in the category, set once the Post is created
+	Image            *PostImage         `json:"image,omitempty" bson:"image,omitempty"`
+	Preview          *LinkPreview       `json:"preview,omitempty" bson:"preview,omitempty"` // Filled in the background shortly after a link Post is created
+	Poll             *Poll              `json:"poll,omitempty" bson:"poll,omitempty"`
+	CrosspostParent  *CrosspostParent   `json:"crosspostParent,omitempty" bson:"crosspostParent,omitempty"` // Set if the Post is a crosspost
+	Crossposts       []*CrosspostRef    `json:"crossposts,omitempty" bson:"crossposts,omitempty"`           // Crossposts made from the Post
+	CrosspostCount   int                `json:"crosspostCount" bson:"crosspostCount" example:"0"`
+	Author           jwt.TokenPayload   `json:"author" bson:"author"`                                                            // User who created the Post
+	Category         PostCategory       `json:"category" bson:"category" example:"music"`                                        // Name of the community to which the Post belongs
+	Flair            *Flair             `json:"flair,omitempty" bson:"flair,omitempty"`                                          // Flair picked by the author from the templates of the community
+	Text             string             `json:"text,omitempty" bson:"text,omitempty" example:"Awesome text" minLength:"4"`       // Content of the Post in Markdown
+	TextHTML         string             `json:"textHtml,omitempty" bson:"textHtml,omitempty" example:"<p>Awesome text</p>"`      // Sanitized HTML rendering of the text
+	Mentions         []jwt.TokenPayload `json:"mentions,omitempty" bson:"mentions,omitempty"`                                    // Users referenced in the text as @username or /u/username
+	NSFW             bool               `json:"nsfw" bson:"nsfw" example:"false"`                                                // Not safe for work, shown only to the viewers who allow it
+	Spoiler          bool               `json:"spoiler" bson:"spoiler" example:"false"`                                          // The text is withheld from lists of posts
+	Locked           bool               `json:"locked" bson:"locked" example:"false"`                                            // No new comments are accepted
+	VotesFrozen      bool               `json:"votesFrozen" bson:"votesFrozen" example:"false"`                                  // No votes are accepted or changed
+	Archived         bool               `json:"archived" bson:"-" example:"false"`                                               // The Post is too old to be commented or voted on
+	Votes            Votes              `json:"votes" bson:"votes"`                                                              // List of all the votes put by users on the post
+	Comments         []*PostComment     `json:"comments" bson:"-"`                                                               // First page of the comments left under the post in the order they were left, stored apart from it
+	CommentCount     int                `json:"commentCount" bson:"commentCount" example:"0" minimum:"0"`                        // Number of comments under the post, deleted ones kept as placeholders included
+	Created          time.Time          `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"`    // Date the Post was created
+	UpvotePercentage int                `json:"upvotePercentage" bson:"upvotePercentage" example:"75" minimum:"0" maximum:"100"` // Percentage of positive Votes to Post
+	Saved            bool               `json:"saved" bson:"-" example:"false"`                                                  // Whether the viewer has saved the Post
+	Hidden           bool               `json:"hidden" bson:"-" example:"false"`                                                 // Whether the viewer has hidden the Post from his/her feeds
+	Pinned           bool               `json:"pinned" bson:"-" example:"false"`                                                 // Whether the Post is pinned on top of the feed
+	HiddenBy         []users.ID         `json:"-" bson:"hiddenBy,omitempty"`                                                     // Users who have hidden the Post
 }
 
 type Posts []*Post
@@ -36,11 +57,21 @@ type Posts []*Post
 //
 // @Description PostPayload contains the necessary information to create a post
 type PostPayload struct {
-	Type     PostType     `json:"type"` // link or text
-	Title    string       `json:"title" example:"Awesome title"`
-	URL      string       `json:"url,omitempty" example:"http://localhost:8080/"`
-	Category PostCategory `json:"category" example:"0"`                                // Number of the category to which the Post belongs
-	Text     string       `json:"text,omitempty" example:"Awesome text" minLength:"4"` // Content of the Post
+	Type         PostType           `json:"type"` // link, text, image or poll
+	Title        string             `json:"title" example:"Awesome title"`
+	URL          string             `json:"url,omitempty" example:"http://localhost:8080/"`
+	CanonicalURL string             `json:"-"`                                                                // Set by the app once the URL has been validated
+	Image        *PostImage         `json:"-"`                                                                // Set by the app once the image has been uploaded
+	Poll         *PollPayload       `json:"poll,omitempty"`                                                   // Required for poll posts
+	Category     PostCategory       `json:"category" example:"music"`                                         // Name of the community to which the Post belongs
+	Text         string             `json:"text,omitempty" example:"Awesome text" minLength:"4"`              // Content of the Post in Markdown
+	TextHTML     string             `json:"-"`                                                                // Set by the app once the text has been rendered
+	Mentions     []jwt.TokenPayload `json:"-"`                                                                // Set by the app once the mentioned users have been found
+	FlairID      users.ID           `json:"flairId,omitempty" example:"12345678-9abc-def1-2345-6789abcdef12"` // One of the flairs of the community, optional
+	Flair        *Flair             `json:"-"`                                                                // Set by the app once the flair has been found in the community
+	NSFW         bool               `json:"nsfw,omitempty" example:"false"`                                   // Always set for NSFW communities
+	Spoiler      bool               `json:"spoiler,omitempty" example:"false"`
+	PublishAt    *time.Time         `json:"publishAt,omitempty" bson:"-" format:"date-time"` // Schedules the Post instead of publishing it right away
 }
 
 func NewPost(author jwt.TokenPayload, payload PostPayload) *Post {
@@ -53,35 +84,104 @@ func NewPost(author jwt.TokenPayload, payload PostPayload) *Post {
 		Author:           author,
 		Category:         payload.Category,
 		Text:             payload.Text,
+		TextHTML:         payload.TextHTML,
+		Mentions:         payload.Mentions,
+		Flair:            payload.Flair,
+		NSFW:             payload.NSFW,
+		Spoiler:          payload.Spoiler,
 		Votes:            Votes{author.ID: NewPostVote(author.ID, upVote)},
 		Comments:         make([]*PostComment, 0),
-		Created:          time.Now().Format(TimeFormat),
+		Created:          Now(),
 		UpvotePercentage: 100,
 	}
-	if newPost.Type == WithLink {
+	switch newPost.Type {
+	case WithLink:
 		newPost.URL = payload.URL
+		newPost.CanonicalURL = payload.CanonicalURL
+	case WithImage:
+		newPost.Image = payload.Image
+	case WithPoll:
+		if payload.Poll != nil {
+			newPost.Poll = NewPoll(payload.Poll)
+		}
 	}
 
 	return newPost
 }
 
-func (p *Post) AddComment(author jwt.TokenPayload, commentBody string) *PostComment {
-	newComment := NewPostComment(author, commentBody)
+// AddComment appends the comment to the post. A reply must have its parent among the comments of the same post
+func (p *Post) AddComment(author jwt.TokenPayload, comment Comment) (*PostComment, error) {
+	newComment := NewPostComment(author, comment)
+	newComment.PostID = p.ID
+	if comment.ParentID != "" {
+		parent, err := p.GetComment(comment.ParentID)
+		if err != nil {
+			return nil, errs.ErrBadParentComment
+		}
+		newComment.Depth = parent.Depth + 1
+	}
 	p.Comments = append(p.Comments, newComment)
+	p.CommentCount++
 
-	return newComment
+	return newComment, nil
 }
 
-func (p *Post) DeleteComment(commentID users.ID) error {
-	lenBeforeDelete := len(p.Comments)
+// DeletedPlaceholder is the body left in place of a deleted comment that still has replies
+const DeletedPlaceholder = "[deleted]"
+
+// DeleteComment deletes the comment. A comment with replies is blanked into a placeholder that keeps its id and position,
+// otherwise it is removed along with the placeholders above it that have no other replies. Returns the ids of all removed comments
+func (p *Post) DeleteComment(commentID users.ID) ([]users.ID, error) {
+	comment, err := p.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, errs.ErrCommentNotFound
+	}
+
+	children := p.children()
+	if len(children[commentID]) != 0 {
+		comment.blank()
+		return nil, nil
+	}
+
+	removed := []users.ID{commentID}
+	for parentID := comment.ParentID; parentID != ""; {
+		parent, err := p.GetComment(parentID)
+		if err != nil || !parent.Deleted || len(children[parentID]) > 1 {
+			break
+		}
+		removed = append(removed, parentID)
+		parentID = parent.ParentID
+	}
 	p.Comments = slices.DeleteFunc(p.Comments, func(comment *PostComment) bool {
-		return commentID == comment.ID
+		return slices.Contains(removed, comment.ID)
 	})
-	if lenBeforeDelete == len(p.Comments) {
-		return errs.ErrCommentNotFound
+	p.CommentCount -= len(removed)
+
+	return removed, nil
+}
+
+// blank turns the comment into the "[deleted]" placeholder
+func (c *PostComment) blank() {
+	c.Author = jwt.TokenPayload{}
+	c.Body, c.BodyHTML, c.Mentions = DeletedPlaceholder, "", nil
+	c.Edited, c.Revisions = time.Time{}, nil
+	// The votes name the voters, the author among them
+	c.Score, c.Votes = 0, nil
+	c.Deleted = true
+}
+
+func (p *Post) GetComment(commentID users.ID) (*PostComment, error) {
+	commentIdx := slices.IndexFunc(p.Comments, func(comment *PostComment) bool {
+		return comment.ID == commentID
+	})
+	if commentIdx == -1 {
+		return nil, errs.ErrCommentNotFound
 	}
 
-	return nil
+	return p.Comments[commentIdx], nil
 }
 
 func (p *Post) Upvote(userID users.ID) (*PostVote, bool) {
@@ -145,9 +245,20 @@ func (p *Post) updateUpvotePercentage() {
 	p.UpvotePercentage = ((p.Score + totalVotes) * 100) / (totalVotes * 2)
 }
 
-func (p *Post) UpdateViews() *Post {
-	p.Views++
-	return p
+// ViewFor returns the post as the viewer may see it. The post is copied only if something has to be hidden or flagged
+func (p *Post) ViewFor(viewerID users.ID, now time.Time) *Post {
+	hidden := p.IsHiddenBy(viewerID)
+	if p.Poll == nil && !hidden {
+		return p
+	}
+
+	view := *p
+	view.Hidden = hidden
+	if p.Poll != nil {
+		view.Poll = p.Poll.View(viewerID, now)
+	}
+
+	return &view
 }
 
 func (p *Post) getVoteByUserID(userID users.ID) (*PostVote, bool) {
@@ -23,12 +23,12 @@ type Vote int
 
 // PostCategory type
 //
-// @Description PostCategory is an integer representing the category to which post belongs
-type PostCategory int
+// @Description PostCategory is the name of the community to which post belongs
+type PostCategory string
 
 // PostType type
 //
-// @Description PostType is an integer(0 or 1) representing the type of the Post
+// @Description PostType is an integer(0, 1, 2 or 3) representing the type of the Post
 type PostType int
 type Votes map[users.ID]*PostVote
 
@@ -36,17 +36,56 @@ type Votes map[users.ID]*PostVote
 //
 // @Description Comment contains the text of the comment on Post
 type Comment struct {
-	Body string `json:"comment" example:"Some comment body example" minLength:"4"`
+	Body     string             `json:"comment" example:"Some comment body example" minLength:"4"`
+	ParentID users.ID           `json:"parentId,omitempty" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"` // Comment being replied to, a top-level comment if empty
+	BodyHTML string             `json:"-"`                                                                                               // Set by the app once the body has been rendered
+	Mentions []jwt.TokenPayload `json:"-"`                                                                                               // Set by the app once the mentioned users have been found
 }
 
 // PostComment model info
 //
 // @Description PostComment contains all information about a specific comment on a Post
 type PostComment struct {
-	Created string           `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"` // Date the comment was created
-	Author  jwt.TokenPayload `json:"author" bson:"author"`
-	Body    string           `json:"body" bson:"body" example:"Some comment body example" minLength:"4"` // Content of the comment
-	ID      users.ID         `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Created     time.Time          `json:"created" bson:"created" example:"2006-01-02T15:04:05.999Z" format:"date-time"` // Date the comment was created
+	Author      jwt.TokenPayload   `json:"author" bson:"author"`
+	Body        string             `json:"body" bson:"body" example:"Some comment body example" minLength:"4"`                      // Content of the comment in Markdown
+	BodyHTML    string             `json:"bodyHtml,omitempty" bson:"bodyHtml,omitempty" example:"<p>Some comment body example</p>"` // Sanitized HTML rendering of the body
+	Mentions    []jwt.TokenPayload `json:"mentions,omitempty" bson:"mentions,omitempty"`                                            // Users referenced in the body as @username or /u/username
+	ID          users.ID           `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	PostID      users.ID           `json:"-" bson:"postId"`                                                                               // Post the comment is left on, the key of the comments in the storage
+	ParentID    users.ID           `json:"parentId,omitempty" bson:"parentId,omitempty" example:"12345678-9abc-def1-2345-6789abcdef12"`   // Comment this one replies to, empty for top-level comments
+	Depth       int                `json:"depth" bson:"depth" example:"0" minimum:"0"`                                                    // Number of ancestors of the comment
+	Score       int                `json:"score" bson:"score" example:"1"`                                                                // The overall balance of the comment's votes
+	Votes       Votes              `json:"votes" bson:"votes,omitempty"`                                                                  // List of all the votes put by users on the comment
+	Edited      time.Time          `json:"edited,omitzero" bson:"edited,omitempty" example:"2006-01-02T15:04:05.999Z" format:"date-time"` // Date the comment was last edited, missing if never
+	Revisions   []*CommentRevision `json:"-" bson:"revisions,omitempty"`                                                                  // Previous bodies of the comment, shown to the moderators only
+	Deleted     bool               `json:"deleted,omitempty" bson:"deleted,omitempty" example:"false"`                                    // Whether the comment was deleted and is kept as a placeholder for its replies
+	Saved       bool               `json:"saved" bson:"-" example:"false"`                                                                // Whether the viewer has saved the comment
+	Replies     []*PostComment     `json:"replies,omitempty" bson:"-"`                                                                    // Replies to the comment, down to the maximum depth of a tree
+	MoreReplies int                `json:"moreReplies,omitempty" bson:"-" example:"0" minimum:"0"`                                        // "Continue thread" stub: number of replies below the maximum depth, fetched as the thread of this comment
+}
+
+// PostImage model info
+//
+// @Description PostImage contains links to the image of the Post and to its thumbnail
+type PostImage struct {
+	URL          string `json:"url" bson:"url" example:"/api/media/12345678-9abc-def1-2345-6789abcdef12.jpg"`
+	ThumbnailURL string `json:"thumbnailUrl" bson:"thumbnailUrl" example:"/api/media/12345678-9abc-def1-2345-6789abcdef12_thumb.jpg"`
+	ContentType  string `json:"contentType" bson:"contentType" example:"image/jpeg"`
+	Width        int    `json:"width" bson:"width" example:"1920"`
+	Height       int    `json:"height" bson:"height" example:"1080"`
+	Key          string `json:"-" bson:"key"`          // Key of the image in the blob store
+	ThumbnailKey string `json:"-" bson:"thumbnailKey"` // Key of the thumbnail in the blob store
+}
+
+// LinkPreview model info
+//
+// @Description LinkPreview is the summary of the page a link Post points to, taken from its OpenGraph and oEmbed metadata
+type LinkPreview struct {
+	Title        string `json:"title,omitempty" bson:"title,omitempty" example:"Example Domain"`
+	Description  string `json:"description,omitempty" bson:"description,omitempty" example:"This domain is for use in illustrative examples"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty" bson:"thumbnailUrl,omitempty" example:"https://example.com/preview.png"`
+	SiteName     string `json:"siteName,omitempty" bson:"siteName,omitempty" example:"Example"`
 }
 
 // PostVote model info
@@ -62,33 +101,37 @@ const (
 	downVote Vote = iota - 1
 	upVote   Vote = iota
 
-	withLink    = "link"
-	withText    = "text"
-	music       = "music"
-	funny       = "funny"
-	videos      = "videos"
-	programming = "programming"
-	news        = "news"
-	fashion     = "fashion"
+	withLink  = "link"
+	withText  = "text"
+	withImage = "image"
+	withPoll  = "poll"
 
-	CategoryCount int = 6
-	UUIDLength    int = 36
+	UUIDLength int = 36
 
+	// TimeFormat is the format of the timestamps stored as strings before they became dates
 	TimeFormat = "2006-01-02T15:04:05.999Z"
 )
 
+// Now is the current time as it is stored: in UTC and to the millisecond, like BSON dates
+func Now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// Communities seeded on the first start of the app
 const (
-	Music PostCategory = iota
-	Funny
-	Videos
-	Programming
-	News
-	Fashion
+	Music       PostCategory = "music"
+	Funny       PostCategory = "funny"
+	Videos      PostCategory = "videos"
+	Programming PostCategory = "programming"
+	News        PostCategory = "news"
+	Fashion     PostCategory = "fashion"
 )
 
 const (
 	WithLink PostType = iota
 	WithText
+	WithImage
+	WithPoll
 )
 
 var (
@@ -96,85 +139,16 @@ var (
 )
 
 var (
-	postCategories = map[PostCategory]string{
-		0: music,
-		1: funny,
-		2: videos,
-		3: programming,
-		4: news,
-		5: fashion,
-	}
 	postTypes = map[PostType]string{
 		0: withLink,
 		1: withText,
+		2: withImage,
+		3: withPoll,
 	}
 )
 
 func (pc PostCategory) String() string {
-	return postCategories[pc]
-}
-
-func (pc *PostCategory) UnmarshalJSON(category []byte) error {
-	var s string
-	if err := json.Unmarshal(category, &s); err != nil {
-		return err
-	}
-
-	ctgry, err := StringToPostCategory(s)
-	if err != nil {
-		return err
-	}
-	*pc = ctgry
-
-	return nil
-}
-
-func (pc *PostCategory) UnmarshalBSONValue(bt bsontype.Type, category []byte) error {
-	if bt != bson.TypeString {
-		return fmt.Errorf("invalid bson postCategory type '%s'", bt.String())
-	}
-	cat, _, ok := bsoncore.ReadString(category)
-	if !ok {
-		return fmt.Errorf("invalid bson postCategory value")
-	}
-
-	ctgry, err := StringToPostCategory(cat)
-	if err != nil {
-		return err
-	}
-	*pc = ctgry
-
-	return nil
-}
-
-func (pc PostCategory) MarshalJSON() ([]byte, error) {
-	return json.Marshal(pc.String())
-}
-
-func (pc PostCategory) MarshalBSONValue() (bsontype.Type, []byte, error) {
-	return bson.MarshalValue(pc.String())
-}
-
-func StringToPostCategory(s string) (PostCategory, error) {
-	var category PostCategory
-	switch s {
-	case music:
-		category = Music
-	case funny:
-		category = Funny
-	case videos:
-		category = Videos
-	case programming:
-		category = Programming
-	case news:
-		category = News
-	case fashion:
-		category = Fashion
-	default:
-		return category, errs.ErrInvalidCategory
-	}
-
-	return category, nil
+	return string(pc)
 }
 
 func (pt PostType) String() string {
@@ -187,14 +161,11 @@ func (pt *PostType) UnmarshalJSON(postType []byte) error {
 		return err
 	}
 
-	switch s {
-	case withLink:
-		*pt = WithLink
-	case withText:
-		*pt = WithText
-	default:
-		return errs.ErrInvalidPostType
+	tp, err := stringToPostType(s)
+	if err != nil {
+		return err
 	}
+	*pt = tp
 
 	return nil
 }
@@ -208,18 +179,25 @@ func (pt *PostType) UnmarshalBSONValue(bt bsontype.Type, postType []byte) error
 		return fmt.Errorf("invalid bson postType value")
 	}
 
-	switch tp {
-	case withLink:
-		*pt = WithLink
-	case withText:
-		*pt = WithText
-	default:
-		return errs.ErrInvalidPostType
+	postTp, err := stringToPostType(tp)
+	if err != nil {
+		return err
 	}
+	*pt = postTp
 
 	return nil
 }
 
+func stringToPostType(s string) (PostType, error) {
+	for postType, name := range postTypes {
+		if name == s {
+			return postType, nil
+		}
+	}
+
+	return 0, errs.ErrInvalidPostType
+}
+
 func (pt PostType) MarshalJSON() ([]byte, error) {
 	return json.Marshal(pt.String())
 }
@@ -277,12 +255,17 @@ func (v *Votes) UnmarshalBSONValue(bt bsontype.Type, votes []byte) error {
 	return nil
 }
 
-func NewPostComment(author jwt.TokenPayload, commentBody string) *PostComment {
+func NewPostComment(author jwt.TokenPayload, comment Comment) *PostComment {
 	return &PostComment{
-		ID:      users.ID(uuid.New().String()),
-		Created: time.Now().Format(TimeFormat),
-		Author:  author,
-		Body:    commentBody,
+		ID:       users.ID(uuid.New().String()),
+		Created:  Now(),
+		Author:   author,
+		Body:     comment.Body,
+		BodyHTML: comment.BodyHTML,
+		Mentions: comment.Mentions,
+		ParentID: comment.ParentID,
+		Score:    1,
+		Votes:    Votes{author.ID: NewPostVote(author.ID, upVote)},
 	}
 }
 
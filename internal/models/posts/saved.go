@@ -0,0 +1,114 @@
+package posts
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// ItemType type
+//
+// @Description ItemType tells whether a saved item is a post or a comment
+type ItemType string
+
+const (
+	ItemPost    ItemType = "post"
+	ItemComment ItemType = "comment"
+)
+
+// SavedItem links a user to a post or a comment he/she has saved
+type SavedItem struct {
+	UserID users.ID
+	Type   ItemType
+	ItemID users.ID
+	PostID users.ID // Equals ItemID for posts, the post of the comment otherwise
+	Saved  time.Time
+}
+
+// SavedQuery selects the saved items of a user, the most recently saved first
+type SavedQuery struct {
+	Type ItemType // Items of any type if empty
+	Page
+}
+
+// SavedEntry model info
+//
+// @Description SavedEntry is a post or a comment saved by the user
+type SavedEntry struct {
+	Type      ItemType     `json:"type" example:"comment"`
+	Saved     time.Time    `json:"saved" format:"date-time"` // When the item was saved
+	Post      *Post        `json:"post,omitempty"`           // Set for saved posts
+	Comment   *PostComment `json:"comment,omitempty"`        // Set for saved comments
+	PostID    users.ID     `json:"postId" example:"12345678-9abc-def1-2345-6789abcdef12"`
+	PostTitle string       `json:"postTitle" example:"Awesome title"`
+}
+
+// SavedSet holds the ids of the posts and comments saved by a viewer
+type SavedSet map[users.ID]struct{}
+
+func ParseItemType(s string) (ItemType, error) {
+	switch itemType := ItemType(s); itemType {
+	case "", ItemPost, ItemComment:
+		return itemType, nil
+	default:
+		return "", errs.ErrBadItemType
+	}
+}
+
+func NewSavedItem(userID users.ID, post *Post, commentID users.ID) SavedItem {
+	item := SavedItem{
+		UserID: userID,
+		Type:   ItemPost,
+		ItemID: post.ID,
+		PostID: post.ID,
+		Saved:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if commentID != "" {
+		item.Type = ItemComment
+		item.ItemID = commentID
+	}
+
+	return item
+}
+
+func (s SavedSet) Contains(id users.ID) bool {
+	_, ok := s[id]
+	return ok
+}
+
+// ItemIDs returns the ids of the posts and of all their comments
+func ItemIDs(postList ...*Post) []users.ID {
+	ids := make([]users.ID, 0, len(postList))
+	for _, post := range postList {
+		ids = append(ids, post.ID)
+		for _, comment := range post.Comments {
+			ids = append(ids, comment.ID)
+		}
+	}
+
+	return ids
+}
+
+// MarkSaved returns the post with the saved flags of the viewer set. The post is copied only if something has been saved
+func (p *Post) MarkSaved(saved SavedSet) *Post {
+	if len(saved) == 0 {
+		return p
+	}
+
+	view := *p
+	view.Saved = saved.Contains(p.ID)
+	if slices.ContainsFunc(p.Comments, func(comment *PostComment) bool {
+		return saved.Contains(comment.ID)
+	}) {
+		view.Comments = make([]*PostComment, 0, len(p.Comments))
+		for _, comment := range p.Comments {
+			commentView := *comment
+			commentView.Saved = saved.Contains(comment.ID)
+			view.Comments = append(view.Comments, &commentView)
+		}
+	}
+
+	return &view
+}
@@ -0,0 +1,63 @@
+package posts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const (
+	MaxScheduleAhead   = 90 * 24 * time.Hour
+	MaxPublishAttempts = 5
+)
+
+// ScheduledPost model info
+//
+// @Description ScheduledPost is a Post waiting to be published. Only its author can see it
+type ScheduledPost struct {
+	ID          users.ID         `json:"id" bson:"uuid" example:"12345678-9abc-def1-2345-6789abcdef12" minLength:"36" maxLength:"36"`
+	Author      jwt.TokenPayload `json:"author" bson:"author"`
+	Post        PostPayload      `json:"post" bson:"post"`                              // Content the Post will be created with
+	PublishAt   time.Time        `json:"publishAt" bson:"publishAt" format:"date-time"` // When the Post is published
+	Created     time.Time        `json:"created" bson:"created" format:"date-time"`
+	Attempts    int              `json:"attempts,omitempty" bson:"attempts,omitempty" example:"0"` // Times the Post has been tried to be published, it is given up on after MaxPublishAttempts
+	LockedUntil time.Time        `json:"-" bson:"lockedUntil,omitempty"`                           // Until when the scheduler publishing the Post holds it
+}
+
+func NewScheduledPost(author jwt.TokenPayload, payload PostPayload) *ScheduledPost {
+	scheduled := &ScheduledPost{
+		ID:      users.ID(uuid.New().String()),
+		Author:  author,
+		Created: Now(),
+	}
+	scheduled.Update(payload)
+
+	return scheduled
+}
+
+// Update replaces the content and the publish time of the scheduled post. The post is tried to be published afresh
+func (s *ScheduledPost) Update(payload PostPayload) {
+	s.PublishAt = payload.PublishAt.UTC().Truncate(time.Millisecond)
+	payload.PublishAt = nil
+	s.Post = payload
+	s.Attempts = 0
+}
+
+// Claimable reports whether the post may be taken to be published at now:
+// no scheduler holds it and it has not been given up on
+func (s *ScheduledPost) Claimable(now time.Time) bool {
+	return s.Attempts < MaxPublishAttempts && !s.LockedUntil.After(now)
+}
+
+// ValidatePublishTime checks the post can be scheduled at publishAt
+func ValidatePublishTime(publishAt *time.Time, now time.Time) error {
+	if publishAt == nil || !publishAt.After(now) || publishAt.Sub(now) > MaxScheduleAhead {
+		return errs.ErrBadPublishTime
+	}
+
+	return nil
+}
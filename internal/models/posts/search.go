@@ -0,0 +1,62 @@
+package posts
+
+import (
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Page describes which part of a list should be returned
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// SearchQuery describes a full-text search request over posts and their comments
+type SearchQuery struct {
+	Text     string
+	Category PostCategory   // Optional, empty means all communities
+	Author   users.Username // Optional, empty means all users
+	From     time.Time      // Optional lower bound of the Post creation date
+	To       time.Time      // Optional upper bound of the Post creation date
+	NSFW     bool           // Whether NSFW posts are included, set from the preferences of the viewer
+	Page
+}
+
+const (
+	DefaultPageLimit int = 25
+	MaxPageLimit     int = 100
+	MaxSearchLength  int = 256
+)
+
+func NewPage(limit, offset int) Page {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+
+	return Page{
+		Limit:  min(limit, MaxPageLimit),
+		Offset: max(offset, 0),
+	}
+}
+
+// Matches reports whether the post satisfies all the filters of the query except for the text itself
+func (q SearchQuery) Matches(post *Post) bool {
+	if q.Category != "" && post.Category != q.Category {
+		return false
+	}
+	if q.Author != "" && post.Author.Login != q.Author {
+		return false
+	}
+
+	return FeedQuery{NSFW: q.NSFW, From: q.From, To: q.To}.Matches(post)
+}
+
+// Apply cuts the requested page out of the list
+func (pg Page) Apply(postList []*Post) []*Post {
+	if pg.Offset >= len(postList) {
+		return make([]*Post, 0)
+	}
+
+	return postList[pg.Offset:min(pg.Offset+pg.Limit, len(postList))]
+}
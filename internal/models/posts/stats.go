@@ -0,0 +1,135 @@
+package posts
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// StatsInterval is the width of the buckets of the stats of a Post
+type StatsInterval string
+
+const (
+	Hourly StatsInterval = "hour"
+	Daily  StatsInterval = "day"
+
+	// StatsResolution is the width of the buckets the stats are stored in
+	StatsResolution = time.Hour
+)
+
+// StatsCounts model info
+//
+// @Description StatsCounts sums up what happened to a Post. Votes taken back are subtracted, so a bucket may go negative
+type StatsCounts struct {
+	Views     int `json:"views" bson:"views" example:"42"`
+	Upvotes   int `json:"upvotes" bson:"upvotes" example:"5"`
+	Downvotes int `json:"downvotes" bson:"downvotes" example:"1"`
+	Comments  int `json:"comments" bson:"comments" example:"3"`
+}
+
+// StatsBucket model info
+//
+// @Description StatsBucket holds the events of a Post in the interval starting at Start
+type StatsBucket struct {
+	PostID      users.ID  `json:"-" bson:"postId"`
+	Start       time.Time `json:"start" bson:"start" format:"date-time"`
+	StatsCounts `bson:",inline"`
+}
+
+// PostStats model info
+//
+// @Description PostStats is the time series of the events of a Post. Buckets without events are omitted
+type PostStats struct {
+	PostID   users.ID       `json:"postId" example:"12345678-9abc-def1-2345-6789abcdef12"`
+	Interval StatsInterval  `json:"interval" example:"hour"`
+	Total    StatsCounts    `json:"total"`
+	Buckets  []*StatsBucket `json:"buckets"`
+}
+
+type StatsQuery struct {
+	Interval StatsInterval
+	From     time.Time // Zero for the creation of the Post
+	To       time.Time // Zero for now
+}
+
+// Validate fills in the defaults of the query
+func (q *StatsQuery) Validate(now time.Time) error {
+	if q.Interval == "" {
+		q.Interval = Hourly
+	}
+	if q.Interval != Hourly && q.Interval != Daily {
+		return errs.ErrBadStatsQuery
+	}
+	if q.To.IsZero() {
+		q.To = now
+	}
+	if q.From.After(q.To) {
+		return errs.ErrBadStatsQuery
+	}
+
+	return nil
+}
+
+func (c *StatsCounts) Add(other StatsCounts) {
+	c.Views += other.Views
+	c.Upvotes += other.Upvotes
+	c.Downvotes += other.Downvotes
+	c.Comments += other.Comments
+}
+
+func (c StatsCounts) IsZero() bool {
+	return c == StatsCounts{}
+}
+
+// VoteChange counts the change of the vote of a user. The zero Vote stands for no vote
+func VoteChange(before, after Vote) StatsCounts {
+	counts := StatsCounts{}
+	count := func(vote Vote, sign int) {
+		switch vote {
+		case upVote:
+			counts.Upvotes += sign
+		case downVote:
+			counts.Downvotes += sign
+		}
+	}
+	count(before, -1)
+	count(after, 1)
+
+	return counts
+}
+
+// VoteOf returns the vote of the user, zero if there is none
+func (p *Post) VoteOf(userID users.ID) Vote {
+	if vote, ok := p.Votes[userID]; ok {
+		return vote.Vote
+	}
+
+	return 0
+}
+
+// NewPostStats merges the stored buckets into the buckets of the interval, oldest first
+func NewPostStats(postID users.ID, interval StatsInterval, stored []*StatsBucket) *PostStats {
+	width := StatsResolution
+	if interval == Daily {
+		width = 24 * time.Hour
+	}
+
+	stats := &PostStats{PostID: postID, Interval: interval, Buckets: make([]*StatsBucket, 0)}
+	merged := make(map[time.Time]*StatsBucket)
+	for _, bucket := range stored {
+		stats.Total.Add(bucket.StatsCounts)
+		start := bucket.Start.UTC().Truncate(width)
+		if _, ok := merged[start]; !ok {
+			merged[start] = &StatsBucket{PostID: postID, Start: start}
+			stats.Buckets = append(stats.Buckets, merged[start])
+		}
+		merged[start].Add(bucket.StatsCounts)
+	}
+	slices.SortFunc(stats.Buckets, func(a, b *StatsBucket) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return stats
+}
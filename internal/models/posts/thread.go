@@ -0,0 +1,112 @@
+package posts
+
+import (
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// DefaultCommentDepth is the number of levels of a comment tree sent at once
+const DefaultCommentDepth = 8
+
+// Replies returns the ids of all the replies to the comment, direct or not
+func (p *Post) Replies(commentID users.ID) []users.ID {
+	replies := descendants(p.children(), commentID)
+	replyIDs := make([]users.ID, 0, len(replies))
+	for _, reply := range replies {
+		replyIDs = append(replyIDs, reply.ID)
+	}
+
+	return replyIDs
+}
+
+// descendants returns all the replies to the comment, direct or not, level by level
+func descendants(children map[users.ID][]*PostComment, commentID users.ID) []*PostComment {
+	replies := make([]*PostComment, 0)
+	for queue := []users.ID{commentID}; len(queue) != 0; queue = queue[1:] {
+		for _, reply := range children[queue[0]] {
+			replies = append(replies, reply)
+			queue = append(queue, reply.ID)
+		}
+	}
+
+	return replies
+}
+
+// WithCommentTree returns a copy of the Post with the replies nested under the comments they reply to,
+// at most maxDepth levels deep. The comments on the last level carry the "continue thread" stub instead of replies
+func (p *Post) WithCommentTree(maxDepth int) *Post {
+	if len(p.Comments) == 0 {
+		return p
+	}
+
+	view := *p
+	children := p.children()
+	view.Comments = make([]*PostComment, 0, len(children[""]))
+	for _, comment := range children[""] {
+		view.Comments = append(view.Comments, nest(children, comment, maxDepth))
+	}
+
+	return &view
+}
+
+// WithComments returns a copy of the Post carrying the comments given instead of its own
+func (p *Post) WithComments(comments []*PostComment) *Post {
+	view := *p
+	view.Comments = comments
+
+	return &view
+}
+
+// Thread returns the comment with the replies to it nested under it, at most maxDepth levels deep
+func (p *Post) Thread(commentID users.ID, maxDepth int) (*PostComment, error) {
+	comment, err := p.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nest(p.children(), comment, maxDepth), nil
+}
+
+// children groups the comments by the comment they reply to, in the order they were left.
+// Top-level comments and the replies whose parent is gone are grouped under the empty id
+func (p *Post) children() map[users.ID][]*PostComment {
+	ids := make(map[users.ID]struct{}, len(p.Comments))
+	for _, comment := range p.Comments {
+		ids[comment.ID] = struct{}{}
+	}
+
+	children := make(map[users.ID][]*PostComment)
+	for _, comment := range p.Comments {
+		parentID := comment.ParentID
+		if _, ok := ids[parentID]; !ok {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], comment)
+	}
+
+	return children
+}
+
+// nest copies the comment with the replies to it attached, levels deep including the comment itself
+func nest(children map[users.ID][]*PostComment, comment *PostComment, levels int) *PostComment {
+	view := *comment
+	view.Replies, view.MoreReplies = nil, 0
+	if levels <= 1 {
+		view.MoreReplies = countReplies(children, comment.ID)
+		return &view
+	}
+
+	for _, reply := range children[comment.ID] {
+		view.Replies = append(view.Replies, nest(children, reply, levels-1))
+	}
+
+	return &view
+}
+
+func countReplies(children map[users.ID][]*PostComment, commentID users.ID) int {
+	count := 0
+	for _, reply := range children[commentID] {
+		count += 1 + countReplies(children, reply.ID)
+	}
+
+	return count
+}
@@ -0,0 +1,33 @@
+package posts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+)
+
+type fingerprintKey struct{}
+
+// Fingerprint is the context key of the fingerprint telling anonymous viewers apart
+var Fingerprint fingerprintKey = struct{}{}
+
+// NewFingerprint identifies an anonymous viewer by the address and the user agent without storing either of them
+func NewFingerprint(remoteAddr, userAgent string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+	sum := sha256.Sum256([]byte(remoteAddr + "\x00" + userAgent))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// WithPendingViews returns the Post counting the views not saved yet. The Post itself is never modified
+func (p *Post) WithPendingViews(pending uint) *Post {
+	if pending == 0 {
+		return p
+	}
+	view := *p
+	view.Views += pending
+
+	return &view
+}
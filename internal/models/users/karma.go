@@ -0,0 +1,10 @@
+package users
+
+// Karma model info
+//
+// @Description Karma is the balance of the votes other users have put on the posts and the comments of the User
+type Karma struct {
+	Post    int `json:"post" example:"42"`    // Karma earned with posts
+	Comment int `json:"comment" example:"17"` // Karma earned with comments
+	Total   int `json:"total" example:"59"`
+}
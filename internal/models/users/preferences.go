@@ -0,0 +1,8 @@
+package users
+
+// Preferences model info
+//
+// @Description Preferences stores the settings of the User that change what the app shows to him/her
+type Preferences struct {
+	ShowNSFW bool `json:"showNsfw" example:"false"` // Whether NSFW posts are shown in the feeds
+}
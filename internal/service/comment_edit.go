@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// EditComment replaces the body of the comment. Only the author can do it, as long as the post can be commented on
+func (p *PostHandler) EditComment(ctx context.Context, postID, commentID users.ID, comment posts.Comment) (*posts.Post, error) {
+	source := "EditComment"
+	if comment.Body == "" {
+		return nil, errs.ErrBadCommentBody
+	}
+
+	post, err := p.postWithComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	edited, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if edited.Deleted {
+		return nil, errors.Wrap(errs.ErrCommentNotFound, source)
+	}
+	if edited.Author.ID != viewerID(ctx) {
+		return nil, errors.Wrap(errs.ErrNotCommentAuthor, source)
+	}
+	if err = p.archived(post).CheckCommentable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if comment.Mentions, err = p.resolveMentions(ctx, comment.Body); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	comment.BodyHTML = p.render(comment.Body, comment.Mentions)
+	before := edited.Mentions
+	post, err = p.actionController.EditComment(ctx, post, commentID, comment)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	p.notifyMentions(ctx, edited.Author, postID, commentID, comment.Mentions, before)
+
+	return p.view(ctx, post)
+}
+
+// GetCommentRevisions returns the bodies the comment had before it was edited, oldest first. Only the moderators can see them
+func (p *PostHandler) GetCommentRevisions(ctx context.Context, postID, commentID users.ID) ([]*posts.CommentRevision, error) {
+	source := "GetCommentRevisions"
+	post, err := p.moderatedPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if post, err = p.repo.LoadCommentThread(ctx, post, commentID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	revisions := make([]*posts.CommentRevision, len(comment.Revisions))
+	copy(revisions, comment.Revisions)
+
+	return revisions, nil
+}
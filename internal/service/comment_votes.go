@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+func (p *PostHandler) UpvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, postID, commentID, p.actionController.UpvoteComment)
+}
+
+func (p *PostHandler) DownvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, postID, commentID, p.actionController.DownvoteComment)
+}
+
+func (p *PostHandler) UnvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, postID, commentID, p.actionController.UnvoteComment)
+}
+
+// voteComment applies the vote to the comment. Comments can be voted on as long as their post can
+func (p *PostHandler) voteComment(
+	ctx context.Context,
+	postID, commentID users.ID,
+	vote func(context.Context, *posts.Post, users.ID) (*posts.Post, error),
+) (*posts.Post, error) {
+	source := "voteComment"
+	post, err := p.postWithComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if err = p.archived(post).CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	post, err = vote(ctx, post, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+// GetKarma returns the balance of the votes other users have put on the posts and the comments of the user
+func (p *PostHandler) GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error) {
+	source := "GetKarma"
+	karma, err := p.repo.GetKarma(ctx, userLogin)
+	if err != nil {
+		return users.Karma{}, errors.Wrap(err, source)
+	}
+
+	return karma, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type CommunityStorage interface {
+	GetAllCommunities(ctx context.Context) ([]*communities.Community, error)
+	GetCommunityByName(ctx context.Context, name string) (*communities.Community, error)
+	CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error)
+	AddFlair(ctx context.Context, community *communities.Community, flair communities.Flair) (*communities.Community, error)
+	DeleteFlair(ctx context.Context, community *communities.Community, flairID users.ID) (*communities.Community, error)
+}
+
+type CommunityHandler struct {
+	repo CommunityStorage
+}
+
+func NewCommunityHandler(storage CommunityStorage) *CommunityHandler {
+	return &CommunityHandler{
+		repo: storage,
+	}
+}
+
+func (c *CommunityHandler) GetAllCommunities(ctx context.Context) ([]*communities.Community, error) {
+	source := "GetAllCommunities"
+	communityList, err := c.repo.GetAllCommunities(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return communityList, nil
+}
+
+func (c *CommunityHandler) GetCommunityByName(ctx context.Context, name string) (*communities.Community, error) {
+	source := "GetCommunityByName"
+	community, err := c.repo.GetCommunityByName(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return community, nil
+}
+
+func (c *CommunityHandler) CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error) {
+	source := "CreateCommunity"
+	switch {
+	case !communities.NameTemplate.MatchString(payload.Name):
+		return nil, errors.Wrap(errs.ErrInvalidCommunity, source)
+	case utf8.RuneCountInString(payload.Description) > communities.MaxDescriptionLength:
+		return nil, errors.Wrap(errs.ErrBadDescription, source)
+	case len(payload.Rules) > communities.MaxRulesCount:
+		return nil, errors.Wrap(errs.ErrBadRules, source)
+	}
+	for _, rule := range payload.Rules {
+		if rule.Title == "" || utf8.RuneCountInString(rule.Title) > communities.MaxRuleTitleLength ||
+			utf8.RuneCountInString(rule.Description) > communities.MaxDescriptionLength {
+			return nil, errors.Wrap(errs.ErrBadRules, source)
+		}
+	}
+
+	community, err := c.repo.CreateCommunity(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return community, nil
+}
+
+func (c *CommunityHandler) CreateFlair(ctx context.Context, name string, payload communities.FlairPayload) (*communities.Community, error) {
+	source := "CreateFlair"
+	if err := payload.Validate(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	community, err := c.moderatedCommunity(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if len(community.Flairs) >= communities.MaxFlairsCount {
+		return nil, errors.Wrap(errs.ErrTooManyFlairs, source)
+	}
+
+	community, err = c.repo.AddFlair(ctx, community, communities.NewFlair(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return community, nil
+}
+
+// DeleteFlair removes the flair template. Posts keep the copies of the flair they already have
+func (c *CommunityHandler) DeleteFlair(ctx context.Context, name string, flairID users.ID) (*communities.Community, error) {
+	source := "DeleteFlair"
+	community, err := c.moderatedCommunity(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	community, err = c.repo.DeleteFlair(ctx, community, flairID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return community, nil
+}
+
+// moderatedCommunity returns the community if the user of the request is among its moderators
+func (c *CommunityHandler) moderatedCommunity(ctx context.Context, name string) (*communities.Community, error) {
+	community, err := c.repo.GetCommunityByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !community.IsModerator(viewerID(ctx)) {
+		return nil, errs.ErrNotModerator
+	}
+
+	return community, nil
+}
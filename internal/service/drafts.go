@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type DraftStorage interface {
+	AddDraft(ctx context.Context, draft *posts.Draft) error
+	GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error)
+	GetDrafts(ctx context.Context, authorID users.ID) ([]*posts.Draft, error)
+	UpdateDraft(ctx context.Context, draft *posts.Draft) error
+	DeleteDraft(ctx context.Context, draftID users.ID) error
+	// ClaimDraft atomically removes the draft and returns its latest version
+	ClaimDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error)
+}
+
+// WithDrafts lets authors keep unfinished posts on the server
+func WithDrafts(drafts DraftStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.drafts = drafts
+	}
+}
+
+// CreateDraft saves the payload as is, nothing is validated until the draft is published
+func (p *PostHandler) CreateDraft(ctx context.Context, payload posts.PostPayload) (*posts.Draft, error) {
+	source := "CreateDraft"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	switch {
+	case p.drafts == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case !ok:
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	draftList, err := p.drafts.GetDrafts(ctx, author.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if len(draftList) >= posts.MaxDrafts {
+		return nil, errors.Wrap(errs.ErrTooManyDrafts, source)
+	}
+
+	draft := posts.NewDraft(*author, payload)
+	if err = p.drafts.AddDraft(ctx, draft); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	// Concurrent requests may all pass the check above, so the limit is checked once more with the draft in place
+	if draftList, err = p.drafts.GetDrafts(ctx, author.ID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if len(draftList) > posts.MaxDrafts {
+		if err = p.drafts.DeleteDraft(ctx, draft.ID); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+		return nil, errors.Wrap(errs.ErrTooManyDrafts, source)
+	}
+
+	return draft, nil
+}
+
+// GetDrafts returns the drafts of the user, the most recently saved first
+func (p *PostHandler) GetDrafts(ctx context.Context) ([]*posts.Draft, error) {
+	source := "GetDrafts"
+	authorID := viewerID(ctx)
+	switch {
+	case p.drafts == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case authorID == "":
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	draftList, err := p.drafts.GetDrafts(ctx, authorID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return draftList, nil
+}
+
+func (p *PostHandler) GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) {
+	source := "GetDraft"
+	draft, err := p.ownDraft(ctx, draftID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return draft, nil
+}
+
+func (p *PostHandler) UpdateDraft(ctx context.Context, draftID users.ID, payload posts.PostPayload) (*posts.Draft, error) {
+	source := "UpdateDraft"
+	draft, err := p.ownDraft(ctx, draftID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	draft.Update(payload)
+	if err = p.drafts.UpdateDraft(ctx, draft); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return draft, nil
+}
+
+func (p *PostHandler) DeleteDraft(ctx context.Context, draftID users.ID) error {
+	source := "DeleteDraft"
+	if _, err := p.ownDraft(ctx, draftID); err != nil {
+		return errors.Wrap(err, source)
+	}
+	if err := p.drafts.DeleteDraft(ctx, draftID); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+// PublishDraft validates the draft as CreatePost does and turns it into a post. The draft is taken away before
+// the post is published, so concurrent requests publish it once. It is put back if the post is not published
+func (p *PostHandler) PublishDraft(ctx context.Context, draftID users.ID) (*posts.Post, error) {
+	source := "PublishDraft"
+	if _, err := p.ownDraft(ctx, draftID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	draft, err := p.drafts.ClaimDraft(ctx, draftID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	newPost, reposts, err := p.publishDraft(ctx, draft)
+	if err != nil {
+		if restoreErr := p.drafts.AddDraft(ctx, draft); restoreErr != nil {
+			return nil, errors.Wrap(restoreErr, source)
+		}
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.withReposts(ctx, newPost, reposts)
+}
+
+func (p *PostHandler) publishDraft(ctx context.Context, draft *posts.Draft) (*posts.Post, []users.ID, error) {
+	payload := draft.Post
+	if err := p.validatePayload(ctx, &payload, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	reposts, err := p.reposts(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	newPost, err := p.publish(ctx, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newPost, reposts, nil
+}
+
+// ownDraft returns the draft of the user. Drafts of other users are reported as not found
+func (p *PostHandler) ownDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) {
+	if p.drafts == nil {
+		return nil, errs.ErrFeatureDisabled
+	}
+
+	draft, err := p.drafts.GetDraft(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if authorID := viewerID(ctx); authorID == "" || draft.Author.ID != authorID {
+		return nil, errs.ErrDraftNotFound
+	}
+
+	return draft, nil
+}
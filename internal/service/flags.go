@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// SetPostFlags marks the post as NSFW and/or spoiler. Both the author and the moderators of the community may do it
+func (p *PostHandler) SetPostFlags(ctx context.Context, postID users.ID, flags posts.FlagsPayload) (*posts.Post, error) {
+	source := "SetPostFlags"
+	post, _, err := p.editablePost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if post, err = p.actionController.SetFlags(ctx, post, flags); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
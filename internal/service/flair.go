@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// SetPostFlair replaces the flair of the post. Both the author and the moderators of the community may do it
+func (p *PostHandler) SetPostFlair(ctx context.Context, postID users.ID, payload posts.FlairPayload) (*posts.Post, error) {
+	source := "SetPostFlair"
+	post, community, err := p.editablePost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	flair, err := flairOf(community, payload.FlairID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if post, err = p.actionController.SetFlair(ctx, post, flair); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+// flairOf copies the flair template of the community into a post flair, no flair id means no flair
+func flairOf(community *communities.Community, flairID users.ID) (*posts.Flair, error) {
+	if flairID == "" {
+		return nil, nil
+	}
+
+	template, err := community.GetFlair(flairID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &posts.Flair{
+		ID:    template.ID,
+		Text:  template.Text,
+		Color: template.Color,
+	}, nil
+}
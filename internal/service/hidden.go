@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// HidePost removes the post from the feeds of the viewer. The post stays reachable by its id
+func (p *PostHandler) HidePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	source := "HidePost"
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	post, err = p.actionController.HidePost(ctx, post)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) UnhidePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	source := "UnhidePost"
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	post, err = p.actionController.UnhidePost(ctx, post)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error) {
+	source := "GetHiddenPosts"
+	postList, err := p.repo.GetHiddenPosts(ctx, posts.NewPage(page.Limit, page.Offset))
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.viewAll(ctx, postList)
+}
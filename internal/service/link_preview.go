@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/pkg/linkpreview"
+)
+
+const (
+	DefaultPreviewWorkers   = 4
+	DefaultPreviewQueueSize = 256
+	DefaultPreviewTimeout   = 10 * time.Second
+)
+
+type LinkPreviewStorage interface {
+	SetLinkPreview(ctx context.Context, postID users.ID, preview *posts.LinkPreview) error
+}
+
+type LinkPreviewConfig struct {
+	Workers   int
+	QueueSize int           // Links waiting to be fetched, new ones are dropped when the queue is full
+	Timeout   time.Duration // Limit for building a single preview, the oEmbed request included
+}
+
+type linkPreviewJob struct {
+	postID users.ID
+	url    string
+}
+
+// LinkPreviewWorker fetches the pages link posts point to and saves their previews in the background
+type LinkPreviewWorker struct {
+	fetcher linkpreview.Fetcher
+	repo    LinkPreviewStorage
+	logger  *zap.SugaredLogger
+	jobs    chan linkPreviewJob
+	workers int
+	timeout time.Duration
+}
+
+func NewLinkPreviewWorker(fetcher linkpreview.Fetcher, repo LinkPreviewStorage, logger *zap.SugaredLogger, cfg LinkPreviewConfig) *LinkPreviewWorker {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultPreviewWorkers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultPreviewQueueSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultPreviewTimeout
+	}
+
+	return &LinkPreviewWorker{
+		fetcher: fetcher,
+		repo:    repo,
+		logger:  logger,
+		jobs:    make(chan linkPreviewJob, cfg.QueueSize),
+		workers: cfg.Workers,
+		timeout: cfg.Timeout,
+	}
+}
+
+// WithLinkPreviews makes the handler queue every new link post for preview extraction
+func WithLinkPreviews(worker *LinkPreviewWorker) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.previews = worker
+	}
+}
+
+// Enqueue schedules the preview of the post without blocking. It reports whether the link has been queued
+func (w *LinkPreviewWorker) Enqueue(postID users.ID, url string) bool {
+	select {
+	case w.jobs <- linkPreviewJob{postID: postID, url: url}:
+		return true
+	default:
+		w.logger.Warnw("link preview queue is full", "post", postID, "url", url)
+		return false
+	}
+}
+
+// Run processes the queue until the context is canceled
+func (w *LinkPreviewWorker) Run(ctx context.Context) {
+	wg := &sync.WaitGroup{}
+	for range w.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.jobs:
+					w.process(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *LinkPreviewWorker) process(ctx context.Context, job linkPreviewJob) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	preview, err := linkpreview.Extract(ctx, w.fetcher, job.url)
+	if err != nil {
+		w.logger.Infow("link preview is not available", "post", job.postID, "url", job.url, "error", err.Error())
+		return
+	}
+
+	if err = w.repo.SetLinkPreview(ctx, job.postID, &posts.LinkPreview{
+		Title:        preview.Title,
+		Description:  preview.Description,
+		ThumbnailURL: preview.ThumbnailURL,
+		SiteName:     preview.SiteName,
+	}); err != nil {
+		w.logger.Errorw("saving link preview failed", "post", job.postID, "error", err.Error())
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// WithArchiveAfter makes the posts older than the age read-only: they can be neither commented nor voted on
+func WithArchiveAfter(age time.Duration) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.archiveAfter = age
+	}
+}
+
+// LockPost stops the post from getting new comments and, if requested, votes. Only the moderators of the community can do it
+func (p *PostHandler) LockPost(ctx context.Context, postID users.ID, payload posts.LockPayload) (*posts.Post, error) {
+	source := "LockPost"
+	post, err := p.moderatedPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if post, err = p.actionController.SetLock(ctx, post, true, payload.FreezeVotes); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) UnlockPost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	source := "UnlockPost"
+	post, err := p.moderatedPost(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if post, err = p.actionController.SetLock(ctx, post, false, false); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+// moderatedPost returns the post if the user is a moderator of its community
+func (p *PostHandler) moderatedPost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	community, err := p.getCommunity(ctx, post.Category)
+	if err != nil {
+		return nil, err
+	}
+	if !community.IsModerator(viewerID(ctx)) {
+		return nil, errs.ErrNotModerator
+	}
+
+	return post, nil
+}
+
+// archived returns the post flagged as archived if it is old enough. The stored post is never modified
+func (p *PostHandler) archived(post *posts.Post) *posts.Post {
+	if post.IsArchived(p.archiveAfter, time.Now()) {
+		return post.AsArchived()
+	}
+
+	return post
+}
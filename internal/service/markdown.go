@@ -0,0 +1,27 @@
+package service
+
+import (
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
+)
+
+// WithMarkdown makes the handler render the text of the posts and the comments into sanitized HTML
+func WithMarkdown(renderer *markdown.Renderer) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.markdown = renderer
+	}
+}
+
+// render returns the HTML of the Markdown source with the mentioned users linked, nothing if rendering is off
+func (p *PostHandler) render(source string, mentioned []jwt.TokenPayload) string {
+	if p.markdown == nil {
+		return ""
+	}
+
+	usernames := make([]string, 0, len(mentioned))
+	for _, user := range mentioned {
+		usernames = append(usernames, string(user.Login))
+	}
+
+	return p.markdown.RenderMentions(source, usernames)
+}
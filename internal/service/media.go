@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/pkg/imaging"
+)
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type MediaHandler struct {
+	blobs BlobStore
+}
+
+func NewMediaHandler(blobs BlobStore) *MediaHandler {
+	return &MediaHandler{
+		blobs: blobs,
+	}
+}
+
+// UploadImage strips the metadata off the image, generates its thumbnail and saves both to the blob store
+func (m *MediaHandler) UploadImage(ctx context.Context, data []byte) (*posts.PostImage, error) {
+	source := "UploadImage"
+	if int64(len(data)) > media.MaxImageSize {
+		return nil, errors.Wrap(errs.ErrImageTooLarge, source)
+	}
+
+	img, err := imaging.Sanitize(data, media.MaxImagePixels, media.MaxImageFrames)
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedType):
+		return nil, errors.Wrap(errs.ErrUnsupportedMedia, source)
+	case errors.Is(err, imaging.ErrTooManyPixels), errors.Is(err, imaging.ErrTooManyFrames):
+		return nil, errors.Wrap(errs.ErrImageTooLarge, source)
+	case err != nil:
+		return nil, errors.Wrap(errs.ErrBadImage, source)
+	}
+
+	thumb, err := img.Thumbnail(media.ThumbnailSide)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	name := uuid.New().String()
+	postImage := &posts.PostImage{
+		ContentType:  img.ContentType,
+		Width:        img.Width,
+		Height:       img.Height,
+		Key:          name + imaging.Extension(img.ContentType),
+		ThumbnailKey: name + "_thumb" + imaging.Extension(thumb.ContentType),
+	}
+	postImage.URL = media.URLPrefix + postImage.Key
+	postImage.ThumbnailURL = media.URLPrefix + postImage.ThumbnailKey
+
+	if err = m.blobs.Put(ctx, postImage.Key, img.Data, img.ContentType); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = m.blobs.Put(ctx, postImage.ThumbnailKey, thumb.Data, thumb.ContentType); err != nil {
+		m.blobs.Delete(ctx, postImage.Key) //nolint:errcheck
+		return nil, errors.Wrap(err, source)
+	}
+
+	return postImage, nil
+}
+
+func (m *MediaHandler) DeleteImage(ctx context.Context, image *posts.PostImage) error {
+	source := "DeleteImage"
+	if err := m.blobs.Delete(ctx, image.Key); err != nil {
+		return errors.Wrap(err, source)
+	}
+	if err := m.blobs.Delete(ctx, image.ThumbnailKey); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+func (m *MediaHandler) GetBlob(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error) {
+	source := "GetBlob"
+	blob, info, err := m.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	return blob, info, nil
+}
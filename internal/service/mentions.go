@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type MentionStorage interface {
+	AddMentions(ctx context.Context, events []*posts.MentionEvent) error
+	GetMentions(ctx context.Context, userID users.ID, page posts.Page) ([]*posts.MentionEvent, error)
+}
+
+// WithMentions links the users referenced as @username or /u/username in the posts and the comments
+// and tells them where they were mentioned. Without it the references stay plain text
+func WithMentions(userStorage UserStorage, mentions MentionStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.users = userStorage
+		p.mentions = mentions
+	}
+}
+
+// GetMentions returns the page of the mentions of the viewer, the most recent first
+func (p *PostHandler) GetMentions(ctx context.Context, page posts.Page) ([]*posts.MentionEvent, error) {
+	source := "GetMentions"
+	userID := viewerID(ctx)
+	switch {
+	case p.mentions == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case userID == "":
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	events, err := p.mentions.GetMentions(ctx, userID, posts.NewPage(page.Limit, page.Offset))
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return events, nil
+}
+
+// resolveMentions returns the registered users referenced in the text, nothing if mentions are off
+func (p *PostHandler) resolveMentions(ctx context.Context, text string) ([]jwt.TokenPayload, error) {
+	if p.users == nil {
+		return nil, nil
+	}
+	usernames, err := posts.ParseMentions(text)
+	if err != nil || len(usernames) == 0 {
+		return nil, err
+	}
+
+	userList, err := p.users.GetUsersByLogins(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	// Logins are case-insensitive, so @Alice mentions alice
+	byLogin := make(map[string]*users.User, len(userList))
+	for _, user := range userList {
+		byLogin[strings.ToLower(string(user.Username))] = user
+	}
+	mentioned := make([]jwt.TokenPayload, 0, len(userList))
+	for _, username := range usernames {
+		if user, ok := byLogin[strings.ToLower(string(username))]; ok {
+			mentioned = append(mentioned, jwt.TokenPayload{Login: user.Username, ID: user.ID})
+		}
+	}
+
+	return mentioned, nil
+}
+
+// notifyMentions stores an event for each of the users mentioned in the post or the comment who were not mentioned in it before.
+// A lost event is not worth failing the content that has already been saved
+func (p *PostHandler) notifyMentions(ctx context.Context, author jwt.TokenPayload, postID, commentID users.ID, mentioned, before []jwt.TokenPayload) {
+	if p.mentions == nil || len(mentioned) == 0 {
+		return
+	}
+
+	p.mentions.AddMentions(ctx, posts.NewMentionEvents(author, postID, commentID, mentioned, before)) //nolint:errcheck
+}
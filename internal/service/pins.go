@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type PinStorage interface {
+	GetPins(ctx context.Context, scope posts.PostCategory) (posts.Pins, error)
+	// UpdatePins replaces the pins of the scope with the ones update makes of them. The other updates of the scope
+	// wait in the meantime, so no two of them see the same pins. More than MaxPins pins are refused with ErrTooManyPins
+	UpdatePins(ctx context.Context, scope posts.PostCategory, update func(pins posts.Pins) (posts.Pins, error)) error
+}
+
+// WithPins lets moderators pin posts on top of their categories and admins on top of the front page
+func WithPins(pins PinStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.pins = pins
+	}
+}
+
+// WithAdmins grants the users the rights of site administrators
+func WithAdmins(logins ...users.Username) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.admins = append(p.admins, logins...)
+	}
+}
+
+// PinPost pins the post or moves it among the pins if it is pinned already
+func (p *PostHandler) PinPost(ctx context.Context, postID users.ID, payload posts.PinPayload) (*posts.Post, error) {
+	source := "PinPost"
+	now := time.Now()
+	if err := payload.Validate(now); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	post, scope, err := p.pinnablePost(ctx, postID, payload.FrontPage)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if err = p.pins.UpdatePins(ctx, scope, func(pins posts.Pins) (posts.Pins, error) {
+		live, err := p.livePins(ctx, pins, now)
+		if err != nil {
+			return nil, err
+		}
+		return live.Place(posts.NewPin(postID, scope, payload), payload.Position), nil
+	}); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post.AsPinned())
+}
+
+func (p *PostHandler) UnpinPost(ctx context.Context, postID users.ID, frontPage bool) (*posts.Post, error) {
+	source := "UnpinPost"
+	post, scope, err := p.pinnablePost(ctx, postID, frontPage)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if err = p.pins.UpdatePins(ctx, scope, func(pins posts.Pins) (posts.Pins, error) {
+		live, err := p.livePins(ctx, pins, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		rest, ok := live.Remove(postID)
+		if !ok {
+			return nil, errs.ErrPinNotFound
+		}
+		return rest, nil
+	}); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+// pinnablePost returns the post along with the scope of the pin if the user may pin it there
+func (p *PostHandler) pinnablePost(ctx context.Context, postID users.ID, frontPage bool) (*posts.Post, posts.PostCategory, error) {
+	if p.pins == nil {
+		return nil, "", errs.ErrFeatureDisabled
+	}
+
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, "", err
+	}
+	if frontPage {
+		if !p.isAdmin(ctx) {
+			return nil, "", errs.ErrNotAdmin
+		}
+		return post, posts.FrontPage, nil
+	}
+
+	community, err := p.getCommunity(ctx, post.Category)
+	if err != nil {
+		return nil, "", err
+	}
+	if !community.IsModerator(viewerID(ctx)) {
+		return nil, "", errs.ErrNotModerator
+	}
+
+	return post, post.Category, nil
+}
+
+// livePins returns the pins without the expired ones and the ones of removed posts
+func (p *PostHandler) livePins(ctx context.Context, pins posts.Pins, now time.Time) (posts.Pins, error) {
+	live := make(posts.Pins, 0, len(pins))
+	for _, pin := range pins.Active(now) {
+		_, err := p.repo.GetPostByID(ctx, pin.PostID)
+		switch {
+		case errors.Is(err, errs.ErrPostNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		live = append(live, pin)
+	}
+
+	return live, nil
+}
+
+// pinFirst puts the active pins of the scope on top of the feed
+func (p *PostHandler) pinFirst(ctx context.Context, scope posts.PostCategory, postList []*posts.Post) ([]*posts.Post, error) {
+	if p.pins == nil {
+		return postList, nil
+	}
+
+	pins, err := p.pins.GetPins(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return posts.PinFirst(postList, pins.Active(time.Now())), nil
+}
+
+func (p *PostHandler) isAdmin(ctx context.Context) bool {
+	payload, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	return ok && slices.Contains(p.admins, payload.Login)
+}
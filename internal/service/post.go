@@ -2,72 +2,157 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
 	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
 	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
 	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
 )
 
 type PostStorage interface {
-	GetAllPosts(ctx context.Context) ([]*posts.Post, error)
-	GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error)
-	GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error)
+	GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error)
+	GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error)
+	GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error)
 	GetPostByID(ctx context.Context, postID users.ID) (*posts.Post, error)
+	GetPostsByURL(ctx context.Context, canonicalURL string) ([]*posts.Post, error)
+	GetPostsByIDs(ctx context.Context, postIDs []users.ID) ([]*posts.Post, error)
+	GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error)
 	CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error)
 	DeletePost(ctx context.Context, postID users.ID) error
+	SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error)
+	CreateCrosspost(ctx context.Context, parent *posts.Post, payload posts.CrosspostPayload) (*posts.Post, error)
+	RemoveCrosspostSource(ctx context.Context, parentID users.ID) error
+	RemoveCrosspostRef(ctx context.Context, parentID, crosspostID users.ID) error
+	GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error)
+	// GetComments returns the page of the top-level comments followed by all the replies under them
+	GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error)
+	// LoadCommentThread returns the post carrying the comment, the comments above it along with their replies,
+	// and all the replies under the comment. Those are all the comments the actions on the comment need
+	LoadCommentThread(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error)
+	GetCommentsByIDs(ctx context.Context, commentIDs []users.ID) ([]*posts.PostComment, error)
 }
 
 type PostActions interface {
 	AddComment(ctx context.Context, post *posts.Post, comment posts.Comment) (*posts.Post, error)
 	DeleteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error)
+	EditComment(ctx context.Context, post *posts.Post, commentID users.ID, comment posts.Comment) (*posts.Post, error)
 	Upvote(ctx context.Context, post *posts.Post) (*posts.Post, error)
 	Downvote(ctx context.Context, post *posts.Post) (*posts.Post, error)
 	Unvote(ctx context.Context, post *posts.Post) (*posts.Post, error)
-	UpdateViews(ctx context.Context, postID users.ID) error
+	UpvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error)
+	DownvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error)
+	UnvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error)
+	CastBallot(ctx context.Context, post *posts.Post, choice []int) (*posts.Post, error)
+	HidePost(ctx context.Context, post *posts.Post) (*posts.Post, error)
+	UnhidePost(ctx context.Context, post *posts.Post) (*posts.Post, error)
+	SetFlair(ctx context.Context, post *posts.Post, flair *posts.Flair) (*posts.Post, error)
+	SetFlags(ctx context.Context, post *posts.Post, flags posts.FlagsPayload) (*posts.Post, error)
+	SetLock(ctx context.Context, post *posts.Post, locked, votesFrozen bool) (*posts.Post, error)
 }
 
 type PostHandler struct {
 	repo             PostStorage
 	actionController PostActions
+	communities      CommunityStorage
+	media            *MediaHandler
+	previews         *LinkPreviewWorker
+	saved            SavedStorage
+	preferences      PreferencesStorage
+	scheduled        ScheduledStorage
+	drafts           DraftStorage
+	pins             PinStorage
+	admins           []users.Username
+	archiveAfter     time.Duration
+	repostPolicy     RepostPolicy
+	markdown         *markdown.Renderer
+	viewTracker      *ViewTracker
+	stats            *StatsRecorder
+	users            UserStorage
+	mentions         MentionStorage
+	commentDepth     int
 }
 
-func NewPostHandler(storage PostStorage, actions PostActions) *PostHandler {
-	return &PostHandler{
+type PostHandlerOption func(*PostHandler)
+
+func NewPostHandler(storage PostStorage, actions PostActions, communities CommunityStorage, opts ...PostHandlerOption) *PostHandler {
+	handler := &PostHandler{
 		repo:             storage,
 		actionController: actions,
+		communities:      communities,
+		commentDepth:     posts.DefaultCommentDepth,
+	}
+
+	for _, opt := range opts {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithMedia enables image posts
+func WithMedia(media *MediaHandler) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.media = media
 	}
 }
 
-func (p *PostHandler) GetAllPosts(ctx context.Context) ([]*posts.Post, error) {
+func (p *PostHandler) GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error) {
 	source := "GetAllPosts"
-	postList, err := p.repo.GetAllPosts(ctx)
+	query, err := p.feedQuery(ctx, query)
 	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
+	postList, err := p.repo.GetAllPosts(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if postList, err = p.pinFirst(ctx, posts.FrontPage, postList); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
 
-	return postList, nil
+	return p.viewAll(ctx, postList)
 }
 
-func (p *PostHandler) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error) {
+func (p *PostHandler) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error) {
 	source := "GetPostsByCategory"
-	postList, err := p.repo.GetPostsByCategory(ctx, postCategory)
+	if err := p.checkCategory(ctx, postCategory); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	query, err := p.feedQuery(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postList, err := p.repo.GetPostsByCategory(ctx, postCategory, query)
 	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
+	if postList, err = p.pinFirst(ctx, postCategory, postList); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
 
-	return postList, nil
+	return p.viewAll(ctx, postList)
 }
 
-func (p *PostHandler) GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error) {
+func (p *PostHandler) GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error) {
 	source := "GetPostsByUser"
-	postList, err := p.repo.GetPostsByUser(ctx, userLogin)
+	query, err := p.feedQuery(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postList, err := p.repo.GetPostsByUser(ctx, userLogin, query)
 	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
 
-	return postList, nil
+	return p.viewAll(ctx, postList)
 }
 
 func (p *PostHandler) GetPostByID(ctx context.Context, postID users.ID) (*posts.Post, error) {
@@ -77,31 +162,195 @@ func (p *PostHandler) GetPostByID(ctx context.Context, postID users.ID) (*posts.
 		return post, errors.Wrap(err, source)
 	}
 
-	if err = p.actionController.UpdateViews(ctx, postID); err != nil {
-		return nil, errors.Wrap(err, source)
+	// A view lost to an unavailable counter is not worth failing the read
+	counted := p.viewTracker == nil
+	if p.viewTracker != nil {
+		counted, _ = p.viewTracker.Record(ctx, postID, viewer(ctx), time.Now())
+		post = post.WithPendingViews(p.viewTracker.Pending(postID))
+	}
+	if counted {
+		p.record(postID, posts.StatsCounts{Views: 1})
 	}
 
-	return post.UpdateViews(), nil
+	return p.view(ctx, post)
 }
 
 func (p *PostHandler) CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
 	source := "CreatePost"
-	if postPayload.Type == posts.WithLink && !posts.URLTemplate.MatchString(postPayload.URL) {
-		return nil, errors.Wrap(errs.ErrInvalidURL, source)
+	if err := p.validatePayload(ctx, &postPayload, time.Now()); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	reposts, err := p.reposts(ctx, postPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	newPost, err := p.publish(ctx, postPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.withReposts(ctx, newPost, reposts)
+}
+
+// validatePayload checks the content of a text, link or poll post about to be published at publishAt
+// and completes the payload with the settings of the community
+func (p *PostHandler) validatePayload(ctx context.Context, postPayload *posts.PostPayload, publishAt time.Time) error {
+	if postPayload.Type == posts.WithImage {
+		return errs.ErrInvalidPostType
+	}
+	// The mentioned users are looked up once the post is published, only their number is checked ahead
+	if p.users != nil {
+		if _, err := posts.ParseMentions(postPayload.Text); err != nil {
+			return err
+		}
+	}
+	if postPayload.Type == posts.WithLink {
+		if !posts.URLTemplate.MatchString(postPayload.URL) {
+			return errs.ErrInvalidURL
+		}
+		canonicalURL, err := posts.CanonicalURL(postPayload.URL)
+		if err != nil {
+			return err
+		}
+		postPayload.CanonicalURL = canonicalURL
+	}
+	if postPayload.Type == posts.WithPoll {
+		if postPayload.Poll == nil {
+			return errs.ErrBadPoll
+		}
+		if err := postPayload.Poll.Validate(publishAt); err != nil {
+			return err
+		}
+	}
+
+	return p.applyCommunity(ctx, postPayload)
+}
+
+// publish creates the post from an already validated payload
+func (p *PostHandler) publish(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
+	mentioned, err := p.resolveMentions(ctx, postPayload.Text)
+	if err != nil {
+		return nil, err
+	}
+	postPayload.Mentions = mentioned
+	postPayload.TextHTML = p.render(postPayload.Text, mentioned)
+	newPost, err := p.repo.CreatePost(ctx, postPayload)
+	if err != nil {
+		return nil, err
+	}
+	p.notifyMentions(ctx, newPost.Author, newPost.ID, "", newPost.Mentions, nil)
+	if newPost.Type == posts.WithLink && p.previews != nil {
+		p.previews.Enqueue(newPost.ID, newPost.URL)
 	}
+	// The author upvotes every new post
+	p.record(newPost.ID, posts.StatsCounts{Upvotes: 1})
 
-	return p.repo.CreatePost(ctx, postPayload)
+	return newPost, nil
+}
+
+func (p *PostHandler) CreateImagePost(ctx context.Context, postPayload posts.PostPayload, image []byte) (*posts.Post, error) {
+	source := "CreateImagePost"
+	if p.media == nil {
+		return nil, errors.Wrap(errs.ErrUnsupportedMedia, source)
+	}
+	if err := p.applyCommunity(ctx, &postPayload); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	mentioned, err := p.resolveMentions(ctx, postPayload.Text)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postPayload.Mentions = mentioned
+
+	postImage, err := p.media.UploadImage(ctx, image)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postPayload.Type = posts.WithImage
+	postPayload.URL = ""
+	postPayload.Image = postImage
+	postPayload.TextHTML = p.render(postPayload.Text, postPayload.Mentions)
+
+	newPost, err := p.repo.CreatePost(ctx, postPayload)
+	if err != nil {
+		p.media.DeleteImage(ctx, postImage) //nolint:errcheck
+		return nil, errors.Wrap(err, source)
+	}
+	p.notifyMentions(ctx, newPost.Author, newPost.ID, "", newPost.Mentions, nil)
+	p.record(newPost.ID, posts.StatsCounts{Upvotes: 1})
+
+	return newPost, nil
 }
 
 func (p *PostHandler) DeletePost(ctx context.Context, postID users.ID) error {
 	source := "DeletePost"
-	if err := p.repo.DeletePost(ctx, postID); err != nil {
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	if err = p.repo.DeletePost(ctx, postID); err != nil {
 		return errors.Wrap(err, source)
 	}
+	if p.saved != nil {
+		if err = p.saved.DeleteSavedItems(ctx, postID); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+
+	if post.CrosspostCount > 0 {
+		if err = p.repo.RemoveCrosspostSource(ctx, postID); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+	if post.CrosspostParent != nil && !post.CrosspostParent.Removed {
+		if err = p.repo.RemoveCrosspostRef(ctx, post.CrosspostParent.ID, postID); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+
+	// The post is already gone, so a leftover blob is not worth failing the request.
+	// Crossposts share the image of the original and never own it
+	if post.Image != nil && post.CrosspostParent == nil && p.media != nil {
+		p.media.DeleteImage(ctx, post.Image) //nolint:errcheck
+	}
 
 	return nil
 }
 
+func (p *PostHandler) Crosspost(ctx context.Context, postID users.ID, payload posts.CrosspostPayload) (*posts.Post, error) {
+	source := "Crosspost"
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	parentID, err := post.CrosspostSource()
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if parentID != post.ID {
+		if post, err = p.repo.GetPostByID(ctx, parentID); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+	}
+
+	if payload.Category == post.Category {
+		return nil, errors.Wrap(errs.ErrBadCrosspost, source)
+	}
+	if err = p.checkCategory(ctx, payload.Category); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	crosspost, err := p.repo.CreateCrosspost(ctx, post, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, crosspost)
+}
+
 func (p *PostHandler) Upvote(ctx context.Context, postID users.ID) (*posts.Post, error) {
 	source := "Upvote"
 	post, err := p.repo.GetPostByID(ctx, postID)
@@ -109,12 +358,18 @@ func (p *PostHandler) Upvote(ctx context.Context, postID users.ID) (*posts.Post,
 		return nil, errors.Wrap(err, source)
 	}
 
+	if err = p.archived(post).CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	before := post.VoteOf(viewerID(ctx))
 	post, err = p.actionController.Upvote(ctx, post)
 	if err != nil {
 		return post, errors.Wrap(err, source)
 	}
+	p.record(postID, posts.VoteChange(before, post.VoteOf(viewerID(ctx))))
 
-	return post, nil
+	return p.view(ctx, post)
 }
 
 func (p *PostHandler) Downvote(ctx context.Context, postID users.ID) (*posts.Post, error) {
@@ -124,12 +379,18 @@ func (p *PostHandler) Downvote(ctx context.Context, postID users.ID) (*posts.Pos
 		return nil, errors.Wrap(err, source)
 	}
 
+	if err = p.archived(post).CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	before := post.VoteOf(viewerID(ctx))
 	post, err = p.actionController.Downvote(ctx, post)
 	if err != nil {
 		return post, errors.Wrap(err, source)
 	}
+	p.record(postID, posts.VoteChange(before, post.VoteOf(viewerID(ctx))))
 
-	return post, nil
+	return p.view(ctx, post)
 }
 
 func (p *PostHandler) Unvote(ctx context.Context, postID users.ID) (*posts.Post, error) {
@@ -139,12 +400,18 @@ func (p *PostHandler) Unvote(ctx context.Context, postID users.ID) (*posts.Post,
 		return nil, errors.Wrap(err, source)
 	}
 
+	if err = p.archived(post).CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	before := post.VoteOf(viewerID(ctx))
 	post, err = p.actionController.Unvote(ctx, post)
 	if err != nil {
 		return post, errors.Wrap(err, source)
 	}
+	p.record(postID, posts.VoteChange(before, post.VoteOf(viewerID(ctx))))
 
-	return post, nil
+	return p.view(ctx, post)
 }
 
 func (p *PostHandler) AddComment(ctx context.Context, postID users.ID, comment posts.Comment) (*posts.Post, error) {
@@ -153,22 +420,38 @@ func (p *PostHandler) AddComment(ctx context.Context, postID users.ID, comment p
 		return nil, errs.ErrBadCommentBody
 	}
 
-	post, err := p.repo.GetPostByID(ctx, postID)
+	post, err := p.postWithComment(ctx, postID, comment.ParentID)
 	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
+	if comment.ParentID != "" {
+		if _, err = post.GetComment(comment.ParentID); err != nil {
+			return nil, errors.Wrap(errs.ErrBadParentComment, source)
+		}
+	}
 
+	if err = p.archived(post).CheckCommentable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if comment.Mentions, err = p.resolveMentions(ctx, comment.Body); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	comment.BodyHTML = p.render(comment.Body, comment.Mentions)
 	post, err = p.actionController.AddComment(ctx, post, comment)
 	if err != nil {
 		return post, errors.Wrap(err, source)
 	}
+	p.record(postID, posts.StatsCounts{Comments: 1})
+	newComment := post.Comments[len(post.Comments)-1]
+	p.notifyMentions(ctx, newComment.Author, postID, newComment.ID, newComment.Mentions, nil)
 
-	return post, nil
+	return p.view(ctx, post)
 }
 
 func (p *PostHandler) DeleteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
 	source := "DeleteComment"
-	post, err := p.repo.GetPostByID(ctx, postID)
+	post, err := p.postWithComment(ctx, postID, commentID)
 	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
@@ -177,6 +460,182 @@ func (p *PostHandler) DeleteComment(ctx context.Context, postID, commentID users
 	if err != nil {
 		return post, errors.Wrap(err, source)
 	}
+	if p.saved != nil {
+		if err = p.saved.DeleteSavedItems(ctx, commentID); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) CastBallot(ctx context.Context, postID users.ID, ballot posts.BallotPayload) (*posts.Post, error) {
+	source := "CastBallot"
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if post.Type != posts.WithPoll {
+		return nil, errors.Wrap(errs.ErrNotAPoll, source)
+	}
+	if err = p.archived(post).CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	post, err = p.actionController.CastBallot(ctx, post, ballot.Choice)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error) {
+	source := "SearchPosts"
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" || utf8.RuneCountInString(query.Text) > posts.MaxSearchLength {
+		return nil, errors.Wrap(errs.ErrBadSearchQuery, source)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.From.After(query.To) {
+		return nil, errors.Wrap(errs.ErrBadSearchQuery, source)
+	}
+	if query.Category != "" {
+		if err := p.checkCategory(ctx, query.Category); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+	}
+	query.Page = posts.NewPage(query.Limit, query.Offset)
+	feed, err := p.feedQuery(ctx, posts.FeedQuery{})
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	query.NSFW = feed.NSFW
+
+	postList, err := p.repo.SearchPosts(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return p.viewAll(ctx, postList)
+}
+
+func (p *PostHandler) checkCategory(ctx context.Context, postCategory posts.PostCategory) error {
+	_, err := p.getCommunity(ctx, postCategory)
+	return err
+}
+
+func (p *PostHandler) getCommunity(ctx context.Context, postCategory posts.PostCategory) (*communities.Community, error) {
+	community, err := p.communities.GetCommunityByName(ctx, postCategory.String())
+	switch {
+	case errors.Is(err, errs.ErrCommunityNotFound):
+		return nil, errs.ErrInvalidCategory
+	case err != nil:
+		return nil, err
+	}
 
-	return post, nil
+	return community, nil
+}
+
+// applyCommunity checks the category of the payload, copies the flair picked by the author from its templates
+// and marks the post as NSFW if the community is
+func (p *PostHandler) applyCommunity(ctx context.Context, postPayload *posts.PostPayload) error {
+	community, err := p.getCommunity(ctx, postPayload.Category)
+	if err != nil {
+		return err
+	}
+
+	postPayload.NSFW = postPayload.NSFW || community.NSFW
+	postPayload.Flair, err = flairOf(community, postPayload.FlairID)
+
+	return err
+}
+
+// editablePost returns the post and its community if the user of the request is either the author of the post
+// or a moderator of the community
+func (p *PostHandler) editablePost(ctx context.Context, postID users.ID) (*posts.Post, *communities.Community, error) {
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, nil, err
+	}
+	community, err := p.getCommunity(ctx, post.Category)
+	if err != nil {
+		return nil, nil, err
+	}
+	if editor := viewerID(ctx); editor != post.Author.ID && !community.IsModerator(editor) {
+		return nil, nil, errs.ErrNotAuthor
+	}
+
+	return post, community, nil
+}
+
+// viewerID returns the id of the user the request is made by, if the request is authenticated
+func viewerID(ctx context.Context) users.ID {
+	if payload, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload); ok {
+		return payload.ID
+	}
+
+	return ""
+}
+
+// postWithComment returns the post carrying the comment along with the comments around it, if a comment is given
+func (p *PostHandler) postWithComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil || commentID == "" {
+		return post, err
+	}
+
+	return p.repo.LoadCommentThread(ctx, post, commentID)
+}
+
+// view returns the post as the viewer may see it, with the saved flags set. The post carries the first page
+// of its comments in the order they were left, the rest are loaded with GetComments
+func (p *PostHandler) view(ctx context.Context, post *posts.Post) (*posts.Post, error) {
+	page, err := p.repo.GetComments(ctx, post.ID, posts.CommentQuery{Sort: posts.SortOld, Limit: posts.DefaultCommentLimit})
+	if err != nil {
+		return nil, err
+	}
+	postList, err := p.views(ctx, []*posts.Post{post.WithComments(page.Comments)})
+	if err != nil {
+		return nil, err
+	}
+
+	return postList[0].WithCommentTree(p.commentDepth), nil
+}
+
+// viewAll prepares a list of posts for the viewer. Unlike single posts, lists never reveal the text of spoilers
+func (p *PostHandler) viewAll(ctx context.Context, postList []*posts.Post) ([]*posts.Post, error) {
+	postList, err := p.views(ctx, postList)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, post := range postList {
+		postList[i] = post.WithoutSpoiler().WithCommentTree(p.commentDepth)
+	}
+
+	return postList, nil
+}
+
+func (p *PostHandler) views(ctx context.Context, postList []*posts.Post) ([]*posts.Post, error) {
+	source := "views"
+	viewer, now := viewerID(ctx), time.Now()
+	saved := make(posts.SavedSet)
+	if viewer != "" && p.saved != nil && len(postList) != 0 {
+		var err error
+		if saved, err = p.saved.GetSavedIDs(ctx, viewer, posts.ItemIDs(postList...)); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+	}
+
+	for i, post := range postList {
+		if post.IsArchived(p.archiveAfter, now) {
+			post = post.AsArchived()
+		}
+		if p.markdown != nil {
+			post = post.WithHTML(p.markdown.Render)
+		}
+		postList[i] = post.ViewFor(viewer, now).MarkSaved(saved)
+	}
+
+	return postList, nil
 }
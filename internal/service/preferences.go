@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type PreferencesStorage interface {
+	GetPreferences(ctx context.Context, userID users.ID) (users.Preferences, error)
+	SetPreferences(ctx context.Context, userID users.ID, prefs users.Preferences) error
+}
+
+// WithPreferences lets users store the settings of their feeds. Without it NSFW posts are never shown in the feeds
+func WithPreferences(prefs PreferencesStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.preferences = prefs
+	}
+}
+
+func (p *PostHandler) GetPreferences(ctx context.Context) (users.Preferences, error) {
+	source := "GetPreferences"
+	userID := viewerID(ctx)
+	switch {
+	case p.preferences == nil:
+		return users.Preferences{}, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case userID == "":
+		return users.Preferences{}, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	prefs, err := p.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		return users.Preferences{}, errors.Wrap(err, source)
+	}
+
+	return prefs, nil
+}
+
+func (p *PostHandler) UpdatePreferences(ctx context.Context, prefs users.Preferences) (users.Preferences, error) {
+	source := "UpdatePreferences"
+	userID := viewerID(ctx)
+	switch {
+	case p.preferences == nil:
+		return users.Preferences{}, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case userID == "":
+		return users.Preferences{}, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	if err := p.preferences.SetPreferences(ctx, userID, prefs); err != nil {
+		return users.Preferences{}, errors.Wrap(err, source)
+	}
+
+	return prefs, nil
+}
+
+// feedQuery completes the query with the preferences of the viewer. Anonymous viewers never get NSFW posts
+func (p *PostHandler) feedQuery(ctx context.Context, query posts.FeedQuery) (posts.FeedQuery, error) {
+	query.NSFW = false
+	userID := viewerID(ctx)
+	if p.preferences == nil || userID == "" {
+		return query, nil
+	}
+
+	prefs, err := p.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		return query, err
+	}
+	query.NSFW = prefs.ShowNSFW
+
+	return query, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// RepostPolicy tells how the links posted again to the same category are treated
+type RepostPolicy struct {
+	Window time.Duration // Only the posts made within the window count as the same discussion, no detection if zero
+	Reject bool          // Refuse reposts instead of reporting them along with the new post
+}
+
+// WithRepostPolicy enables the detection of reposts of a link in the same category
+func WithRepostPolicy(policy RepostPolicy) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.repostPolicy = policy
+	}
+}
+
+// GetPostsByURL lists the discussions of the link, whatever form of it was posted. The posts are filtered for
+// the viewer as the feeds are
+func (p *PostHandler) GetPostsByURL(ctx context.Context, rawURL string) ([]*posts.Post, error) {
+	source := "GetPostsByURL"
+	canonicalURL, err := posts.CanonicalURL(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	feed, err := p.feedQuery(ctx, posts.FeedQuery{})
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	// The repo lists every post of the link, the detection of reposts needs them all
+	postList, err := p.repo.GetPostsByURL(ctx, canonicalURL)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	viewer := viewerID(ctx)
+	postList = slices.DeleteFunc(postList, func(post *posts.Post) bool {
+		return post.IsHiddenBy(viewer) || !feed.Matches(post)
+	})
+
+	return p.viewAll(ctx, postList)
+}
+
+// reposts returns the recent posts of the same link in the category of the validated payload
+func (p *PostHandler) reposts(ctx context.Context, postPayload posts.PostPayload) ([]users.ID, error) {
+	if postPayload.Type != posts.WithLink || p.repostPolicy.Window <= 0 {
+		return nil, nil
+	}
+
+	postList, err := p.repo.GetPostsByURL(ctx, postPayload.CanonicalURL)
+	if err != nil {
+		return nil, err
+	}
+	reposts := posts.Reposts(postList, postPayload.Category, p.repostPolicy.Window, time.Now())
+	if len(reposts) != 0 && p.repostPolicy.Reject {
+		return nil, errs.ErrRepost
+	}
+
+	return reposts, nil
+}
+
+// withReposts prepares the new post for its author, warning about the earlier posts of the same link
+func (p *PostHandler) withReposts(ctx context.Context, newPost *posts.Post, reposts []users.ID) (*posts.Post, error) {
+	newPost, err := p.view(ctx, newPost)
+	if err != nil {
+		return nil, err
+	}
+	if len(reposts) == 0 {
+		return newPost, nil
+	}
+
+	// The view may be the stored post itself, the warning is only for the response
+	warned := *newPost
+	warned.Reposts = reposts
+
+	return &warned, nil
+}
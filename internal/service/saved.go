@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type SavedStorage interface {
+	SaveItem(ctx context.Context, item posts.SavedItem) error
+	UnsaveItem(ctx context.Context, userID, itemID users.ID) error
+	GetSavedItems(ctx context.Context, userID users.ID, query posts.SavedQuery) ([]posts.SavedItem, error)
+	GetSavedIDs(ctx context.Context, userID users.ID, itemIDs []users.ID) (posts.SavedSet, error)
+	DeleteSavedItems(ctx context.Context, itemID users.ID) error
+}
+
+// WithSavedItems lets users save posts and comments
+func WithSavedItems(saved SavedStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.saved = saved
+	}
+}
+
+func (p *PostHandler) SavePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	source := "SavePost"
+	post, err := p.saveItem(ctx, postID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostHandler) UnsavePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	source := "UnsavePost"
+	post, err := p.unsaveItem(ctx, postID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostHandler) SaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	source := "SaveComment"
+	post, err := p.saveItem(ctx, postID, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostHandler) UnsaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	source := "UnsaveComment"
+	post, err := p.unsaveItem(ctx, postID, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+// GetSaved returns the items saved by the viewer, the most recently saved first.
+// Items of the posts and comments that have vanished or been deleted in the meantime are forgotten on the way,
+// and more items are fetched in their place, so the pages stay full and the offsets keep pointing at the same items
+func (p *PostHandler) GetSaved(ctx context.Context, query posts.SavedQuery) ([]*posts.SavedEntry, error) {
+	source := "GetSaved"
+	userID := viewerID(ctx)
+	switch {
+	case p.saved == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case userID == "":
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+	query.Page = posts.NewPage(query.Limit, query.Offset)
+
+	limit, offset := query.Limit, query.Offset
+	entries := make([]*posts.SavedEntry, 0, limit)
+	for {
+		items, err := p.saved.GetSavedItems(ctx, userID, query)
+		if err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+		found, vanished, err := p.savedEntries(ctx, items)
+		if err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+		entries = append(entries, found...)
+		for _, itemID := range vanished {
+			if err = p.saved.DeleteSavedItems(ctx, itemID); err != nil {
+				return nil, errors.Wrap(err, source)
+			}
+		}
+		if len(vanished) == 0 || len(items) < query.Limit {
+			return entries, nil
+		}
+
+		// Once the vanished items are forgotten, the items up to the end of the page are the ones returned
+		query.Offset, query.Limit = offset+len(entries), limit-len(entries)
+	}
+}
+
+// savedEntries returns the entries of the saved items along with the ids of the items that have vanished
+func (p *PostHandler) savedEntries(ctx context.Context, items []posts.SavedItem) ([]*posts.SavedEntry, []users.ID, error) {
+	postIDs := make([]users.ID, 0, len(items))
+	commentIDs := make([]users.ID, 0)
+	for _, item := range items {
+		postIDs = append(postIDs, item.PostID)
+		if item.Type == posts.ItemComment {
+			commentIDs = append(commentIDs, item.ItemID)
+		}
+	}
+	postList, err := p.repo.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	comments, err := p.repo.GetCommentsByIDs(ctx, commentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The posts carry just the saved comments, so the comments are viewed along with their posts
+	byPost := make(map[users.ID][]*posts.PostComment, len(postList))
+	for _, comment := range comments {
+		byPost[comment.PostID] = append(byPost[comment.PostID], comment)
+	}
+	for i, post := range postList {
+		postList[i] = post.WithComments(byPost[post.ID])
+	}
+	if postList, err = p.views(ctx, postList); err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[users.ID]*posts.Post, len(postList))
+	for _, post := range postList {
+		byID[post.ID] = post
+	}
+	entries := make([]*posts.SavedEntry, 0, len(items))
+	vanished := make([]users.ID, 0)
+	for _, item := range items {
+		post, ok := byID[item.PostID]
+		if !ok {
+			vanished = append(vanished, item.PostID)
+			continue
+		}
+		entry := &posts.SavedEntry{
+			Type:      item.Type,
+			Saved:     item.Saved,
+			PostID:    post.ID,
+			PostTitle: post.Title,
+		}
+		if item.Type == posts.ItemPost {
+			entry.Post = post.WithoutSpoiler().WithComments(make([]*posts.PostComment, 0))
+		} else if entry.Comment, err = post.GetComment(item.ItemID); err != nil || entry.Comment.Deleted {
+			vanished = append(vanished, item.ItemID)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, vanished, nil
+}
+
+func (p *PostHandler) saveItem(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	userID := viewerID(ctx)
+	switch {
+	case p.saved == nil:
+		return nil, errs.ErrFeatureDisabled
+	case userID == "":
+		return nil, errs.ErrBadPayload
+	}
+
+	post, err := p.postWithComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if commentID != "" {
+		comment, err := post.GetComment(commentID)
+		if err != nil {
+			return nil, err
+		}
+		// The placeholder of a deleted comment is not worth saving
+		if comment.Deleted {
+			return nil, errs.ErrCommentNotFound
+		}
+	}
+
+	if err = p.saved.SaveItem(ctx, posts.NewSavedItem(userID, post, commentID)); err != nil {
+		return nil, err
+	}
+
+	return p.view(ctx, post)
+}
+
+func (p *PostHandler) unsaveItem(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	userID := viewerID(ctx)
+	switch {
+	case p.saved == nil:
+		return nil, errs.ErrFeatureDisabled
+	case userID == "":
+		return nil, errs.ErrBadPayload
+	}
+
+	post, err := p.postWithComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	itemID := postID
+	if commentID != "" {
+		if _, err = post.GetComment(commentID); err != nil {
+			return nil, err
+		}
+		itemID = commentID
+	}
+
+	if err = p.saved.UnsaveItem(ctx, userID, itemID); err != nil {
+		return nil, err
+	}
+
+	return p.view(ctx, post)
+}
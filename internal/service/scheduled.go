@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type ScheduledStorage interface {
+	AddScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error
+	GetScheduledPost(ctx context.Context, scheduledID users.ID) (*posts.ScheduledPost, error)
+	GetScheduledPosts(ctx context.Context, authorID users.ID) ([]*posts.ScheduledPost, error)
+	GetDueScheduledPosts(ctx context.Context, now time.Time, limit int) ([]*posts.ScheduledPost, error)
+	UpdateScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error
+	DeleteScheduledPost(ctx context.Context, scheduledID users.ID) error
+	// ClaimScheduledPost atomically holds the claimable post until the time given, counts the attempt
+	// and returns its latest version. The post stays on the schedule until it is deleted once published
+	ClaimScheduledPost(ctx context.Context, scheduledID users.ID, now, until time.Time) (*posts.ScheduledPost, error)
+}
+
+// WithScheduledPosts lets authors schedule their posts. They are published by a PostScheduler
+func WithScheduledPosts(scheduled ScheduledStorage) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.scheduled = scheduled
+	}
+}
+
+// SchedulePost validates the post as CreatePost does and stores it until payload.PublishAt
+func (p *PostHandler) SchedulePost(ctx context.Context, payload posts.PostPayload) (*posts.ScheduledPost, error) {
+	source := "SchedulePost"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	switch {
+	case p.scheduled == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case !ok:
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+	if err := posts.ValidatePublishTime(payload.PublishAt, time.Now()); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err := p.validatePayload(ctx, &payload, *payload.PublishAt); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	scheduled := posts.NewScheduledPost(*author, payload)
+	if err := p.scheduled.AddScheduledPost(ctx, scheduled); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return scheduled, nil
+}
+
+func (p *PostHandler) GetScheduledPosts(ctx context.Context) ([]*posts.ScheduledPost, error) {
+	source := "GetScheduledPosts"
+	authorID := viewerID(ctx)
+	switch {
+	case p.scheduled == nil:
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	case authorID == "":
+		return nil, errors.Wrap(errs.ErrBadPayload, source)
+	}
+
+	scheduledList, err := p.scheduled.GetScheduledPosts(ctx, authorID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return scheduledList, nil
+}
+
+// UpdateScheduledPost replaces both the content and the publish time of a post that has not been published yet
+func (p *PostHandler) UpdateScheduledPost(ctx context.Context, scheduledID users.ID, payload posts.PostPayload) (*posts.ScheduledPost, error) {
+	source := "UpdateScheduledPost"
+	scheduled, err := p.ownScheduledPost(ctx, scheduledID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = posts.ValidatePublishTime(payload.PublishAt, time.Now()); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = p.validatePayload(ctx, &payload, *payload.PublishAt); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	scheduled.Update(payload)
+	if err = p.scheduled.UpdateScheduledPost(ctx, scheduled); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return scheduled, nil
+}
+
+func (p *PostHandler) CancelScheduledPost(ctx context.Context, scheduledID users.ID) error {
+	source := "CancelScheduledPost"
+	if _, err := p.ownScheduledPost(ctx, scheduledID); err != nil {
+		return errors.Wrap(err, source)
+	}
+	if err := p.scheduled.DeleteScheduledPost(ctx, scheduledID); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+// ownScheduledPost returns the scheduled post of the user. Posts of other users are reported as not found
+func (p *PostHandler) ownScheduledPost(ctx context.Context, scheduledID users.ID) (*posts.ScheduledPost, error) {
+	if p.scheduled == nil {
+		return nil, errs.ErrFeatureDisabled
+	}
+
+	scheduled, err := p.scheduled.GetScheduledPost(ctx, scheduledID)
+	if err != nil {
+		return nil, err
+	}
+	if authorID := viewerID(ctx); authorID == "" || scheduled.Author.ID != authorID {
+		return nil, errs.ErrScheduledPostNotFound
+	}
+
+	return scheduled, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+const (
+	DefaultSchedulerInterval  = 30 * time.Second
+	DefaultSchedulerBatchSize = 100
+	DefaultSchedulerLease     = 5 * time.Minute
+)
+
+type SchedulerConfig struct {
+	Interval  time.Duration // How often the due posts are looked for
+	BatchSize int           // Posts published per round at most
+	Lease     time.Duration // How long a post is held by the instance publishing it, and so the pause before it is retried
+}
+
+// PostScheduler publishes the scheduled posts once they are due. Any number of instances may run it:
+// a post is held by one of them while it is published and taken off the schedule only once it is.
+// A post that fails or whose instance crashes is retried once the hold runs out, MaxPublishAttempts times at most
+type PostScheduler struct {
+	handler  *PostHandler
+	repo     ScheduledStorage
+	logger   *zap.SugaredLogger
+	interval time.Duration
+	batch    int
+	lease    time.Duration
+}
+
+func NewPostScheduler(handler *PostHandler, repo ScheduledStorage, logger *zap.SugaredLogger, cfg SchedulerConfig) *PostScheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultSchedulerInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultSchedulerBatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = DefaultSchedulerLease
+	}
+
+	return &PostScheduler{
+		handler:  handler,
+		repo:     repo,
+		logger:   logger,
+		interval: cfg.Interval,
+		batch:    cfg.BatchSize,
+		lease:    cfg.Lease,
+	}
+}
+
+// Run publishes the due posts every interval until the context is canceled
+func (s *PostScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.PublishDue(ctx, now)
+		}
+	}
+}
+
+// PublishDue publishes the posts due by now and returns how many of them have been published by this instance
+func (s *PostScheduler) PublishDue(ctx context.Context, now time.Time) int {
+	dueList, err := s.repo.GetDueScheduledPosts(ctx, now, s.batch)
+	if err != nil {
+		s.logger.Errorw("getting due scheduled posts failed", "error", err.Error())
+		return 0
+	}
+
+	published := 0
+	for _, due := range dueList {
+		if s.publish(ctx, due, now) {
+			published++
+		}
+	}
+
+	return published
+}
+
+func (s *PostScheduler) publish(ctx context.Context, due *posts.ScheduledPost, now time.Time) bool {
+	// Another instance may hold or have published the post or its author may have canceled it since the listing
+	scheduled, err := s.repo.ClaimScheduledPost(ctx, due.ID, now, now.Add(s.lease))
+	switch {
+	case errors.Is(err, errs.ErrScheduledPostNotFound):
+		return false
+	case err != nil:
+		s.logger.Errorw("claiming scheduled post failed", "scheduled", due.ID, "error", err.Error())
+		return false
+	}
+
+	author := scheduled.Author
+	newPost, err := s.handler.publish(context.WithValue(ctx, jwt.Payload, &author), scheduled.Post)
+	if err != nil {
+		// The post stays on the schedule and is tried again once the hold runs out
+		s.logger.Errorw("publishing scheduled post failed",
+			"scheduled", due.ID,
+			"attempt", scheduled.Attempts,
+			"givenUp", scheduled.Attempts >= posts.MaxPublishAttempts,
+			"error", err.Error(),
+		)
+		return false
+	}
+	// Left on the schedule, the post would be published again once the hold runs out
+	if err = s.repo.DeleteScheduledPost(ctx, scheduled.ID); err != nil && !errors.Is(err, errs.ErrScheduledPostNotFound) {
+		s.logger.Errorw("taking published post off the schedule failed", "scheduled", due.ID, "error", err.Error())
+	}
+	s.logger.Infow("Scheduled post has been published",
+		"scheduled", scheduled.ID,
+		"post", newPost.ID,
+		"author", scheduled.Author.Login,
+	)
+
+	return true
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const DefaultStatsFlushInterval = 30 * time.Second
+
+type StatsStorage interface {
+	AddStats(ctx context.Context, buckets []*posts.StatsBucket) error
+	GetStats(ctx context.Context, postID users.ID, from, to time.Time) ([]*posts.StatsBucket, error)
+}
+
+type StatsConfig struct {
+	FlushInterval time.Duration // How often the recorded events are added to the stored buckets
+}
+
+type statsKey struct {
+	postID users.ID
+	start  time.Time
+}
+
+// StatsRecorder rolls the events of the posts up into buckets of posts.StatsResolution and saves them in batches
+type StatsRecorder struct {
+	repo     StatsStorage
+	logger   *zap.SugaredLogger
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[statsKey]*posts.StatsBucket
+}
+
+func NewStatsRecorder(repo StatsStorage, logger *zap.SugaredLogger, cfg StatsConfig) *StatsRecorder {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultStatsFlushInterval
+	}
+
+	return &StatsRecorder{
+		repo:     repo,
+		logger:   logger,
+		interval: cfg.FlushInterval,
+		pending:  make(map[statsKey]*posts.StatsBucket),
+	}
+}
+
+// WithStats makes the handler record the views, votes and comments of the posts for their authors
+func WithStats(recorder *StatsRecorder) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.stats = recorder
+	}
+}
+
+// Record adds the counts to the bucket of the post the moment falls in
+func (r *StatsRecorder) Record(postID users.ID, at time.Time, counts posts.StatsCounts) {
+	if counts.IsZero() {
+		return
+	}
+
+	key := statsKey{postID: postID, start: at.UTC().Truncate(posts.StatsResolution)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bucket, ok := r.pending[key]
+	if !ok {
+		bucket = &posts.StatsBucket{PostID: postID, Start: key.start}
+		r.pending[key] = bucket
+	}
+	bucket.Add(counts)
+}
+
+// Flush saves the events recorded since the last flush. The events are kept for the next flush on failure
+func (r *StatsRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[statsKey]*posts.StatsBucket)
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	buckets := make([]*posts.StatsBucket, 0, len(pending))
+	for _, bucket := range pending {
+		buckets = append(buckets, bucket)
+	}
+	if err := r.repo.AddStats(ctx, buckets); err != nil {
+		for _, bucket := range buckets {
+			r.Record(bucket.PostID, bucket.Start, bucket.StatsCounts)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Run flushes the events every interval until the context is canceled, then flushes the rest
+func (r *StatsRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Flush(context.WithoutCancel(ctx)); err != nil {
+				r.logger.Errorw("stats lost on shutdown", "err", err)
+			}
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.logger.Warnw("failed to flush stats", "err", err)
+			}
+		}
+	}
+}
+
+// GetPostStats returns the time series of the events of the post. Only the author can see them
+func (p *PostHandler) GetPostStats(ctx context.Context, postID users.ID, query posts.StatsQuery) (*posts.PostStats, error) {
+	source := "GetPostStats"
+	if p.stats == nil {
+		return nil, errors.Wrap(errs.ErrFeatureDisabled, source)
+	}
+	if err := query.Validate(time.Now()); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if viewerID(ctx) != post.Author.ID {
+		return nil, errors.Wrap(errs.ErrNotAuthor, source)
+	}
+
+	buckets, err := p.stats.repo.GetStats(ctx, postID, query.From, query.To)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return posts.NewPostStats(postID, query.Interval, buckets), nil
+}
+
+// record adds the counts to the stats of the post if they are being recorded
+func (p *PostHandler) record(postID users.ID, counts posts.StatsCounts) {
+	if p.stats != nil {
+		p.stats.Record(postID, time.Now(), counts)
+	}
+}
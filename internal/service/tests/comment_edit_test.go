@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestEditComment(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithArchiveAfter(24*time.Hour))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	post, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "First"})
+	require.NoError(t, err)
+	commentID := post.Comments[0].ID
+	assert.True(t, post.Comments[0].Edited.IsZero())
+	// Comments that were never edited have no edit date at all
+	data, err := json.Marshal(post.Comments[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"edited"`)
+
+	// Only the author edits the comment, with the same body rules as when it was left
+	_, err = handler.EditComment(authorCtx, post.ID, commentID, posts.Comment{Body: "Hijacked"})
+	assert.ErrorIs(t, err, errs.ErrNotCommentAuthor)
+	_, err = handler.EditComment(voterCtx, post.ID, commentID, posts.Comment{})
+	assert.ErrorIs(t, err, errs.ErrBadCommentBody)
+	_, err = handler.EditComment(voterCtx, post.ID, post.ID, posts.Comment{Body: "Lost"})
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	post, err = handler.EditComment(voterCtx, post.ID, commentID, posts.Comment{Body: "Second"})
+	require.NoError(t, err)
+	assert.Equal(t, "Second", post.Comments[0].Body)
+	assert.False(t, post.Comments[0].Edited.IsZero())
+	data, err = json.Marshal(post.Comments[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"edited"`)
+	post, err = handler.EditComment(voterCtx, post.ID, commentID, posts.Comment{Body: "Third"})
+	require.NoError(t, err)
+	assert.Equal(t, "Third", post.Comments[0].Body)
+
+	// The previous bodies are kept for the moderators
+	_, err = handler.GetCommentRevisions(voterCtx, post.ID, commentID)
+	assert.ErrorIs(t, err, errs.ErrNotModerator)
+	revisions, err := handler.GetCommentRevisions(authorCtx, post.ID, commentID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "First", revisions[0].Body)
+	assert.Equal(t, post.Comments[0].Created, revisions[0].Created)
+	assert.Equal(t, "Second", revisions[1].Body)
+
+	// No edits on locked or archived posts
+	_, err = handler.LockPost(authorCtx, post.ID, posts.LockPayload{})
+	require.NoError(t, err)
+	_, err = handler.EditComment(voterCtx, post.ID, commentID, posts.Comment{Body: "Locked"})
+	assert.ErrorIs(t, err, errs.ErrPostLocked)
+	_, err = handler.UnlockPost(authorCtx, post.ID)
+	require.NoError(t, err)
+
+	stored, err := repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	stored.Created = time.Now().Add(-48 * time.Hour)
+	_, err = handler.EditComment(voterCtx, post.ID, commentID, posts.Comment{Body: "Archived"})
+	assert.ErrorIs(t, err, errs.ErrPostArchived)
+}
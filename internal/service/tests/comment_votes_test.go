@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestCommentVotes(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	post, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "Comment"})
+	require.NoError(t, err)
+	commentID := post.Comments[0].ID
+
+	// The author upvotes his/her own comment
+	assert.Equal(t, 1, post.Comments[0].Score)
+	assert.Len(t, post.Comments[0].Votes, 1)
+
+	post, err = handler.UpvoteComment(authorCtx, post.ID, commentID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, post.Comments[0].Score)
+	post, err = handler.DownvoteComment(authorCtx, post.ID, commentID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, post.Comments[0].Score)
+	assert.Len(t, post.Comments[0].Votes, 2)
+	assert.Equal(t, 1, post.Score, "the post keeps its own score")
+
+	// Karma of the comment author counts only the votes of the others
+	karma, err := handler.GetKarma(context.Background(), voter.Login)
+	require.NoError(t, err)
+	assert.Equal(t, users.Karma{Comment: -1, Total: -1}, karma)
+	post, err = handler.Upvote(voterCtx, post.ID)
+	require.NoError(t, err)
+	karma, err = handler.GetKarma(context.Background(), author.Login)
+	require.NoError(t, err)
+	assert.Equal(t, users.Karma{Post: 1, Total: 1}, karma)
+
+	post, err = handler.UnvoteComment(authorCtx, post.ID, commentID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, post.Comments[0].Score)
+	_, err = handler.UnvoteComment(authorCtx, post.ID, commentID)
+	assert.ErrorIs(t, err, errs.ErrVoteNotFound)
+	_, err = handler.UpvoteComment(authorCtx, post.ID, post.ID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Frozen votes cover the comments too
+	_, err = handler.LockPost(authorCtx, post.ID, posts.LockPayload{FreezeVotes: true})
+	require.NoError(t, err)
+	_, err = handler.UpvoteComment(authorCtx, post.ID, commentID)
+	assert.ErrorIs(t, err, errs.ErrVotingFrozen)
+}
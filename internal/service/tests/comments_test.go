@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestGetComments(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	ids := make([]users.ID, 0, 3)
+	for _, body := range []string{"First", "Second", "Third"} {
+		post, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: body})
+		require.NoError(t, err)
+		ids = append(ids, post.Comments[len(post.Comments)-1].ID)
+	}
+	post, err = handler.AddComment(authorCtx, post.ID, posts.Comment{Body: "Reply", ParentID: ids[0]})
+	require.NoError(t, err)
+	assert.Equal(t, 4, post.CommentCount)
+
+	// First: one up, one down. Second: one up. Third: two up
+	_, err = handler.DownvoteComment(authorCtx, post.ID, ids[0])
+	require.NoError(t, err)
+	_, err = handler.UpvoteComment(authorCtx, post.ID, ids[2])
+	require.NoError(t, err)
+
+	order := func(page *posts.CommentPage) []users.ID {
+		got := make([]users.ID, 0, len(page.Comments))
+		for _, comment := range page.Comments {
+			got = append(got, comment.ID)
+		}
+		return got
+	}
+	for sort, expected := range map[posts.CommentSort][]users.ID{
+		posts.SortBest:          {ids[2], ids[1], ids[0]},
+		posts.SortTop:           {ids[2], ids[1], ids[0]},
+		posts.SortOld:           {ids[0], ids[1], ids[2]},
+		posts.SortControversial: {ids[0], ids[1], ids[2]},
+	} {
+		query, err := posts.NewCommentQuery(sort, 0, "")
+		require.NoError(t, err)
+		page, err := handler.GetComments(context.Background(), post.ID, query)
+		require.NoError(t, err, sort)
+		assert.Equal(t, expected, order(page), sort)
+		assert.Empty(t, page.After, sort)
+	}
+
+	// Pages continue after the last top-level comment of the previous one
+	query, err := posts.NewCommentQuery(posts.SortTop, 2, "")
+	require.NoError(t, err)
+	page, err := handler.GetComments(context.Background(), post.ID, query)
+	require.NoError(t, err)
+	assert.Equal(t, []users.ID{ids[2], ids[1]}, order(page))
+	assert.Equal(t, ids[1], page.After)
+
+	query.After = page.After
+	page, err = handler.GetComments(context.Background(), post.ID, query)
+	require.NoError(t, err)
+	assert.Equal(t, []users.ID{ids[0]}, order(page))
+	assert.Empty(t, page.After)
+	require.Len(t, page.Comments[0].Replies, 1)
+	assert.Equal(t, "Reply", page.Comments[0].Replies[0].Body)
+
+	// Only top-level comments are cursors
+	query.After = page.Comments[0].Replies[0].ID
+	_, err = handler.GetComments(context.Background(), post.ID, query)
+	assert.ErrorIs(t, err, errs.ErrBadCommentCursor)
+
+	// Queries
+	_, err = posts.NewCommentQuery("random", 0, "")
+	assert.ErrorIs(t, err, errs.ErrBadCommentQuery)
+	query, err = posts.NewCommentQuery("", posts.MaxCommentLimit+1, "")
+	require.NoError(t, err)
+	assert.Equal(t, posts.SortBest, query.Sort)
+	assert.Equal(t, posts.MaxCommentLimit, query.Limit)
+
+	_, err = handler.GetComments(context.Background(), ids[0], query)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// Deleting a comment updates the count
+	post, err = handler.DeleteComment(voterCtx, post.ID, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, 3, post.CommentCount)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestFeedTimeRange(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	fresh, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Fresh", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, fresh.Created.Location())
+	old, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Old", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	stored, err := repo.GetPostByID(context.Background(), old.ID)
+	require.NoError(t, err)
+	stored.Created = time.Now().Add(-72 * time.Hour)
+
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	for _, tc := range []struct {
+		query    posts.FeedQuery
+		expected []*posts.Post
+	}{
+		{posts.FeedQuery{From: dayAgo}, []*posts.Post{fresh}},
+		{posts.FeedQuery{To: dayAgo}, []*posts.Post{old}},
+		{posts.FeedQuery{From: dayAgo.Add(-72 * time.Hour), To: dayAgo}, []*posts.Post{old}},
+		{posts.FeedQuery{From: dayAgo, To: dayAgo.Add(time.Hour)}, nil},
+	} {
+		postList, err := handler.GetPostsByUser(authorCtx, author.Login, tc.query)
+		require.NoError(t, err)
+		require.Len(t, postList, len(tc.expected))
+		for i, post := range tc.expected {
+			assert.Equal(t, post.ID, postList[i].ID)
+		}
+	}
+
+	postList, err := handler.GetAllPosts(authorCtx, posts.FeedQuery{From: dayAgo})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	postList, err = handler.GetPostsByCategory(authorCtx, posts.Music, posts.FeedQuery{To: dayAgo})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestCrosspost(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	original, err := handler.CreatePost(authorCtx, posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Original",
+		Category: posts.Music,
+		Text:     "I love music of Kartik.",
+	})
+	require.NoError(t, err)
+
+	// Same community
+	_, err = handler.Crosspost(voterCtx, original.ID, posts.CrosspostPayload{Category: posts.Music})
+	assert.ErrorIs(t, err, errs.ErrBadCrosspost)
+
+	// Unknown community
+	_, err = handler.Crosspost(voterCtx, original.ID, posts.CrosspostPayload{Category: "nowhere"})
+	assert.ErrorIs(t, err, errs.ErrInvalidCategory)
+
+	// Success
+	crosspost, err := handler.Crosspost(voterCtx, original.ID, posts.CrosspostPayload{Category: posts.Funny})
+	require.NoError(t, err)
+	assert.Equal(t, "Original", crosspost.Title)
+	assert.Equal(t, original.Text, crosspost.Text)
+	assert.Equal(t, posts.Funny, crosspost.Category)
+	assert.Equal(t, voter.Login, crosspost.Author.Login)
+	assert.Equal(t, &posts.CrosspostParent{
+		ID:       original.ID,
+		Title:    original.Title,
+		Category: posts.Music,
+		Author:   author,
+		Created:  original.Created,
+	}, crosspost.CrosspostParent)
+
+	// Crossposts of crossposts refer to the original
+	second, err := handler.Crosspost(authorCtx, crosspost.ID, posts.CrosspostPayload{Category: posts.News, Title: "Again"})
+	require.NoError(t, err)
+	assert.Equal(t, "Again", second.Title)
+	assert.Equal(t, original.ID, second.CrosspostParent.ID)
+
+	original, err = handler.GetPostByID(context.Background(), original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, original.CrosspostCount)
+	assert.Equal(t, []*posts.CrosspostRef{
+		{ID: crosspost.ID, Category: posts.Funny},
+		{ID: second.ID, Category: posts.News},
+	}, original.Crossposts)
+
+	// Deleting a crosspost detaches it from the original
+	require.NoError(t, handler.DeletePost(authorCtx, second.ID))
+	original, err = handler.GetPostByID(context.Background(), original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, original.CrosspostCount)
+
+	// Deleting the original leaves the crosspost in the "source removed" state
+	require.NoError(t, handler.DeletePost(authorCtx, original.ID))
+	crosspost, err = handler.GetPostByID(context.Background(), crosspost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, posts.RemovedCrosspostParent(original.ID), crosspost.CrosspostParent)
+	assert.Empty(t, crosspost.Text)
+
+	_, err = handler.Crosspost(voterCtx, crosspost.ID, posts.CrosspostPayload{Category: posts.Videos})
+	assert.ErrorIs(t, err, errs.ErrCrosspostSourceRemoved)
+
+	_, err = handler.Crosspost(voterCtx, original.ID, posts.CrosspostPayload{Category: posts.Videos})
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+}
+
+func TestCrosspostPoll(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	payload := pollPayload([]string{"Rock", "Jazz"}, false, nil)
+	payload.Text = "Vote in the original post"
+	original, err := handler.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+
+	// The votes stay with the original, the crosspost carries its text only
+	crosspost, err := handler.Crosspost(voterCtx, original.ID, posts.CrosspostPayload{Category: posts.Funny})
+	require.NoError(t, err)
+	assert.Equal(t, posts.WithText, crosspost.Type)
+	assert.Nil(t, crosspost.Poll)
+	assert.Equal(t, payload.Text, crosspost.Text)
+	assert.Equal(t, original.ID, crosspost.CrosspostParent.ID)
+
+	_, err = handler.CastBallot(voterCtx, crosspost.ID, posts.BallotPayload{Choice: []int{0}})
+	assert.ErrorIs(t, err, errs.ErrNotAPoll)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestDrafts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithDrafts(inmem.NewDraftRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	// Partial content is saved as is
+	draft, err := handler.CreateDraft(authorCtx, posts.PostPayload{Title: "Work in progress"})
+	require.NoError(t, err)
+	assert.Equal(t, author.ID, draft.Author.ID)
+	assert.Equal(t, "Work in progress", draft.Post.Title)
+
+	_, err = handler.CreateDraft(context.Background(), posts.PostPayload{})
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+
+	// Drafts are private
+	_, err = handler.GetDraft(voterCtx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	_, err = handler.UpdateDraft(voterCtx, draft.ID, posts.PostPayload{})
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	_, err = handler.PublishDraft(voterCtx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	assert.ErrorIs(t, handler.DeleteDraft(voterCtx, draft.ID), errs.ErrDraftNotFound)
+	draftList, err := handler.GetDrafts(voterCtx)
+	require.NoError(t, err)
+	assert.Empty(t, draftList)
+
+	// The content is validated only once published, the draft is a link post without a link so far
+	_, err = handler.PublishDraft(authorCtx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrInvalidURL)
+	// and is kept for the author to fix it
+	_, err = handler.GetDraft(authorCtx, draft.ID)
+	require.NoError(t, err)
+
+	draft, err = handler.UpdateDraft(authorCtx, draft.ID, posts.PostPayload{Type: posts.WithText, Title: "Done", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	assert.Equal(t, "Done", draft.Post.Title)
+	draftList, err = handler.GetDrafts(authorCtx)
+	require.NoError(t, err)
+	require.Len(t, draftList, 1)
+	assert.Equal(t, draft.Post, draftList[0].Post)
+
+	post, err := handler.PublishDraft(authorCtx, draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Done", post.Title)
+	assert.Equal(t, author.ID, post.Author.ID)
+
+	// The draft is gone once published, so it is published once
+	_, err = handler.GetDraft(authorCtx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	_, err = handler.PublishDraft(authorCtx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 1)
+}
+
+func TestDraftsLimit(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithDrafts(inmem.NewDraftRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	var last *posts.Draft
+	for range posts.MaxDrafts {
+		draft, err := handler.CreateDraft(authorCtx, posts.PostPayload{})
+		require.NoError(t, err)
+		last = draft
+	}
+	_, err := handler.CreateDraft(authorCtx, posts.PostPayload{})
+	assert.ErrorIs(t, err, errs.ErrTooManyDrafts)
+
+	// The limit is per user
+	_, err = handler.CreateDraft(voterCtx, posts.PostPayload{})
+	assert.NoError(t, err)
+
+	require.NoError(t, handler.DeleteDraft(authorCtx, last.ID))
+	_, err = handler.CreateDraft(authorCtx, posts.PostPayload{})
+	assert.NoError(t, err)
+
+	// Without the storage the feature is off
+	disabled := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	_, err = disabled.CreateDraft(authorCtx, posts.PostPayload{})
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+	_, err = disabled.PublishDraft(authorCtx, last.ID)
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
+
+func TestDraftsConcurrency(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithDrafts(inmem.NewDraftRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	const workers = 8
+
+	// Concurrent publishing gives a single post
+	draft, err := handler.CreateDraft(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Once", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = handler.PublishDraft(authorCtx, draft.ID)
+		}()
+	}
+	wg.Wait()
+	postList, err := repo.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 1)
+
+	// Concurrent creation does not get past the limit
+	for range workers + posts.MaxDrafts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = handler.CreateDraft(authorCtx, posts.PostPayload{})
+		}()
+	}
+	wg.Wait()
+	draftList, err := handler.GetDrafts(authorCtx)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(draftList), posts.MaxDrafts)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestPostFlair(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	communityRepo := inmem.NewCommunityRepo()
+	handler := service.NewPostHandler(repo, repo, communityRepo)
+	communityHandler := service.NewCommunityHandler(communityRepo)
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	// Only moderators manage the templates
+	_, err := communityHandler.CreateFlair(voterCtx, string(posts.Music), communities.FlairPayload{Text: "News", Color: "#0079D3"})
+	assert.ErrorIs(t, err, errs.ErrNotModerator)
+	_, err = communityHandler.CreateFlair(authorCtx, string(posts.Music), communities.FlairPayload{Text: " ", Color: "#0079d3"})
+	assert.ErrorIs(t, err, errs.ErrBadFlair)
+	_, err = communityHandler.CreateFlair(authorCtx, string(posts.Music), communities.FlairPayload{Text: "News", Color: "blue"})
+	assert.ErrorIs(t, err, errs.ErrBadFlair)
+
+	community, err := communityHandler.CreateFlair(authorCtx, string(posts.Music), communities.FlairPayload{Text: " News ", Color: "#0079D3"})
+	require.NoError(t, err)
+	require.Len(t, community.Flairs, 1)
+	news := community.Flairs[0]
+	assert.Equal(t, "News", news.Text)
+	assert.Equal(t, "#0079d3", news.Color)
+	community, err = communityHandler.CreateFlair(authorCtx, string(posts.Music), communities.FlairPayload{Text: "Discussion", Color: "#ff4500"})
+	require.NoError(t, err)
+	discussion := community.Flairs[1]
+
+	// The flair must belong to the community of the post
+	_, err = handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Funny, Text: "Text", FlairID: news.ID})
+	assert.ErrorIs(t, err, errs.ErrInvalidFlair)
+
+	flaired, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", FlairID: news.ID})
+	require.NoError(t, err)
+	require.NotNil(t, flaired.Flair)
+	assert.Equal(t, news.Text, flaired.Flair.Text)
+	_, err = handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	// Feeds filter by the text of the flair
+	postList, err := handler.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{Flair: news.Text})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.Equal(t, flaired.ID, postList[0].ID)
+
+	// Only the author and the moderators change the flair
+	_, err = handler.SetPostFlair(voterCtx, flaired.ID, posts.FlairPayload{FlairID: discussion.ID})
+	assert.ErrorIs(t, err, errs.ErrNotAuthor)
+	_, err = handler.SetPostFlair(authorCtx, flaired.ID, posts.FlairPayload{FlairID: "00000000-0000-0000-0000-000000000000"})
+	assert.ErrorIs(t, err, errs.ErrInvalidFlair)
+
+	post, err := handler.SetPostFlair(authorCtx, flaired.ID, posts.FlairPayload{FlairID: discussion.ID})
+	require.NoError(t, err)
+	assert.Equal(t, discussion.Text, post.Flair.Text)
+
+	// Deleted templates stay on the posts
+	community, err = communityHandler.DeleteFlair(authorCtx, string(posts.Music), discussion.ID)
+	require.NoError(t, err)
+	assert.Len(t, community.Flairs, 1)
+	_, err = communityHandler.DeleteFlair(authorCtx, string(posts.Music), discussion.ID)
+	assert.ErrorIs(t, err, errs.ErrInvalidFlair)
+
+	post, err = handler.GetPostByID(context.Background(), flaired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, discussion.Text, post.Flair.Text)
+
+	post, err = handler.SetPostFlair(authorCtx, flaired.ID, posts.FlairPayload{})
+	require.NoError(t, err)
+	assert.Nil(t, post.Flair)
+}
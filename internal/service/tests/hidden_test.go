@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestHidePost(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	hidden, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Boring", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	visible, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Great", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	post, err := handler.HidePost(voterCtx, hidden.ID)
+	require.NoError(t, err)
+	assert.True(t, post.Hidden)
+
+	// Every feed of the voter skips the post
+	feeds := map[string]func(ctx context.Context) ([]*posts.Post, error){
+		"all": func(ctx context.Context) ([]*posts.Post, error) {
+			return handler.GetAllPosts(ctx, posts.FeedQuery{})
+		},
+		"category": func(ctx context.Context) ([]*posts.Post, error) {
+			return handler.GetPostsByCategory(ctx, posts.Music, posts.FeedQuery{})
+		},
+		"user": func(ctx context.Context) ([]*posts.Post, error) {
+			return handler.GetPostsByUser(ctx, author.Login, posts.FeedQuery{})
+		},
+		"search": func(ctx context.Context) ([]*posts.Post, error) {
+			return handler.SearchPosts(ctx, posts.SearchQuery{Text: "text"})
+		},
+	}
+	for name, feed := range feeds {
+		postList, err := feed(voterCtx)
+		require.NoError(t, err, name)
+		require.Len(t, postList, 1, name)
+		assert.Equal(t, visible.ID, postList[0].ID, name)
+
+		postList, err = feed(authorCtx)
+		require.NoError(t, err, name)
+		assert.Len(t, postList, 2, name)
+
+		postList, err = feed(context.Background())
+		require.NoError(t, err, name)
+		assert.Len(t, postList, 2, name)
+	}
+
+	// The post is still reachable directly and listed as hidden
+	post, err = handler.GetPostByID(voterCtx, hidden.ID)
+	require.NoError(t, err)
+	assert.True(t, post.Hidden)
+
+	postList, err := handler.GetHiddenPosts(voterCtx, posts.Page{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.Equal(t, hidden.ID, postList[0].ID)
+
+	// Unhiding brings it back
+	post, err = handler.UnhidePost(voterCtx, hidden.ID)
+	require.NoError(t, err)
+	assert.False(t, post.Hidden)
+
+	postList, err = handler.GetAllPosts(voterCtx, posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 2)
+
+	postList, err = handler.GetHiddenPosts(voterCtx, posts.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, postList)
+}
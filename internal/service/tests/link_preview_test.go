@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+	"github.com/Benzogang-Tape/Reddit/pkg/linkpreview"
+)
+
+const (
+	openGraphPage = `<!DOCTYPE html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Kartik &amp; the Band">
+<meta property="og:description" content="  New   album
+ out now ">
+<meta property="og:image" content="/static/cover.png">
+<meta property="og:site_name" content="Music Site">
+</head><body><meta property="og:title" content="Ignored"></body></html>`
+	oEmbedPage = `<html><head>
+<title>Video page</title>
+<meta name="description" content="Plain description">
+<link rel="alternate" type="application/json+oembed" href="/oembed?format=json">
+</head></html>`
+	oEmbedResponse = `{"type":"video","title":"Embedded video","provider_name":"Tube","thumbnail_url":"https://img.example.com/thumb.jpg"}`
+)
+
+var author = &jwt.TokenPayload{
+	Login: "admin",
+	ID:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
+}
+
+// previewRecorder reports every saved preview, so the test does not have to poll the storage
+type previewRecorder struct {
+	*inmem.PostRepo
+	saved chan users.ID
+}
+
+func (r *previewRecorder) SetLinkPreview(ctx context.Context, postID users.ID, preview *posts.LinkPreview) error {
+	err := r.PostRepo.SetLinkPreview(ctx, postID, preview)
+	r.saved <- postID
+
+	return err
+}
+
+func newSite(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/og", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, openGraphPage)
+	})
+	mux.HandleFunc("/video", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, oEmbedPage)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, oEmbedResponse)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/og", http.StatusFound)
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><title>Big page</title></head><body>`)
+		fmt.Fprint(w, strings.Repeat("a", 1<<20))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, "%PDF-1.4")
+	})
+	site := httptest.NewServer(mux)
+	t.Cleanup(site.Close)
+
+	return site
+}
+
+func TestExtract(t *testing.T) {
+	site := newSite(t)
+	fetcher := linkpreview.NewHTTPFetcher(linkpreview.Config{
+		Timeout:      200 * time.Millisecond,
+		MaxBodySize:  4 << 10,
+		AllowPrivate: true,
+	})
+	ctx := context.Background()
+
+	// OpenGraph
+	preview, err := linkpreview.Extract(ctx, fetcher, site.URL+"/og")
+	require.NoError(t, err)
+	assert.Equal(t, &linkpreview.Preview{
+		Title:        "Kartik & the Band",
+		Description:  "New album out now",
+		ThumbnailURL: site.URL + "/static/cover.png",
+		SiteName:     "Music Site",
+	}, preview)
+
+	// Redirects are followed and relative links are resolved against the final page
+	preview, err = linkpreview.Extract(ctx, fetcher, site.URL+"/redirect")
+	require.NoError(t, err)
+	assert.Equal(t, site.URL+"/static/cover.png", preview.ThumbnailURL)
+
+	// oEmbed fills in what the page lacks
+	preview, err = linkpreview.Extract(ctx, fetcher, site.URL+"/video")
+	require.NoError(t, err)
+	assert.Equal(t, &linkpreview.Preview{
+		Title:        "Video page",
+		Description:  "Plain description",
+		ThumbnailURL: "https://img.example.com/thumb.jpg",
+		SiteName:     "Tube",
+	}, preview)
+
+	// Body is cut at the size limit
+	preview, err = linkpreview.Extract(ctx, fetcher, site.URL+"/big")
+	require.NoError(t, err)
+	assert.Equal(t, "Big page", preview.Title)
+
+	// Not an html page
+	_, err = linkpreview.Extract(ctx, fetcher, site.URL+"/file")
+	assert.ErrorIs(t, err, linkpreview.ErrNotHTML)
+
+	// Bad status
+	_, err = linkpreview.Extract(ctx, fetcher, site.URL+"/missing")
+	assert.ErrorIs(t, err, linkpreview.ErrBadStatus)
+
+	// Timeout
+	_, err = linkpreview.Extract(ctx, fetcher, site.URL+"/slow")
+	assert.Error(t, err)
+
+	// Bad scheme
+	_, err = linkpreview.Extract(ctx, fetcher, "file:///etc/passwd")
+	assert.ErrorIs(t, err, linkpreview.ErrBadScheme)
+}
+
+func TestHTTPFetcherRefusesPrivateAddresses(t *testing.T) {
+	site := newSite(t)
+	fetcher := linkpreview.NewHTTPFetcher(linkpreview.Config{})
+
+	_, err := fetcher.Fetch(context.Background(), site.URL+"/og")
+	assert.ErrorIs(t, err, linkpreview.ErrForbiddenAddress)
+
+	for addr, public := range map[string]bool{
+		"8.8.8.8":              true,
+		"2606:4700::1111":      true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fd00::1":              false,
+		"fe80::1":              false,
+		"::ffff:192.168.1.1":   false,
+		"::ffff:8.8.8.8":       true,
+		"64:ff9b::a9fe:a9fe":   false,
+		"ff02::1":              false,
+		"224.0.0.1":            false,
+		"255.255.255.255":      false,
+		"198.18.0.1":           false,
+		"192.0.0.170":          false,
+		"2001:4860:4860::8888": true,
+	} {
+		assert.Equal(t, public, linkpreview.IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestLinkPreviewWorker(t *testing.T) {
+	site := newSite(t)
+	repo := inmem.NewPostRepo()
+	recorder := &previewRecorder{PostRepo: repo, saved: make(chan users.ID, 1)}
+	communities := inmem.NewCommunityRepo()
+	worker := service.NewLinkPreviewWorker(
+		linkpreview.NewHTTPFetcher(linkpreview.Config{AllowPrivate: true}),
+		recorder,
+		zap.NewNop().Sugar(),
+		service.LinkPreviewConfig{Workers: 1},
+	)
+	handler := service.NewPostHandler(repo, repo, communities, service.WithLinkPreviews(worker))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{
+		Type:     posts.WithLink,
+		Title:    "Link post",
+		URL:      site.URL + "/og",
+		Category: posts.Music,
+	})
+	require.NoError(t, err)
+
+	select {
+	case postID := <-recorder.saved:
+		assert.Equal(t, post.ID, postID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("preview was not saved")
+	}
+
+	saved, err := repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &posts.LinkPreview{
+		Title:        "Kartik & the Band",
+		Description:  "New album out now",
+		ThumbnailURL: site.URL + "/static/cover.png",
+		SiteName:     "Music Site",
+	}, saved.Preview)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not stop")
+	}
+}
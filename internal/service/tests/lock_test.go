@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestLockedPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(voterCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	// Only moderators lock posts, not even the author
+	_, err = handler.LockPost(voterCtx, post.ID, posts.LockPayload{})
+	assert.ErrorIs(t, err, errs.ErrNotModerator)
+
+	locked, err := handler.LockPost(authorCtx, post.ID, posts.LockPayload{})
+	require.NoError(t, err)
+	assert.True(t, locked.Locked)
+	assert.False(t, locked.VotesFrozen)
+
+	_, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "Comment"})
+	assert.ErrorIs(t, err, errs.ErrPostLocked)
+	_, err = handler.Upvote(authorCtx, post.ID)
+	assert.NoError(t, err)
+
+	// Votes are frozen on request
+	_, err = handler.LockPost(authorCtx, post.ID, posts.LockPayload{FreezeVotes: true})
+	require.NoError(t, err)
+	for _, vote := range []func(context.Context, users.ID) (*posts.Post, error){handler.Upvote, handler.Downvote, handler.Unvote} {
+		_, err = vote(authorCtx, post.ID)
+		assert.ErrorIs(t, err, errs.ErrVotingFrozen)
+	}
+	poll, err := handler.CreatePost(voterCtx, pollPayload([]string{"Rock", "Jazz"}, false, nil))
+	require.NoError(t, err)
+	_, err = handler.LockPost(authorCtx, poll.ID, posts.LockPayload{FreezeVotes: true})
+	require.NoError(t, err)
+	_, err = handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{0}})
+	assert.ErrorIs(t, err, errs.ErrVotingFrozen)
+
+	unlocked, err := handler.UnlockPost(authorCtx, post.ID)
+	require.NoError(t, err)
+	assert.False(t, unlocked.Locked)
+	assert.False(t, unlocked.VotesFrozen)
+	_, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "Comment"})
+	assert.NoError(t, err)
+	_, err = handler.Downvote(authorCtx, post.ID)
+	assert.NoError(t, err)
+}
+
+func TestArchivedPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithArchiveAfter(24*time.Hour))
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	fresh, err := handler.CreatePost(voterCtx, posts.PostPayload{Type: posts.WithText, Title: "Fresh", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	assert.False(t, fresh.Archived)
+	old, err := handler.CreatePost(voterCtx, posts.PostPayload{Type: posts.WithText, Title: "Old", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	oldPoll, err := handler.CreatePost(voterCtx, pollPayload([]string{"Rock", "Jazz"}, false, nil))
+	require.NoError(t, err)
+	for _, postID := range []users.ID{old.ID, oldPoll.ID} {
+		stored, err := repo.GetPostByID(context.Background(), postID)
+		require.NoError(t, err)
+		stored.Created = time.Now().Add(-48 * time.Hour)
+	}
+
+	// Archived posts are read-only
+	_, err = handler.AddComment(voterCtx, old.ID, posts.Comment{Body: "Comment"})
+	assert.ErrorIs(t, err, errs.ErrPostArchived)
+	_, err = handler.Downvote(voterCtx, old.ID)
+	assert.ErrorIs(t, err, errs.ErrPostArchived)
+	_, err = handler.CastBallot(voterCtx, oldPoll.ID, posts.BallotPayload{Choice: []int{0}})
+	assert.ErrorIs(t, err, errs.ErrPostArchived)
+	_, err = handler.AddComment(voterCtx, fresh.ID, posts.Comment{Body: "Comment"})
+	assert.NoError(t, err)
+
+	post, err := handler.GetPostByID(context.Background(), old.ID)
+	require.NoError(t, err)
+	assert.True(t, post.Archived)
+	assert.Empty(t, post.Comments)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
+)
+
+func TestMarkdown(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithMarkdown(markdown.NewRenderer(0)))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Title",
+		Category: posts.Music,
+		Text:     "**Bold** [link](https://example.com) [bad](javascript:alert(1))\n\n<script>alert(1)</script><img src=x onerror=alert(1)>",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, post.Text, "**Bold**")
+	assert.Contains(t, post.TextHTML, "<strong>Bold</strong>")
+	assert.Contains(t, post.TextHTML, `<a href="https://example.com" rel="nofollow noreferrer noopener" target="_blank">link</a>`)
+	for _, unsafe := range []string{"<script", "<img", "onerror", "javascript:"} {
+		assert.NotContains(t, post.TextHTML, unsafe)
+	}
+
+	post, err = handler.AddComment(authorCtx, post.ID, posts.Comment{Body: "`code` <b onclick=x>"})
+	require.NoError(t, err)
+	require.Len(t, post.Comments, 1)
+	assert.Equal(t, "<p><code>code</code> </p>\n", post.Comments[0].BodyHTML)
+
+	// The rendering is stored along with the source
+	stored, err := repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.TextHTML, stored.TextHTML)
+	assert.Equal(t, post.Comments[0].BodyHTML, stored.Comments[0].BodyHTML)
+
+	// Posts stored before rendering existed are rendered on read without being modified
+	stored.TextHTML, stored.Comments[0].BodyHTML = "", ""
+	post, err = handler.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Contains(t, post.TextHTML, "<strong>Bold</strong>")
+	assert.Equal(t, "<p><code>code</code> </p>\n", post.Comments[0].BodyHTML)
+	assert.Empty(t, stored.TextHTML)
+	assert.Empty(t, stored.Comments[0].BodyHTML)
+
+	// Spoilers are withheld from lists in both forms
+	_, err = handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Spoiler", Category: posts.Music, Text: "*The end*", Spoiler: true})
+	require.NoError(t, err)
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	for _, listed := range postList {
+		if listed.Spoiler {
+			assert.Empty(t, listed.Text)
+			assert.Empty(t, listed.TextHTML)
+		}
+	}
+
+	// Without the renderer only the source is returned
+	plain := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	post, err = plain.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "**Bold**"})
+	require.NoError(t, err)
+	assert.Empty(t, post.TextHTML)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/pkg/imaging"
+)
+
+var errBlobStore = errors.New("blob store is down")
+
+// blobRecorder keeps the blobs in memory and fails the puts once failAfter of them have succeeded
+type blobRecorder struct {
+	blobs     map[string][]byte
+	types     map[string]string
+	failAfter int
+}
+
+func newBlobRecorder(failAfter int) *blobRecorder {
+	return &blobRecorder{
+		blobs:     make(map[string][]byte),
+		types:     make(map[string]string),
+		failAfter: failAfter,
+	}
+}
+
+func (b *blobRecorder) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if b.failAfter == 0 {
+		return errBlobStore
+	}
+	b.failAfter--
+	b.blobs[key], b.types[key] = data, contentType
+
+	return nil
+}
+
+func (b *blobRecorder) Get(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error) {
+	data, ok := b.blobs[key]
+	if !ok {
+		return nil, nil, errs.ErrBlobNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), &media.BlobInfo{ContentType: b.types[key], Size: int64(len(data))}, nil
+}
+
+func (b *blobRecorder) Delete(ctx context.Context, key string) error {
+	delete(b.blobs, key)
+	delete(b.types, key)
+
+	return nil
+}
+
+func pngOf(t *testing.T, width, height int) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+
+	return buf.Bytes()
+}
+
+func gifOf(t *testing.T, frames int) []byte {
+	t.Helper()
+	animation := &gif.GIF{}
+	for range frames {
+		animation.Image = append(animation.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), palette.Plan9))
+		animation.Delay = append(animation.Delay, 0)
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, gif.EncodeAll(buf, animation))
+
+	return buf.Bytes()
+}
+
+func TestUploadImage(t *testing.T) {
+	blobs := newBlobRecorder(-1)
+	handler := service.NewMediaHandler(blobs)
+
+	postImage, err := handler.UploadImage(context.Background(), pngOf(t, 640, 480))
+	require.NoError(t, err)
+	assert.Equal(t, imaging.PNG, postImage.ContentType)
+	assert.Equal(t, 640, postImage.Width)
+	assert.Equal(t, 480, postImage.Height)
+	assert.Equal(t, strings.TrimSuffix(postImage.Key, ".png")+"_thumb.png", postImage.ThumbnailKey)
+	assert.Equal(t, media.URLPrefix+postImage.Key, postImage.URL)
+	assert.Equal(t, media.URLPrefix+postImage.ThumbnailKey, postImage.ThumbnailURL)
+
+	thumb, info, err := handler.GetBlob(context.Background(), postImage.ThumbnailKey)
+	require.NoError(t, err)
+	defer thumb.Close()
+	cfg, err := png.DecodeConfig(thumb)
+	require.NoError(t, err)
+	assert.Equal(t, imaging.PNG, info.ContentType)
+	assert.Equal(t, media.ThumbnailSide, cfg.Width)
+	assert.Equal(t, media.ThumbnailSide*3/4, cfg.Height)
+
+	// Deleted along with the thumbnail
+	require.NoError(t, handler.DeleteImage(context.Background(), postImage))
+	assert.Empty(t, blobs.blobs)
+}
+
+func TestUploadImageErrors(t *testing.T) {
+	valid := pngOf(t, 64, 64)
+	for _, tc := range []struct {
+		name      string
+		data      []byte
+		failAfter int
+		expected  error
+	}{
+		{"too many bytes", make([]byte, media.MaxImageSize+1), -1, errs.ErrImageTooLarge},
+		{"not an image", []byte("%PDF-1.7"), -1, errs.ErrUnsupportedMedia},
+		{"too many frames", gifOf(t, media.MaxImageFrames+1), -1, errs.ErrImageTooLarge},
+		{"broken image", valid[:len(valid)/2], -1, errs.ErrBadImage},
+		{"image not saved", valid, 0, errBlobStore},
+		{"thumbnail not saved", valid, 1, errBlobStore},
+	} {
+		blobs := newBlobRecorder(tc.failAfter)
+
+		_, err := service.NewMediaHandler(blobs).UploadImage(context.Background(), tc.data)
+		assert.ErrorIs(t, err, tc.expected, tc.name)
+		// Nothing is left behind
+		assert.Empty(t, blobs.blobs, tc.name)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+	"github.com/Benzogang-Tape/Reddit/pkg/markdown"
+)
+
+func TestMentions(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	userRepo := inmem.NewUserRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(),
+		service.WithMarkdown(markdown.NewRenderer(0)),
+		service.WithMentions(userRepo, inmem.NewMentionRepo()),
+	)
+	contexts := make(map[users.Username]context.Context)
+	for _, login := range []users.Username{"alice", "bob", "carol"} {
+		user, err := userRepo.RegisterUser(context.Background(), users.AuthUserInfo{Login: login, Password: "password"})
+		require.NoError(t, err)
+		contexts[login] = context.WithValue(context.Background(), jwt.Payload, &jwt.TokenPayload{Login: user.Username, ID: user.ID})
+	}
+	mentionsOf := func(login users.Username) []*posts.MentionEvent {
+		events, err := handler.GetMentions(contexts[login], posts.Page{})
+		require.NoError(t, err)
+		return events
+	}
+
+	// Only the registered users are mentioned, each of them once
+	post, err := handler.CreatePost(contexts["alice"], posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Title",
+		Category: posts.Music,
+		Text:     "Thanks @bob and /u/carol, not @ghost. Me@bob.com is no mention, @alice and @bob are",
+	})
+	require.NoError(t, err)
+	require.Len(t, post.Mentions, 3)
+	assert.Equal(t, users.Username("bob"), post.Mentions[0].Login)
+	assert.Equal(t, users.Username("carol"), post.Mentions[1].Login)
+	assert.Equal(t, users.Username("alice"), post.Mentions[2].Login)
+	assert.Contains(t, post.TextHTML, `<a href="/u/bob" rel="nofollow noreferrer">@bob</a>`)
+	assert.Contains(t, post.TextHTML, `<a href="/u/carol" rel="nofollow noreferrer">/u/carol</a>`)
+	assert.NotContains(t, post.TextHTML, "/u/ghost")
+
+	// The author is never told about mentioning himself/herself
+	assert.Empty(t, mentionsOf("alice"))
+	for _, login := range []users.Username{"bob", "carol"} {
+		events := mentionsOf(login)
+		require.Len(t, events, 1, login)
+		assert.Equal(t, post.ID, events[0].PostID)
+		assert.Empty(t, events[0].CommentID)
+		assert.Equal(t, users.Username("alice"), events[0].Author.Login)
+	}
+
+	// Mentions in comments
+	post, err = handler.AddComment(contexts["bob"], post.ID, posts.Comment{Body: "You're welcome @alice"})
+	require.NoError(t, err)
+	comment := post.Comments[0]
+	require.Len(t, comment.Mentions, 1)
+	assert.Contains(t, comment.BodyHTML, `href="/u/alice"`)
+	events := mentionsOf("alice")
+	require.Len(t, events, 1)
+	assert.Equal(t, comment.ID, events[0].CommentID)
+
+	// An edit tells only the users who were not mentioned before
+	post, err = handler.EditComment(contexts["bob"], post.ID, comment.ID, posts.Comment{Body: "You're welcome @alice and @carol"})
+	require.NoError(t, err)
+	assert.Len(t, post.Comments[0].Mentions, 2)
+	assert.Len(t, mentionsOf("alice"), 1)
+	events = mentionsOf("carol")
+	require.Len(t, events, 2)
+	assert.Equal(t, comment.ID, events[0].CommentID)
+
+	// Logins are case-insensitive, the mention links to the user as registered
+	post, err = handler.AddComment(contexts["carol"], post.ID, posts.Comment{Body: "Ask @Bob, /u/BOB knows"})
+	require.NoError(t, err)
+	require.Len(t, post.Comments[1].Mentions, 1)
+	assert.Equal(t, users.Username("bob"), post.Comments[1].Mentions[0].Login)
+	assert.Contains(t, post.Comments[1].BodyHTML, `<a href="/u/bob" rel="nofollow noreferrer">@Bob</a>`)
+	assert.Contains(t, post.Comments[1].BodyHTML, `<a href="/u/bob" rel="nofollow noreferrer">/u/BOB</a>`)
+	events = mentionsOf("bob")
+	require.Len(t, events, 2)
+	assert.Equal(t, post.Comments[1].ID, events[0].CommentID)
+
+	// The number of mentions is capped
+	crowd := make([]string, 0, posts.MaxMentions+1)
+	for i := range posts.MaxMentions + 1 {
+		crowd = append(crowd, fmt.Sprintf("@user%d", i))
+	}
+	_, err = handler.CreatePost(contexts["alice"], posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: strings.Join(crowd, " ")})
+	assert.ErrorIs(t, err, errs.ErrTooManyMentions)
+	_, err = handler.AddComment(contexts["alice"], post.ID, posts.Comment{Body: strings.Join(crowd, " ")})
+	assert.ErrorIs(t, err, errs.ErrTooManyMentions)
+	_, err = handler.EditComment(contexts["bob"], post.ID, comment.ID, posts.Comment{Body: strings.Join(crowd, " ")})
+	assert.ErrorIs(t, err, errs.ErrTooManyMentions)
+
+	// Repeating a user does not count
+	_, err = handler.AddComment(contexts["alice"], post.ID, posts.Comment{Body: strings.Repeat("@bob ", posts.MaxMentions+1)})
+	assert.NoError(t, err)
+
+	// Lists withhold the users mentioned in a spoiler along with its text
+	spoiler, err := handler.CreatePost(contexts["alice"], posts.PostPayload{
+		Type: posts.WithText, Title: "Ending", Category: posts.Music, Text: "@bob dies", Spoiler: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, spoiler.Mentions, 1)
+	postList, err := handler.GetAllPosts(contexts["alice"], posts.FeedQuery{})
+	require.NoError(t, err)
+	for _, listed := range postList {
+		if listed.ID == spoiler.ID {
+			assert.Empty(t, listed.Text)
+			assert.Empty(t, listed.Mentions)
+		}
+	}
+
+	// A viewer is required
+	_, err = handler.GetMentions(context.Background(), posts.Page{})
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+}
+
+func TestMentionsDisabled(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithMarkdown(markdown.NewRenderer(0)))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Hi @voter"})
+	require.NoError(t, err)
+	assert.Empty(t, post.Mentions)
+	assert.Equal(t, "<p>Hi @voter</p>\n", post.TextHTML)
+
+	_, err = handler.GetMentions(authorCtx, posts.Page{})
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestNSFWPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	communityRepo := inmem.NewCommunityRepo()
+	handler := service.NewPostHandler(repo, repo, communityRepo, service.WithPreferences(inmem.NewUserRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	// Posts of NSFW communities are NSFW by default
+	_, err := service.NewCommunityHandler(communityRepo).CreateCommunity(authorCtx, communities.CommunityPayload{Name: "after_dark", NSFW: true})
+	require.NoError(t, err)
+	nsfw, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: "after_dark", Text: "Text"})
+	require.NoError(t, err)
+	assert.True(t, nsfw.NSFW)
+	safe, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	assert.False(t, safe.NSFW)
+
+	// Nobody sees NSFW posts in the feeds by default
+	for _, ctx := range []context.Context{context.Background(), voterCtx} {
+		postList, err := handler.GetAllPosts(ctx, posts.FeedQuery{NSFW: true})
+		require.NoError(t, err)
+		require.Len(t, postList, 1)
+		assert.Equal(t, safe.ID, postList[0].ID)
+	}
+
+	// Unless they allow it
+	_, err = handler.UpdatePreferences(context.Background(), users.Preferences{ShowNSFW: true})
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+	prefs, err := handler.UpdatePreferences(voterCtx, users.Preferences{ShowNSFW: true})
+	require.NoError(t, err)
+	assert.True(t, prefs.ShowNSFW)
+	prefs, err = handler.GetPreferences(voterCtx)
+	require.NoError(t, err)
+	assert.True(t, prefs.ShowNSFW)
+
+	postList, err := handler.GetPostsByUser(voterCtx, author.Login, posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 2)
+	postList, err = handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 1)
+
+	// Search follows the same preferences as the feeds
+	for _, tc := range []struct {
+		ctx      context.Context
+		expected int
+	}{
+		{context.Background(), 1},
+		{authorCtx, 1},
+		{voterCtx, 2},
+	} {
+		postList, err = handler.SearchPosts(tc.ctx, posts.SearchQuery{Text: "title", NSFW: true})
+		require.NoError(t, err)
+		assert.Len(t, postList, tc.expected)
+	}
+
+	// Only the author and the moderators set the flags
+	_, err = handler.SetPostFlags(voterCtx, safe.ID, posts.FlagsPayload{NSFW: true})
+	assert.ErrorIs(t, err, errs.ErrNotAuthor)
+	post, err := handler.SetPostFlags(authorCtx, safe.ID, posts.FlagsPayload{NSFW: true})
+	require.NoError(t, err)
+	assert.True(t, post.NSFW)
+
+	postList, err = handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, postList)
+}
+
+func TestSpoilerPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	spoiler, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Ending", Category: posts.Music, Text: "Everyone dies", Spoiler: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Everyone dies", spoiler.Text)
+
+	// Lists withhold the text
+	postList, err := handler.GetAllPosts(authorCtx, posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.True(t, postList[0].Spoiler)
+	assert.Empty(t, postList[0].Text)
+
+	// The post itself does not
+	post, err := handler.GetPostByID(context.Background(), spoiler.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Everyone dies", post.Text)
+
+	post, err = handler.SetPostFlags(authorCtx, spoiler.ID, posts.FlagsPayload{})
+	require.NoError(t, err)
+	assert.False(t, post.Spoiler)
+
+	postList, err = handler.GetAllPosts(authorCtx, posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, "Everyone dies", postList[0].Text)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestPinnedPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithPins(inmem.NewPinRepo()), service.WithAdmins(author.Login))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	postIDs := make([]users.ID, 0, posts.MaxPins+1)
+	for range posts.MaxPins + 1 {
+		post, err := handler.CreatePost(voterCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+		require.NoError(t, err)
+		postIDs = append(postIDs, post.ID)
+	}
+	// The last post is the most popular one
+	_, err := handler.Upvote(authorCtx, postIDs[posts.MaxPins])
+	require.NoError(t, err)
+
+	// Only moderators pin posts in a category, only admins on the front page
+	_, err = handler.PinPost(voterCtx, postIDs[0], posts.PinPayload{})
+	assert.ErrorIs(t, err, errs.ErrNotModerator)
+	_, err = handler.PinPost(voterCtx, postIDs[0], posts.PinPayload{FrontPage: true})
+	assert.ErrorIs(t, err, errs.ErrNotAdmin)
+	_, err = handler.PinPost(authorCtx, postIDs[0], posts.PinPayload{Position: -1})
+	assert.ErrorIs(t, err, errs.ErrBadPin)
+
+	for _, postID := range postIDs[:posts.MaxPins] {
+		post, err := handler.PinPost(authorCtx, postID, posts.PinPayload{})
+		require.NoError(t, err)
+		assert.True(t, post.Pinned)
+	}
+	_, err = handler.PinPost(authorCtx, postIDs[posts.MaxPins], posts.PinPayload{})
+	assert.ErrorIs(t, err, errs.ErrTooManyPins)
+
+	// Pins come first in their order regardless of the score
+	postList, err := handler.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, posts.MaxPins+1)
+	for i, postID := range postIDs[:posts.MaxPins] {
+		assert.Equal(t, postID, postList[i].ID)
+		assert.True(t, postList[i].Pinned)
+	}
+	assert.False(t, postList[posts.MaxPins].Pinned)
+
+	// Category pins stay in the category
+	postList, err = handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, postIDs[posts.MaxPins], postList[0].ID)
+	assert.False(t, postList[0].Pinned)
+
+	// Pinning a pinned post moves it
+	_, err = handler.PinPost(authorCtx, postIDs[2], posts.PinPayload{Position: 1})
+	require.NoError(t, err)
+	postList, err = handler.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []users.ID{postIDs[2], postIDs[0], postIDs[1]}, []users.ID{postList[0].ID, postList[1].ID, postList[2].ID})
+
+	_, err = handler.UnpinPost(authorCtx, postIDs[0], false)
+	require.NoError(t, err)
+	_, err = handler.UnpinPost(authorCtx, postIDs[0], false)
+	assert.ErrorIs(t, err, errs.ErrPinNotFound)
+
+	// Front page pins
+	_, err = handler.PinPost(authorCtx, postIDs[1], posts.PinPayload{FrontPage: true})
+	require.NoError(t, err)
+	postList, err = handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, postIDs[1], postList[0].ID)
+	assert.True(t, postList[0].Pinned)
+}
+
+func TestPinsExpire(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	pinRepo := inmem.NewPinRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithPins(pinRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	_, err = handler.PinPost(authorCtx, post.ID, posts.PinPayload{Expires: &past})
+	assert.ErrorIs(t, err, errs.ErrBadPin)
+
+	// The pin has expired by the time the feed is read
+	require.NoError(t, pinRepo.UpdatePins(context.Background(), posts.Music, func(posts.Pins) (posts.Pins, error) {
+		return posts.Pins{{PostID: post.ID, Scope: posts.Music, Expires: &past}}, nil
+	}))
+	postList, err := handler.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.False(t, postList[0].Pinned)
+
+	// An expired pin is gone for the moderators as well
+	_, err = handler.UnpinPost(authorCtx, post.ID, false)
+	assert.ErrorIs(t, err, errs.ErrPinNotFound)
+
+	// Without the storage the feature is off
+	disabled := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	_, err = disabled.PinPost(authorCtx, post.ID, posts.PinPayload{})
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
+
+func TestPinsConcurrency(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithPins(inmem.NewPinRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	postIDs := make([]users.ID, 0, 2*posts.MaxPins)
+	for range 2 * posts.MaxPins {
+		post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+		require.NoError(t, err)
+		postIDs = append(postIDs, post.ID)
+	}
+
+	// The posts pinned at once never exceed the limit
+	wg := &sync.WaitGroup{}
+	pinned := make(chan error, len(postIDs))
+	for _, postID := range postIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := handler.PinPost(authorCtx, postID, posts.PinPayload{})
+			pinned <- err
+		}()
+	}
+	wg.Wait()
+	close(pinned)
+
+	succeeded := 0
+	for err := range pinned {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, errs.ErrTooManyPins)
+	}
+	assert.Equal(t, posts.MaxPins, succeeded)
+
+	postList, err := handler.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
+	require.NoError(t, err)
+	pins := 0
+	for _, post := range postList {
+		if post.Pinned {
+			pins++
+		}
+	}
+	assert.Equal(t, posts.MaxPins, pins)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+var voter = &jwt.TokenPayload{
+	Login: "voter",
+	ID:    "11111111-1111-1111-1111-111111111111",
+}
+
+func pollPayload(options []string, multipleChoice bool, closesAt *time.Time) posts.PostPayload {
+	return posts.PostPayload{
+		Type:     posts.WithPoll,
+		Title:    "Favourite genre",
+		Category: posts.Music,
+		Poll: &posts.PollPayload{
+			Options:        options,
+			MultipleChoice: multipleChoice,
+			ClosesAt:       closesAt,
+		},
+	}
+}
+
+func TestCreatePoll(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	ctx := context.WithValue(context.Background(), jwt.Payload, author)
+	soon := time.Now().Add(time.Minute)
+	tooLate := time.Now().Add(2 * posts.MaxPollDuration)
+
+	for name, payload := range map[string]posts.PostPayload{
+		"no poll":           {Type: posts.WithPoll, Title: "Poll", Category: posts.Music},
+		"single option":     pollPayload([]string{"Rock"}, false, nil),
+		"too many options":  pollPayload([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}, false, nil),
+		"blank option":      pollPayload([]string{"Rock", "  "}, false, nil),
+		"duplicate options": pollPayload([]string{"Rock", " rock"}, false, nil),
+		"closes too soon":   pollPayload([]string{"Rock", "Jazz"}, false, &soon),
+		"closes too late":   pollPayload([]string{"Rock", "Jazz"}, false, &tooLate),
+	} {
+		_, err := handler.CreatePost(ctx, payload)
+		assert.ErrorIs(t, err, errs.ErrBadPoll, name)
+	}
+
+	post, err := handler.CreatePost(ctx, pollPayload([]string{" Rock ", "Jazz", "Blues"}, true, nil))
+	require.NoError(t, err)
+	assert.Equal(t, posts.WithPoll, post.Type)
+	assert.Equal(t, []*posts.PollOption{
+		{ID: 0, Text: "Rock"},
+		{ID: 1, Text: "Jazz"},
+		{ID: 2, Text: "Blues"},
+	}, post.Poll.Options)
+	assert.True(t, post.Poll.MultipleChoice)
+	assert.False(t, post.Poll.ResultsVisible)
+}
+
+func TestCastBallot(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	poll, err := handler.CreatePost(authorCtx, pollPayload([]string{"Rock", "Jazz", "Blues"}, false, nil))
+	require.NoError(t, err)
+	textPost, err := handler.CreatePost(authorCtx, posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Not a poll",
+		Category: posts.Music,
+		Text:     "Some text",
+	})
+	require.NoError(t, err)
+
+	// Not a poll
+	_, err = handler.CastBallot(voterCtx, textPost.ID, posts.BallotPayload{Choice: []int{0}})
+	assert.ErrorIs(t, err, errs.ErrNotAPoll)
+
+	// Bad choice
+	for _, choice := range [][]int{nil, {3}, {-1}, {0, 1}} {
+		_, err = handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: choice})
+		assert.ErrorIs(t, err, errs.ErrBadBallot, choice)
+	}
+
+	// First ballot reveals the results to the voter only
+	post, err := handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{1}})
+	require.NoError(t, err)
+	assert.True(t, post.Poll.ResultsVisible)
+	assert.Equal(t, []int{1}, post.Poll.Choice)
+	assert.Equal(t, 1, post.Poll.Options[1].Votes)
+	assert.Equal(t, 1, post.Poll.TotalVoters)
+
+	post, err = handler.GetPostByID(authorCtx, poll.ID)
+	require.NoError(t, err)
+	assert.False(t, post.Poll.ResultsVisible)
+	assert.Nil(t, post.Poll.Choice)
+	assert.Zero(t, post.Poll.Options[1].Votes)
+	assert.Equal(t, 1, post.Poll.TotalVoters)
+
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	for _, listed := range postList {
+		if listed.ID == poll.ID {
+			assert.False(t, listed.Poll.ResultsVisible)
+			assert.Zero(t, listed.Poll.Options[1].Votes)
+		}
+	}
+
+	// Changing the ballot moves the vote
+	post, err = handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{2}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, post.Poll.Choice)
+	assert.Zero(t, post.Poll.Options[1].Votes)
+	assert.Equal(t, 1, post.Poll.Options[2].Votes)
+	assert.Equal(t, 1, post.Poll.TotalVoters)
+
+	post, err = handler.CastBallot(authorCtx, poll.ID, posts.BallotPayload{Choice: []int{2}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, post.Poll.Options[2].Votes)
+	assert.Equal(t, 2, post.Poll.TotalVoters)
+}
+
+func TestCastBallotMultipleChoice(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	poll, err := handler.CreatePost(voterCtx, pollPayload([]string{"Rock", "Jazz", "Blues"}, true, nil))
+	require.NoError(t, err)
+
+	_, err = handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{1, 1}})
+	assert.ErrorIs(t, err, errs.ErrBadBallot)
+
+	post, err := handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{2, 0}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, post.Poll.Choice)
+	assert.Equal(t, []int{1, 0, 1}, []int{post.Poll.Options[0].Votes, post.Poll.Options[1].Votes, post.Poll.Options[2].Votes})
+
+	post, err = handler.CastBallot(voterCtx, poll.ID, posts.BallotPayload{Choice: []int{1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 1}, []int{post.Poll.Options[0].Votes, post.Poll.Options[1].Votes, post.Poll.Options[2].Votes})
+}
+
+func TestClosedPoll(t *testing.T) {
+	closedAt := time.Now().Add(-time.Minute)
+	poll := posts.NewPoll(&posts.PollPayload{
+		Options:  []string{"Rock", "Jazz"},
+		ClosesAt: &closedAt,
+	})
+	poll.Options[0].Votes = 3
+
+	_, err := poll.Cast(voter.ID, []int{0}, time.Now())
+	assert.ErrorIs(t, err, errs.ErrPollClosed)
+
+	// Everyone sees the results of a closed poll
+	view := poll.View("", time.Now())
+	assert.True(t, view.Closed)
+	assert.True(t, view.ResultsVisible)
+	assert.Equal(t, 3, view.Options[0].Votes)
+
+	view = poll.View("", closedAt.Add(-time.Minute))
+	assert.False(t, view.Closed)
+	assert.Zero(t, view.Options[0].Votes)
+	assert.Equal(t, 3, poll.Options[0].Votes)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestReposts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithRepostPolicy(service.RepostPolicy{Window: time.Hour}))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+	link := func(url string, category posts.PostCategory) posts.PostPayload {
+		return posts.PostPayload{Type: posts.WithLink, Title: "Title", Category: category, URL: url}
+	}
+
+	original, err := handler.CreatePost(authorCtx, link("https://www.Example.com/article/?utm_source=feed&id=1", posts.Music))
+	require.NoError(t, err)
+	assert.Empty(t, original.Reposts)
+
+	// The same page behind a different form of the link is a repost in the same category only
+	repost, err := handler.CreatePost(voterCtx, link("http://example.com/article?id=1#comments", posts.Music))
+	require.NoError(t, err)
+	assert.Equal(t, "http://example.com/article?id=1#comments", repost.URL)
+	require.Len(t, repost.Reposts, 1)
+	assert.Equal(t, original.ID, repost.Reposts[0])
+
+	elsewhere, err := handler.CreatePost(voterCtx, link("https://example.com/article?id=1", posts.Programming))
+	require.NoError(t, err)
+	assert.Empty(t, elsewhere.Reposts)
+
+	// The warning is for the author of the new post only
+	stored, err := handler.GetPostByID(context.Background(), repost.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Reposts)
+
+	// Other discussions are found for any form of the link
+	discussions, err := handler.GetPostsByURL(context.Background(), "example.com/article/?id=1&fbclid=abc")
+	require.NoError(t, err)
+	assert.Len(t, discussions, 3)
+	_, err = handler.GetPostsByURL(context.Background(), "https://")
+	assert.ErrorIs(t, err, errs.ErrInvalidURL)
+
+	// Shorteners are expanded
+	video, err := handler.CreatePost(authorCtx, link("https://youtu.be/dQw4w9WgXcQ?t=42", posts.Music))
+	require.NoError(t, err)
+	shared, err := handler.CreatePost(voterCtx, link("https://m.youtube.com/watch?t=42&v=dQw4w9WgXcQ&utm_medium=share", posts.Music))
+	require.NoError(t, err)
+	require.Len(t, shared.Reposts, 1)
+	assert.Equal(t, video.ID, shared.Reposts[0])
+
+	// Posts older than the window are not reposts anymore
+	stored, err = repo.GetPostByID(context.Background(), original.ID)
+	require.NoError(t, err)
+	stored.Created = time.Now().Add(-2 * time.Hour)
+	stored, err = repo.GetPostByID(context.Background(), repost.ID)
+	require.NoError(t, err)
+	stored.Created = time.Now().Add(-2 * time.Hour)
+	late, err := handler.CreatePost(authorCtx, link("https://example.com/article?id=1", posts.Music))
+	require.NoError(t, err)
+	assert.Empty(t, late.Reposts)
+}
+
+func TestRejectReposts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithRepostPolicy(service.RepostPolicy{Window: time.Hour, Reject: true}))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	payload := posts.PostPayload{Type: posts.WithLink, Title: "Title", Category: posts.Music, URL: "https://example.com/"}
+
+	_, err := handler.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+	_, err = handler.CreatePost(authorCtx, payload)
+	assert.ErrorIs(t, err, errs.ErrRepost)
+
+	postList, err := handler.GetPostsByURL(context.Background(), "https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, postList, 1)
+
+	// Without a policy the same link may be posted over and over
+	permissive := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	newPost, err := permissive.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+	assert.Empty(t, newPost.Reposts)
+}
+
+func TestPostsByURLFilter(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithPreferences(inmem.NewUserRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+	payload := posts.PostPayload{Type: posts.WithLink, Title: "Title", Category: posts.Music, URL: "https://example.com/"}
+
+	nsfw, err := handler.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+	_, err = handler.SetPostFlags(authorCtx, nsfw.ID, posts.FlagsPayload{NSFW: true})
+	require.NoError(t, err)
+	hidden, err := handler.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+	_, err = handler.HidePost(voterCtx, hidden.ID)
+	require.NoError(t, err)
+	_, err = handler.CreatePost(authorCtx, payload)
+	require.NoError(t, err)
+
+	// The discussions are filtered as the feeds are
+	for _, tc := range []struct {
+		ctx      context.Context
+		expected int
+	}{
+		{context.Background(), 2},
+		{voterCtx, 1},
+	} {
+		postList, err := handler.GetPostsByURL(tc.ctx, "https://example.com")
+		require.NoError(t, err)
+		assert.Len(t, postList, tc.expected)
+	}
+
+	_, err = handler.UpdatePreferences(voterCtx, users.Preferences{ShowNSFW: true})
+	require.NoError(t, err)
+	postList, err := handler.GetPostsByURL(voterCtx, "https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, postList, 2)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestSavedItems(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithSavedItems(inmem.NewSavedRepo()))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Worth saving",
+		Category: posts.Music,
+		Text:     "Some text",
+	})
+	require.NoError(t, err)
+	post, err = handler.AddComment(authorCtx, post.ID, posts.Comment{Body: "Great comment"})
+	require.NoError(t, err)
+	commentID := post.Comments[0].ID
+
+	// Unknown items
+	_, err = handler.SavePost(voterCtx, "12345678-9abc-def1-2345-6789abcdef12")
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+	_, err = handler.SaveComment(voterCtx, post.ID, "12345678-9abc-def1-2345-6789abcdef12")
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Saving is idempotent and flags the items for the viewer only
+	saved, err := handler.SavePost(voterCtx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, saved.Saved)
+	_, err = handler.SavePost(voterCtx, post.ID)
+	require.NoError(t, err)
+	saved, err = handler.SaveComment(voterCtx, post.ID, commentID)
+	require.NoError(t, err)
+	assert.True(t, saved.Saved)
+	assert.True(t, saved.Comments[0].Saved)
+
+	viewed, err := handler.GetPostByID(authorCtx, post.ID)
+	require.NoError(t, err)
+	assert.False(t, viewed.Saved)
+	assert.False(t, viewed.Comments[0].Saved)
+
+	postList, err := handler.GetAllPosts(voterCtx, posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.True(t, postList[0].Saved)
+
+	// Listing, the most recently saved first
+	entries, err := handler.GetSaved(voterCtx, posts.SavedQuery{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, posts.ItemComment, entries[0].Type)
+	assert.Equal(t, commentID, entries[0].Comment.ID)
+	assert.Equal(t, "Worth saving", entries[0].PostTitle)
+	assert.Equal(t, posts.ItemPost, entries[1].Type)
+	assert.Equal(t, post.ID, entries[1].Post.ID)
+
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{Type: posts.ItemPost})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, post.ID, entries[0].PostID)
+
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{Page: posts.Page{Limit: 1, Offset: 1}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, posts.ItemPost, entries[0].Type)
+
+	// Unsaving
+	unsaved, err := handler.UnsavePost(voterCtx, post.ID)
+	require.NoError(t, err)
+	assert.False(t, unsaved.Saved)
+	assert.True(t, unsaved.Comments[0].Saved)
+
+	// Deleted items are forgotten
+	_, err = handler.DeleteComment(authorCtx, post.ID, commentID)
+	require.NoError(t, err)
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = handler.SavePost(voterCtx, post.ID)
+	require.NoError(t, err)
+	require.NoError(t, handler.DeletePost(authorCtx, post.ID))
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSavedItemsDisabled(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	_, err := handler.GetSaved(voterCtx, posts.SavedQuery{})
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
+
+func TestSavedItemsVanished(t *testing.T) { //nolint:funlen
+	repo := inmem.NewPostRepo()
+	saved := inmem.NewSavedRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithSavedItems(saved))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	postIDs := make([]users.ID, 0, 3)
+	for _, title := range []string{"First", "Second", "Third"} {
+		post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: title, Category: posts.Music, Text: "Text"})
+		require.NoError(t, err)
+		_, err = handler.SavePost(voterCtx, post.ID)
+		require.NoError(t, err)
+		postIDs = append(postIDs, post.ID)
+	}
+	post, err := handler.AddComment(authorCtx, postIDs[0], posts.Comment{Body: "Parent"})
+	require.NoError(t, err)
+	parentID := post.Comments[0].ID
+	_, err = handler.AddComment(authorCtx, postIDs[0], posts.Comment{Body: "Reply", ParentID: parentID})
+	require.NoError(t, err)
+	_, err = handler.SaveComment(voterCtx, postIDs[0], parentID)
+	require.NoError(t, err)
+
+	// The items vanish behind the back of the handler
+	require.NoError(t, repo.DeletePost(context.Background(), postIDs[1]))
+	stored, err := repo.GetPostByID(context.Background(), postIDs[0])
+	require.NoError(t, err)
+	_, err = repo.DeleteComment(authorCtx, stored, parentID)
+	require.NoError(t, err)
+
+	// The pages stay full and the offsets keep pointing at the same items
+	entries, err := handler.GetSaved(voterCtx, posts.SavedQuery{Page: posts.Page{Limit: 1}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, postIDs[2], entries[0].PostID)
+
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{Page: posts.Page{Limit: 1, Offset: 1}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, postIDs[0], entries[0].PostID)
+	assert.Equal(t, posts.ItemPost, entries[0].Type)
+
+	entries, err = handler.GetSaved(voterCtx, posts.SavedQuery{Page: posts.Page{Limit: 1, Offset: 2}})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// The vanished items are forgotten
+	items, err := saved.GetSavedItems(context.Background(), voter.ID, posts.SavedQuery{Page: posts.NewPage(0, 0)})
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	// A placeholder cannot be saved
+	_, err = handler.SaveComment(voterCtx, postIDs[0], parentID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+}
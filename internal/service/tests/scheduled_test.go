@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestScheduledPosts(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	scheduledRepo := inmem.NewScheduledRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+	publishAt := time.Now().Add(time.Hour)
+	payload := posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt}
+
+	// The publish time must be in the future and not too far away
+	for _, at := range []time.Time{time.Now().Add(-time.Minute), time.Now().Add(posts.MaxScheduleAhead + time.Hour)} {
+		invalid := payload
+		invalid.PublishAt = &at
+		_, err := handler.SchedulePost(authorCtx, invalid)
+		assert.ErrorIs(t, err, errs.ErrBadPublishTime)
+	}
+	// The content is validated as for CreatePost
+	invalid := payload
+	invalid.Category = "unknown"
+	_, err := handler.SchedulePost(authorCtx, invalid)
+	assert.ErrorIs(t, err, errs.ErrInvalidCategory)
+
+	scheduled, err := handler.SchedulePost(authorCtx, payload)
+	require.NoError(t, err)
+	assert.Equal(t, author.ID, scheduled.Author.ID)
+	assert.Nil(t, scheduled.Post.PublishAt)
+
+	// Scheduled posts are invisible until published
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, postList)
+
+	scheduledList, err := handler.GetScheduledPosts(authorCtx)
+	require.NoError(t, err)
+	require.Len(t, scheduledList, 1)
+	assert.Equal(t, scheduled.ID, scheduledList[0].ID)
+	scheduledList, err = handler.GetScheduledPosts(voterCtx)
+	require.NoError(t, err)
+	assert.Empty(t, scheduledList)
+
+	// Other users do not see the scheduled posts of the author
+	_, err = handler.UpdateScheduledPost(voterCtx, scheduled.ID, payload)
+	assert.ErrorIs(t, err, errs.ErrScheduledPostNotFound)
+	assert.ErrorIs(t, handler.CancelScheduledPost(voterCtx, scheduled.ID), errs.ErrScheduledPostNotFound)
+
+	later := time.Now().Add(2 * time.Hour)
+	edited := payload
+	edited.Title = "Edited"
+	edited.PublishAt = &later
+	scheduled, err = handler.UpdateScheduledPost(authorCtx, scheduled.ID, edited)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", scheduled.Post.Title)
+	assert.WithinDuration(t, later, scheduled.PublishAt, time.Millisecond)
+
+	require.NoError(t, handler.CancelScheduledPost(authorCtx, scheduled.ID))
+	assert.ErrorIs(t, handler.CancelScheduledPost(authorCtx, scheduled.ID), errs.ErrScheduledPostNotFound)
+
+	// Without the storage the feature is off
+	disabled := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	_, err = disabled.SchedulePost(authorCtx, payload)
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+	_, err = disabled.GetScheduledPosts(authorCtx)
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
+
+func TestPostSchedulerPublishesOnce(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	scheduledRepo := inmem.NewScheduledRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	publishAt := time.Now().Add(time.Minute)
+	_, err := handler.SchedulePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt})
+	require.NoError(t, err)
+
+	// Both instances share the storage
+	first := service.NewPostScheduler(handler, scheduledRepo, zap.NewNop().Sugar(), service.SchedulerConfig{})
+	second := service.NewPostScheduler(handler, scheduledRepo, zap.NewNop().Sugar(), service.SchedulerConfig{})
+
+	// Nothing is due yet
+	assert.Zero(t, first.PublishDue(context.Background(), time.Now()))
+
+	due := publishAt.Add(time.Second)
+	assert.Equal(t, 1, first.PublishDue(context.Background(), due))
+	assert.Zero(t, second.PublishDue(context.Background(), due))
+	assert.Zero(t, first.PublishDue(context.Background(), due))
+
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.Equal(t, "Title", postList[0].Title)
+	assert.Equal(t, author.ID, postList[0].Author.ID)
+	scheduledList, err := handler.GetScheduledPosts(authorCtx)
+	require.NoError(t, err)
+	assert.Empty(t, scheduledList)
+}
+
+// racingSchedule lets something happen to the schedule right after the due posts have been listed
+type racingSchedule struct {
+	*inmem.ScheduledRepo
+	race func()
+}
+
+func (r *racingSchedule) GetDueScheduledPosts(ctx context.Context, now time.Time, limit int) ([]*posts.ScheduledPost, error) {
+	dueList, err := r.ScheduledRepo.GetDueScheduledPosts(ctx, now, limit)
+	r.race()
+
+	return dueList, err
+}
+
+func TestPostSchedulerRaces(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	scheduledRepo := inmem.NewScheduledRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	publishAt := time.Now().Add(time.Minute)
+	due := publishAt.Add(time.Second)
+	scheduled, err := handler.SchedulePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt})
+	require.NoError(t, err)
+	schedule := &racingSchedule{ScheduledRepo: scheduledRepo}
+	scheduler := service.NewPostScheduler(handler, schedule, zap.NewNop().Sugar(), service.SchedulerConfig{})
+
+	// The latest version is published
+	schedule.race = func() {
+		payload := scheduled.Post
+		payload.Title, payload.PublishAt = "Edited", &publishAt
+		_, err := handler.UpdateScheduledPost(authorCtx, scheduled.ID, payload)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, scheduler.PublishDue(context.Background(), due))
+	postList, err := handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	require.Len(t, postList, 1)
+	assert.Equal(t, "Edited", postList[0].Title)
+
+	// Once published, it can't be changed or canceled
+	_, err = handler.UpdateScheduledPost(authorCtx, scheduled.ID, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt})
+	assert.ErrorIs(t, err, errs.ErrScheduledPostNotFound)
+	assert.ErrorIs(t, handler.CancelScheduledPost(authorCtx, scheduled.ID), errs.ErrScheduledPostNotFound)
+
+	// Canceled posts are never published
+	scheduled, err = handler.SchedulePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Canceled", Category: posts.Music, Text: "Text", PublishAt: &publishAt})
+	require.NoError(t, err)
+	schedule.race = func() {
+		require.NoError(t, handler.CancelScheduledPost(authorCtx, scheduled.ID))
+	}
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due))
+	postList, err = handler.GetAllPosts(context.Background(), posts.FeedQuery{})
+	require.NoError(t, err)
+	assert.Len(t, postList, 1)
+}
+
+// flakyPosts fails to create the first post
+type flakyPosts struct {
+	*inmem.PostRepo
+	failed bool
+}
+
+func (f *flakyPosts) CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
+	if !f.failed {
+		f.failed = true
+		return nil, errs.ErrUnknownError
+	}
+
+	return f.PostRepo.CreatePost(ctx, postPayload)
+}
+
+func TestPostSchedulerRetries(t *testing.T) {
+	repo := &flakyPosts{PostRepo: inmem.NewPostRepo()}
+	scheduledRepo := inmem.NewScheduledRepo()
+	handler := service.NewPostHandler(repo, repo.PostRepo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	publishAt := time.Now().Add(time.Minute)
+	due := publishAt.Add(time.Second)
+	scheduled, err := handler.SchedulePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt})
+	require.NoError(t, err)
+	scheduler := service.NewPostScheduler(handler, scheduledRepo, zap.NewNop().Sugar(), service.SchedulerConfig{})
+
+	// A post that fails to be published stays on the schedule
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due))
+	kept, err := scheduledRepo.GetScheduledPost(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, scheduled.Post, kept.Post)
+	assert.Equal(t, 1, kept.Attempts)
+
+	// It is held until the lease runs out
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due.Add(time.Minute)))
+
+	// And is published once it does
+	assert.Equal(t, 1, scheduler.PublishDue(context.Background(), due.Add(service.DefaultSchedulerLease)))
+	_, err = scheduledRepo.GetScheduledPost(context.Background(), scheduled.ID)
+	assert.ErrorIs(t, err, errs.ErrScheduledPostNotFound)
+}
+
+func TestPostSchedulerGivesUp(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	scheduledRepo := inmem.NewScheduledRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	publishAt := time.Now().Add(time.Minute)
+	due := publishAt.Add(time.Second)
+	payload := posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt}
+	scheduled, err := handler.SchedulePost(authorCtx, payload)
+	require.NoError(t, err)
+	lease := time.Minute
+	scheduler := service.NewPostScheduler(handler, scheduledRepo, zap.NewNop().Sugar(), service.SchedulerConfig{Lease: lease})
+
+	// A post held by an instance that crashed is published by another one once the lease runs out
+	_, err = scheduledRepo.ClaimScheduledPost(context.Background(), scheduled.ID, due, due.Add(lease))
+	require.NoError(t, err)
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due))
+	assert.Equal(t, 1, scheduler.PublishDue(context.Background(), due.Add(lease)))
+
+	// A post that always fails is given up on
+	flaky := &flakyPosts{PostRepo: inmem.NewPostRepo()}
+	handler = service.NewPostHandler(flaky, flaky.PostRepo, inmem.NewCommunityRepo(), service.WithScheduledPosts(scheduledRepo))
+	scheduled, err = handler.SchedulePost(authorCtx, payload)
+	require.NoError(t, err)
+	require.NoError(t, scheduledRepo.UpdateScheduledPost(context.Background(), &posts.ScheduledPost{
+		ID:        scheduled.ID,
+		Author:    scheduled.Author,
+		Post:      scheduled.Post,
+		PublishAt: scheduled.PublishAt,
+		Attempts:  posts.MaxPublishAttempts - 1,
+	}))
+	scheduler = service.NewPostScheduler(handler, scheduledRepo, zap.NewNop().Sugar(), service.SchedulerConfig{Lease: lease})
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due))
+	assert.Zero(t, scheduler.PublishDue(context.Background(), due.Add(time.Hour)))
+	kept, err := scheduledRepo.GetScheduledPost(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, posts.MaxPublishAttempts, kept.Attempts)
+
+	// Editing the post gives it another chance
+	_, err = handler.UpdateScheduledPost(authorCtx, scheduled.ID, payload)
+	require.NoError(t, err)
+	assert.Equal(t, 1, scheduler.PublishDue(context.Background(), due.Add(time.Hour)))
+
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestPostStats(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	statsRepo := inmem.NewStatsRepo(0)
+	recorder := service.NewStatsRecorder(statsRepo, zap.NewNop().Sugar(), service.StatsConfig{})
+	tracker := service.NewViewTracker(inmem.NewViewCounter(), repo, zap.NewNop().Sugar(), service.ViewConfig{})
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithStats(recorder), service.WithViewTracker(tracker))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	// Only unique views are recorded
+	for range 3 {
+		_, err = handler.GetPostByID(voterCtx, post.ID)
+		require.NoError(t, err)
+	}
+	_, err = handler.GetPostByID(authorCtx, post.ID)
+	require.NoError(t, err)
+
+	// The upvote of the author comes with the post. A changed vote moves from one series to the other,
+	// a vote taken back is subtracted
+	_, err = handler.Upvote(voterCtx, post.ID)
+	require.NoError(t, err)
+	_, err = handler.Upvote(voterCtx, post.ID)
+	require.NoError(t, err)
+	_, err = handler.Downvote(voterCtx, post.ID)
+	require.NoError(t, err)
+	_, err = handler.Unvote(authorCtx, post.ID)
+	require.NoError(t, err)
+	_, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "Comment"})
+	require.NoError(t, err)
+
+	// Nothing is visible until flushed
+	stats, err := handler.GetPostStats(authorCtx, post.ID, posts.StatsQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, stats.Buckets)
+
+	require.NoError(t, recorder.Flush(context.Background()))
+	stats, err = handler.GetPostStats(authorCtx, post.ID, posts.StatsQuery{})
+	require.NoError(t, err)
+	expected := posts.StatsCounts{Views: 2, Upvotes: 0, Downvotes: 1, Comments: 1}
+	assert.Equal(t, post.ID, stats.PostID)
+	assert.Equal(t, posts.Hourly, stats.Interval)
+	assert.Equal(t, expected, stats.Total)
+	require.Len(t, stats.Buckets, 1)
+	assert.Equal(t, expected, stats.Buckets[0].StatsCounts)
+	assert.Equal(t, time.Now().UTC().Truncate(time.Hour), stats.Buckets[0].Start)
+
+	// Hours are merged into days
+	require.NoError(t, statsRepo.AddStats(context.Background(), []*posts.StatsBucket{
+		{PostID: post.ID, Start: time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC), StatsCounts: posts.StatsCounts{Views: 3}},
+		{PostID: post.ID, Start: time.Date(2024, time.March, 1, 22, 0, 0, 0, time.UTC), StatsCounts: posts.StatsCounts{Views: 4}},
+	}))
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	stats, err = handler.GetPostStats(authorCtx, post.ID, posts.StatsQuery{Interval: posts.Daily, From: from, To: from.Add(24 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, stats.Buckets, 1)
+	assert.Equal(t, from, stats.Buckets[0].Start)
+	assert.Equal(t, 7, stats.Buckets[0].Views)
+
+	// The stats are for the author only
+	_, err = handler.GetPostStats(voterCtx, post.ID, posts.StatsQuery{})
+	assert.ErrorIs(t, err, errs.ErrNotAuthor)
+	_, err = handler.GetPostStats(authorCtx, post.ID, posts.StatsQuery{Interval: "week"})
+	assert.ErrorIs(t, err, errs.ErrBadStatsQuery)
+	_, err = handler.GetPostStats(authorCtx, post.ID, posts.StatsQuery{From: time.Now().Add(time.Hour), To: time.Now()})
+	assert.ErrorIs(t, err, errs.ErrBadStatsQuery)
+
+	disabled := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo())
+	_, err = disabled.GetPostStats(authorCtx, post.ID, posts.StatsQuery{})
+	assert.ErrorIs(t, err, errs.ErrFeatureDisabled)
+}
+
+func TestStatsRetention(t *testing.T) {
+	statsRepo := inmem.NewStatsRepo(24 * time.Hour)
+	now := time.Now().UTC().Truncate(time.Hour)
+	postID := author.ID
+
+	require.NoError(t, statsRepo.AddStats(context.Background(), []*posts.StatsBucket{
+		{PostID: postID, Start: now.Add(-48 * time.Hour), StatsCounts: posts.StatsCounts{Views: 1}},
+		{PostID: postID, Start: now, StatsCounts: posts.StatsCounts{Views: 2}},
+	}))
+
+	buckets, err := statsRepo.GetStats(context.Background(), postID, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, 2, buckets[0].Views)
+}
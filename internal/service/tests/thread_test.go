@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func TestCommentThreads(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithCommentDepth(2))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	other, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Other", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	other, err = handler.AddComment(voterCtx, other.ID, posts.Comment{Body: "Elsewhere"})
+	require.NoError(t, err)
+
+	// A chain of replies: top -> reply -> deep -> deeper
+	parentID := users.ID("")
+	ids := make([]users.ID, 0, 4)
+	for _, body := range []string{"Top", "Reply", "Deep", "Deeper"} {
+		post, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: body, ParentID: parentID})
+		require.NoError(t, err)
+		stored, err := repo.GetPostByID(context.Background(), post.ID)
+		require.NoError(t, err)
+		parentID = stored.Comments[len(stored.Comments)-1].ID
+		ids = append(ids, parentID)
+	}
+	post, err = handler.AddComment(authorCtx, post.ID, posts.Comment{Body: "Second top"})
+	require.NoError(t, err)
+
+	// The parent must be on the same post
+	_, err = handler.AddComment(voterCtx, post.ID, posts.Comment{Body: "Lost", ParentID: other.Comments[0].ID})
+	assert.ErrorIs(t, err, errs.ErrBadParentComment)
+
+	// The tree stops at the maximum depth with a stub
+	post, err = handler.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	require.Len(t, post.Comments, 2)
+	top := post.Comments[0]
+	assert.Equal(t, ids[0], top.ID)
+	require.Len(t, top.Replies, 1)
+	assert.Equal(t, ids[1], top.Replies[0].ID)
+	assert.Equal(t, 1, top.Replies[0].Depth)
+	assert.Empty(t, top.Replies[0].Replies)
+	assert.Equal(t, 2, top.Replies[0].MoreReplies)
+	assert.Equal(t, "Second top", post.Comments[1].Body)
+
+	// The stub is continued by the thread of the comment
+	thread, err := handler.GetCommentThread(context.Background(), post.ID, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], thread.ID)
+	require.Len(t, thread.Replies, 1)
+	assert.Equal(t, ids[2], thread.Replies[0].ID)
+	assert.Equal(t, 1, thread.Replies[0].MoreReplies)
+	_, err = handler.GetCommentThread(context.Background(), post.ID, other.Comments[0].ID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// A deleted comment with replies stays in the thread as a placeholder
+	post, err = handler.DeleteComment(voterCtx, post.ID, ids[1])
+	require.NoError(t, err)
+	require.Len(t, post.Comments, 2)
+	require.Len(t, post.Comments[0].Replies, 1)
+	placeholder := post.Comments[0].Replies[0]
+	assert.Equal(t, ids[1], placeholder.ID)
+	assert.True(t, placeholder.Deleted)
+	assert.Equal(t, posts.DeletedPlaceholder, placeholder.Body)
+	assert.Empty(t, placeholder.Author.Login)
+	// The self-upvote of the author would tell who it was
+	assert.Empty(t, placeholder.Votes)
+	assert.Zero(t, placeholder.Score)
+	assert.Equal(t, 2, placeholder.MoreReplies)
+	_, err = handler.DeleteComment(voterCtx, post.ID, ids[1])
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+	_, err = handler.EditComment(voterCtx, post.ID, ids[1], posts.Comment{Body: "Back"})
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+	_, err = handler.UpvoteComment(authorCtx, post.ID, ids[1])
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Placeholders are removed once no replies survive under them
+	post, err = handler.DeleteComment(voterCtx, post.ID, ids[3])
+	require.NoError(t, err)
+	post, err = handler.DeleteComment(voterCtx, post.ID, ids[2])
+	require.NoError(t, err)
+	require.Len(t, post.Comments, 2)
+	assert.Empty(t, post.Comments[0].Replies)
+	assert.Zero(t, post.Comments[0].MoreReplies)
+	stored, err := repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Comments, 2)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+var errUnavailable = errors.New("storage is unavailable")
+
+// flakyViews fails to save the views while down
+type flakyViews struct {
+	*inmem.PostRepo
+	down bool
+}
+
+func (f *flakyViews) AddViews(ctx context.Context, views map[users.ID]uint) error {
+	if f.down {
+		return errUnavailable
+	}
+
+	return f.PostRepo.AddViews(ctx, views)
+}
+
+// brokenCounter never remembers anybody
+type brokenCounter struct{}
+
+func (brokenCounter) AddViewer(ctx context.Context, key, viewer string, ttl time.Duration) (bool, error) {
+	return false, errUnavailable
+}
+
+func TestUniqueViews(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	views := &flakyViews{PostRepo: repo}
+	tracker := service.NewViewTracker(inmem.NewViewCounter(), views, zap.NewNop().Sugar(), service.ViewConfig{Window: time.Hour})
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithViewTracker(tracker))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+	voterCtx := context.WithValue(context.Background(), jwt.Payload, voter)
+	anonCtx := context.WithValue(context.Background(), posts.Fingerprint, posts.NewFingerprint("10.0.0.1:5000", "Firefox"))
+	otherAnonCtx := context.WithValue(context.Background(), posts.Fingerprint, posts.NewFingerprint("10.0.0.1:6000", "Chrome"))
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+	require.Equal(t, uint(1), post.Views)
+
+	// Refreshing the page does not inflate the views, the views not saved yet are shown all the same
+	viewed, err := handler.GetPostByID(voterCtx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), viewed.Views)
+	viewed, err = handler.GetPostByID(voterCtx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), viewed.Views)
+
+	// Anonymous viewers are told apart by the fingerprint, the port does not matter
+	for _, ctx := range []context.Context{anonCtx, anonCtx, otherAnonCtx, context.WithValue(context.Background(), posts.Fingerprint, posts.NewFingerprint("10.0.0.1:7000", "Firefox"))} {
+		_, err = handler.GetPostByID(ctx, post.ID)
+		require.NoError(t, err)
+	}
+	// Viewers nothing is known about are not counted
+	_, err = handler.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+
+	// The views reach the storage only when flushed, a failed flush keeps them for the next one
+	views.down = true
+	assert.ErrorIs(t, tracker.Flush(context.Background()), errUnavailable)
+	stored, err := repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), stored.Views)
+	viewed, err = handler.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), viewed.Views)
+
+	views.down = false
+	require.NoError(t, tracker.Flush(context.Background()))
+	stored, err = repo.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), stored.Views)
+	require.NoError(t, tracker.Flush(context.Background()))
+	assert.Equal(t, uint(4), stored.Views)
+	viewed, err = handler.GetPostByID(voterCtx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), viewed.Views)
+
+	// A new window counts everybody again
+	counted, err := tracker.Record(context.Background(), post.ID, "user:"+string(voter.ID), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, counted)
+}
+
+func TestViewsWithoutCounter(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	tracker := service.NewViewTracker(brokenCounter{}, repo, zap.NewNop().Sugar(), service.ViewConfig{})
+	handler := service.NewPostHandler(repo, repo, inmem.NewCommunityRepo(), service.WithViewTracker(tracker))
+	authorCtx := context.WithValue(context.Background(), jwt.Payload, author)
+
+	post, err := handler.CreatePost(authorCtx, posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text"})
+	require.NoError(t, err)
+
+	// The post is still served, the view is lost
+	viewed, err := handler.GetPostByID(authorCtx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), viewed.Views)
+}
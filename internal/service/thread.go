@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// WithCommentDepth sets the number of levels of the comment trees. Deeper replies are left
+// behind "continue thread" stubs. Non-positive depths keep the default
+func WithCommentDepth(depth int) PostHandlerOption {
+	return func(p *PostHandler) {
+		if depth > 0 {
+			p.commentDepth = depth
+		}
+	}
+}
+
+// GetCommentThread returns the comment with the tree of the replies to it, the way to continue a thread past its stub
+func (p *PostHandler) GetCommentThread(ctx context.Context, postID, commentID users.ID) (*posts.PostComment, error) {
+	source := "GetCommentThread"
+	post, err := p.postWithComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postList, err := p.views(ctx, []*posts.Post{post})
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	thread, err := postList[0].Thread(commentID, p.commentDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return thread, nil
+}
+
+// GetComments returns a page of the comment trees of the post, the way to load the comments past the first page
+func (p *PostHandler) GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error) {
+	source := "GetComments"
+	post, err := p.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	page, err := p.repo.GetComments(ctx, postID, query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postList, err := p.views(ctx, []*posts.Post{post.WithComments(page.Comments)})
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return &posts.CommentPage{
+		Comments: postList[0].CommentTrees(query.Sort, p.commentDepth),
+		After:    page.After,
+	}, nil
+}
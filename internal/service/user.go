@@ -12,6 +12,7 @@ import (
 type UserStorage interface {
 	RegisterUser(ctx context.Context, authData users.AuthUserInfo) (*users.User, error)
 	Authorize(ctx context.Context, authData users.AuthUserInfo) (*users.User, error)
+	GetUsersByLogins(ctx context.Context, logins []users.Username) ([]*users.User, error)
 }
 
 type UserHandler struct {
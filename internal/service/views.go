@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const (
+	DefaultViewWindow        = 24 * time.Hour
+	DefaultViewFlushInterval = 10 * time.Second
+)
+
+// ViewCounter remembers who has viewed what. It is shared by all the instances of the app
+type ViewCounter interface {
+	// AddViewer records the viewer under the key kept for ttl and reports whether the viewer is new to it.
+	// Every true is counted as a view. The answer may be approximate, missing a rare new viewer, but a known
+	// viewer must never be reported as new
+	AddViewer(ctx context.Context, key, viewer string, ttl time.Duration) (bool, error)
+}
+
+type ViewStorage interface {
+	AddViews(ctx context.Context, views map[users.ID]uint) error
+}
+
+type ViewConfig struct {
+	Window        time.Duration // A viewer counts once per post within the window
+	FlushInterval time.Duration // How often the new views are added to the posts
+}
+
+// ViewTracker counts the unique views of the posts and saves them in batches
+type ViewTracker struct {
+	counter  ViewCounter
+	repo     ViewStorage
+	logger   *zap.SugaredLogger
+	window   time.Duration
+	interval time.Duration
+
+	mu       sync.Mutex
+	pending  map[users.ID]uint
+	flushing map[users.ID]uint // Taken out of pending by the flush in progress, not saved yet
+}
+
+func NewViewTracker(counter ViewCounter, repo ViewStorage, logger *zap.SugaredLogger, cfg ViewConfig) *ViewTracker {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultViewWindow
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultViewFlushInterval
+	}
+
+	return &ViewTracker{
+		counter:  counter,
+		repo:     repo,
+		logger:   logger,
+		window:   cfg.Window,
+		interval: cfg.FlushInterval,
+		pending:  make(map[users.ID]uint),
+	}
+}
+
+// WithViewTracker makes the handler count a view of a post once per viewer and window
+func WithViewTracker(tracker *ViewTracker) PostHandlerOption {
+	return func(p *PostHandler) {
+		p.viewTracker = tracker
+	}
+}
+
+// Record counts the view of the post unless the viewer has already seen it within the current window.
+// It reports whether the view has been counted
+func (t *ViewTracker) Record(ctx context.Context, postID users.ID, viewer string, now time.Time) (bool, error) {
+	if viewer == "" {
+		return false, nil
+	}
+
+	start := now.Truncate(t.window)
+	key := fmt.Sprintf("views:%s:%d", postID, start.Unix())
+	isNew, err := t.counter.AddViewer(ctx, key, viewer, start.Add(t.window).Sub(now))
+	if err != nil {
+		t.logger.Warnw("failed to count the view", "post", postID, "err", err)
+		return false, err
+	}
+	if !isNew {
+		return false, nil
+	}
+
+	t.mu.Lock()
+	t.pending[postID]++
+	t.mu.Unlock()
+
+	return true, nil
+}
+
+// Pending returns the views of the post counted by this instance but not saved yet
+func (t *ViewTracker) Pending(postID users.ID) uint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.pending[postID] + t.flushing[postID]
+}
+
+// Flush adds the views counted since the last flush to the posts. The views are kept for the next flush on failure
+func (t *ViewTracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	views := t.pending
+	t.pending, t.flushing = make(map[users.ID]uint), views
+	t.mu.Unlock()
+	if len(views) == 0 {
+		return nil
+	}
+
+	err := t.repo.AddViews(ctx, views)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.flushing = nil
+	if err != nil {
+		for postID, count := range views {
+			t.pending[postID] += count
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Run flushes the views every interval until the context is canceled, then flushes the rest
+func (t *ViewTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := t.Flush(context.WithoutCancel(ctx)); err != nil {
+				t.logger.Errorw("views lost on shutdown", "err", err)
+			}
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				t.logger.Warnw("failed to flush views", "err", err)
+			}
+		}
+	}
+}
+
+// viewer identifies the viewer of a post: the user if signed in, the fingerprint otherwise
+func viewer(ctx context.Context) string {
+	if userID := viewerID(ctx); userID != "" {
+		return "user:" + string(userID)
+	}
+	if fingerprint, ok := ctx.Value(posts.Fingerprint).(string); ok && fingerprint != "" {
+		return "anon:" + fingerprint
+	}
+
+	return ""
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+)
+
+// BlobStoreFS keeps blobs as files in a single directory
+type BlobStoreFS struct {
+	root string
+}
+
+func NewBlobStoreFS(root string) (*BlobStoreFS, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &BlobStoreFS{
+		root: root,
+	}, nil
+}
+
+func (b *BlobStoreFS) Put(ctx context.Context, key string, data []byte, contentType string) error { //nolint:unparam
+	source := "Put"
+	path, err := b.path(key)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	// Write to a temporary file first, so readers never see a partially written blob
+	tmp, err := os.CreateTemp(b.root, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return errors.Wrap(err, source)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), path), source)
+}
+
+func (b *BlobStoreFS) Get(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error) { //nolint:unparam
+	source := "Get"
+	path, err := b.path(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	file, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil, errors.Wrap(errs.ErrBlobNotFound, source)
+	case err != nil:
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	return file, &media.BlobInfo{
+		ContentType: mime.TypeByExtension(filepath.Ext(key)),
+		Size:        stat.Size(),
+		ModTime:     stat.ModTime(),
+	}, nil
+}
+
+func (b *BlobStoreFS) Delete(ctx context.Context, key string) error { //nolint:unparam
+	source := "Delete"
+	path, err := b.path(key)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+// path makes sure the key can't escape the root directory
+func (b *BlobStoreFS) path(key string) (string, error) {
+	if key == "" || key[0] == '.' || filepath.Base(key) != key {
+		return "", errs.ErrBlobNotFound
+	}
+
+	return filepath.Join(b.root, key), nil
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	amzScopeFormat  = "20060102"
+	amzAlgorithm    = "AWS4-HMAC-SHA256"
+	amzService      = "s3"
+	emptyBodySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Config is the address of a bucket in any S3-compatible storage (AWS, MinIO, Ceph...)
+type S3Config struct {
+	Endpoint  string // e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// BlobStoreS3 talks to the bucket with plain path-style requests signed by AWS Signature Version 4
+type BlobStoreS3 struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewBlobStoreS3(cfg S3Config, client *http.Client) *BlobStoreS3 {
+	return &BlobStoreS3{
+		cfg:    cfg,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (b *BlobStoreS3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	source := "Put"
+	req, err := b.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = int64(len(data))
+
+	resp, err := b.do(req, data)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(unexpectedStatus(resp), source)
+	}
+
+	return nil
+}
+
+func (b *BlobStoreS3) Get(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error) {
+	source := "Get"
+	req, err := b.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	resp, err := b.do(req, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, source)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close() //nolint:errcheck
+		return nil, nil, errors.Wrap(errs.ErrBlobNotFound, source)
+	default:
+		defer resp.Body.Close()
+		return nil, nil, errors.Wrap(unexpectedStatus(resp), source)
+	}
+
+	info := &media.BlobInfo{
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+	}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modTime
+	}
+
+	return resp.Body, info, nil
+}
+
+func (b *BlobStoreS3) Delete(ctx context.Context, key string) error {
+	source := "Delete"
+	req, err := b.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	resp, err := b.do(req, nil)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return errors.Wrap(unexpectedStatus(resp), source)
+	}
+}
+
+func (b *BlobStoreS3) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	endpoint, err := url.Parse(b.cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	endpoint = endpoint.JoinPath(b.cfg.Bucket, key)
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	return http.NewRequestWithContext(ctx, method, endpoint.String(), reader)
+}
+
+func (b *BlobStoreS3) do(req *http.Request, body []byte) (*http.Response, error) {
+	b.sign(req, body)
+	return b.client.Do(req)
+}
+
+// sign adds the Authorization header as described in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (b *BlobStoreS3) sign(req *http.Request, body []byte) {
+	now := b.now().UTC()
+	payloadHash := emptyBodySHA256
+	if body != nil {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		signedHeaders = append(signedHeaders, strings.ToLower(name))
+	}
+	sort.Strings(signedHeaders)
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(amzScopeFormat), b.cfg.Region, amzService, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		amzAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+b.cfg.SecretKey), now.Format(amzScopeFormat))
+	signingKey = hmacSHA256(signingKey, b.cfg.Region)
+	signingKey = hmacSHA256(signingKey, amzService)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		amzAlgorithm,
+		b.cfg.AccessKey,
+		scope,
+		strings.Join(signedHeaders, ";"),
+		signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10)) //nolint:errcheck
+	return fmt.Errorf("unexpected s3 response status %d: %s", resp.StatusCode, body)
+}
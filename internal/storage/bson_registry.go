@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// NewRegistry returns the BSON registry of the app. It is the default one, except that time.Time
+// is also decoded from the strings the timestamps were stored as before they became dates
+func NewRegistry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	dateDecoder, err := registry.LookupDecoder(timeType)
+	if err != nil {
+		panic(err)
+	}
+	registry.RegisterTypeDecoder(timeType, legacyTimeDecoder{dateDecoder: dateDecoder})
+
+	return registry
+}
+
+type legacyTimeDecoder struct {
+	dateDecoder bsoncodec.ValueDecoder
+}
+
+func (d legacyTimeDecoder) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	source := "DecodeValue"
+	if vr.Type() != bsontype.String {
+		return d.dateDecoder.DecodeValue(dc, vr, val)
+	}
+
+	str, err := vr.ReadString()
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	parsed, err := parseLegacyTime(str)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	val.Set(reflect.ValueOf(parsed))
+
+	return nil
+}
+
+// parseLegacyTime parses a timestamp stored as a string
+func parseLegacyTime(str string) (time.Time, error) {
+	parsed, err := time.Parse(posts.TimeFormat, str)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339Nano, str)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parsed.UTC(), nil
+}
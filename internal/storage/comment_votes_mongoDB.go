@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+func (p *PostRepoMongoDB) UpvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, post, commentID, (*posts.PostComment).Upvote)
+}
+
+func (p *PostRepoMongoDB) DownvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, post, commentID, (*posts.PostComment).Downvote)
+}
+
+// voteComment updates the vote and the score of the comment in place. The score is incremented,
+// so concurrent votes of the other users are never overwritten. The current vote of the user is part of the filter,
+// so a concurrent vote of the same user can't be counted twice
+func (p *PostRepoMongoDB) voteComment(
+	ctx context.Context,
+	post *posts.Post,
+	commentID users.ID,
+	vote func(*posts.PostComment, users.ID) (*posts.PostVote, bool),
+) (*posts.Post, error) {
+	source := "voteComment"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = comment.CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	scoreBefore, voteBefore := comment.Score, comment.VoteOf(author.ID)
+	newVote, created := vote(comment, author.ID)
+	if !created && comment.Score == scoreBefore {
+		return post, nil
+	}
+
+	filter := bson.M{"uuid": commentID, "deleted": bson.M{"$ne": true}}
+	update := bson.M{
+		"$inc": bson.M{"score": comment.Score - scoreBefore},
+	}
+	if created {
+		filter["votes.user"] = bson.M{"$ne": author.ID}
+		update["$push"] = bson.M{"votes": newVote}
+	} else {
+		filter["votes"] = bson.M{"$elemMatch": bson.M{"user": author.ID, "vote": voteBefore}}
+		update["$set"] = bson.M{"votes.$.vote": newVote.Vote}
+	}
+	matchedCount, err := p.comments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrVoteConflict, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) UnvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	source := "UnvoteComment"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	vote := comment.VoteOf(author.ID)
+	if err = comment.Unvote(author.ID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	filter := bson.M{"uuid": commentID, "votes": bson.M{"$elemMatch": bson.M{"user": author.ID, "vote": vote}}}
+	update := bson.M{
+		"$pull": bson.M{"votes": bson.M{"user": author.ID}},
+		"$inc":  bson.M{"score": -int(vote)},
+	}
+	matchedCount, err := p.comments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrVoteConflict, source)
+	}
+
+	return post, nil
+}
+
+// GetKarma sums up the scores of the posts and the comments of the user
+func (p *PostRepoMongoDB) GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error) {
+	source := "GetKarma"
+	filter := bson.M{"author.username": userLogin}
+	opts := options.Find().SetProjection(bson.M{"author": 1, "score": 1, "votes": 1})
+	cur, err := p.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return users.Karma{}, errors.Wrap(err, source)
+	}
+	postList := make([]*posts.Post, 0)
+	if err = cur.All(ctx, &postList); err != nil {
+		return users.Karma{}, errors.Wrap(err, source)
+	}
+
+	if cur, err = p.comments.Find(ctx, filter, opts); err != nil {
+		return users.Karma{}, errors.Wrap(err, source)
+	}
+	comments := make([]*posts.PostComment, 0)
+	if err = cur.All(ctx, &comments); err != nil {
+		return users.Karma{}, errors.Wrap(err, source)
+	}
+
+	return posts.Karma(userLogin, postList, comments), nil
+}
@@ -0,0 +1,457 @@
+package storage
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// createCommentIndexes creates the indexes of the comments collection
+func (p *PostRepoMongoDB) createCommentIndexes(ctx context.Context) error {
+	for _, index := range []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "uuid", Value: 1}},
+			Options: options.Index().SetName("comments_uuid").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "postId", Value: 1}, {Key: "created", Value: 1}},
+			Options: options.Index().SetName("comments_post"),
+		},
+		{
+			Keys:    bson.D{{Key: "parentId", Value: 1}},
+			Options: options.Index().SetName("comments_parent"),
+		},
+		{
+			Keys:    bson.D{{Key: "author.username", Value: 1}},
+			Options: options.Index().SetName("comments_author"),
+		},
+		{
+			Keys:    bson.D{{Key: "body", Value: "text"}},
+			Options: options.Index().SetName("comments_text"),
+		},
+	} {
+		if _, err := p.comments.CreateIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// topLevel matches the top-level comments of the post, they are stored without a parent
+func topLevel(postID users.ID) bson.M {
+	return bson.M{"postId": postID, "parentId": bson.M{"$in": bson.A{nil, ""}}}
+}
+
+// commentSortKey returns the expression the comments are sorted by in the order and whether the order descends,
+// computed the same way PostComment computes it
+func commentSortKey(sort posts.CommentSort) (any, bool) {
+	switch sort {
+	case posts.SortTop:
+		return "$score", true
+	case posts.SortNew:
+		return "$created", true
+	case posts.SortOld:
+		return "$created", false
+	case posts.SortControversial:
+		return controversy(), true
+	default:
+		return confidence(), true
+	}
+}
+
+// countVotes counts the votes of the comment that compare to zero with the operator
+func countVotes(operator string) bson.M {
+	return bson.M{"$size": bson.M{"$filter": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$votes", bson.A{}}},
+		"as":    "vote",
+		"cond":  bson.M{operator: bson.A{"$$vote.vote", 0}},
+	}}}
+}
+
+// confidence is the lower bound of the Wilson score interval of the share of upvotes, the "best" order
+func confidence() bson.M {
+	z2 := posts.WilsonZ * posts.WilsonZ
+	phat := bson.M{"$divide": bson.A{"$$ups", "$$n"}}
+	spread := bson.M{"$divide": bson.A{
+		bson.M{"$add": bson.A{
+			bson.M{"$multiply": bson.A{phat, bson.M{"$subtract": bson.A{1, phat}}}},
+			bson.M{"$divide": bson.A{z2, bson.M{"$multiply": bson.A{4, "$$n"}}}},
+		}},
+		"$$n",
+	}}
+
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{
+			"ups": countVotes("$gt"),
+			"n":   bson.M{"$add": bson.A{countVotes("$gt"), countVotes("$lt")}},
+		},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{"$$n", 0}},
+			0.0,
+			bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{
+					bson.M{"$add": bson.A{phat, bson.M{"$divide": bson.A{z2, bson.M{"$multiply": bson.A{2, "$$n"}}}}}},
+					bson.M{"$multiply": bson.A{posts.WilsonZ, bson.M{"$sqrt": spread}}},
+				}},
+				bson.M{"$add": bson.A{1, bson.M{"$divide": bson.A{z2, "$$n"}}}},
+			}},
+		}},
+	}}
+}
+
+// controversy grows with the number of votes and with how evenly they are split
+func controversy() bson.M {
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{"ups": countVotes("$gt"), "downs": countVotes("$lt")},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$or": bson.A{bson.M{"$eq": bson.A{"$$ups", 0}}, bson.M{"$eq": bson.A{"$$downs", 0}}}},
+			0.0,
+			bson.M{"$pow": bson.A{
+				bson.M{"$add": bson.A{"$$ups", "$$downs"}},
+				bson.M{"$divide": bson.A{
+					bson.M{"$min": bson.A{"$$ups", "$$downs"}},
+					bson.M{"$max": bson.A{"$$ups", "$$downs"}},
+				}},
+			}},
+		}},
+	}}
+}
+
+// commentKey is the position of a top-level comment in the order
+type commentKey struct {
+	ID      users.ID      `bson:"uuid"`
+	Created bson.RawValue `bson:"created"`
+	SortKey bson.RawValue `bson:"sortKey"`
+}
+
+// GetComments sorts and pages the top-level comments of the post in the database, then loads all the replies under them.
+// The page is continued after the position of the cursor comment, ties broken by the time the comments were left
+func (p *PostRepoMongoDB) GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error) {
+	source := "GetComments"
+	key, descending := commentSortKey(query.Sort)
+	direction, beyond := 1, "$gt"
+	if descending {
+		direction, beyond = -1, "$lt"
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: topLevel(postID)}},
+		{{Key: "$addFields", Value: bson.M{"sortKey": key}}},
+	}
+	if query.After != "" {
+		after, err := p.commentKey(ctx, postID, query.After, key)
+		if err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"sortKey": bson.M{beyond: after.SortKey}},
+			bson.M{"sortKey": after.SortKey, "created": bson.M{"$gt": after.Created}},
+			bson.M{"sortKey": after.SortKey, "created": after.Created, "uuid": bson.M{"$gt": after.ID}},
+		}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "sortKey", Value: direction}, {Key: "created", Value: 1}, {Key: "uuid", Value: 1}}}},
+		// One comment more tells whether there is a next page
+		bson.D{{Key: "$limit", Value: query.Limit + 1}},
+	)
+
+	cur, err := p.comments.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	top := make([]*posts.PostComment, 0)
+	if err = cur.All(ctx, &top); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	page := &posts.CommentPage{}
+	if len(top) > query.Limit {
+		top = top[:query.Limit]
+		page.After = top[len(top)-1].ID
+	}
+	replies, err := p.replies(ctx, top)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	page.Comments = append(top, replies...)
+
+	return page, nil
+}
+
+// commentKey returns the position of the top-level comment the previous page ended with
+func (p *PostRepoMongoDB) commentKey(ctx context.Context, postID, commentID users.ID, key any) (*commentKey, error) {
+	filter := topLevel(postID)
+	filter["uuid"] = commentID
+	cur, err := p.comments.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{"uuid": 1, "created": 1, "sortKey": key}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*commentKey, 0, 1)
+	if err = cur.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errs.ErrBadCommentCursor
+	}
+
+	return keys[0], nil
+}
+
+// replies loads all the replies under the comments level by level, each level in the order the replies were left
+func (p *PostRepoMongoDB) replies(ctx context.Context, comments []*posts.PostComment) ([]*posts.PostComment, error) {
+	replies := make([]*posts.PostComment, 0)
+	for level := comments; len(level) != 0; {
+		parentIDs := make([]users.ID, 0, len(level))
+		for _, comment := range level {
+			parentIDs = append(parentIDs, comment.ID)
+		}
+		next, err := p.findComments(ctx, bson.M{"parentId": bson.M{"$in": parentIDs}})
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, next...)
+		level = next
+	}
+
+	return replies, nil
+}
+
+// findComments finds the comments in the order they were left
+func (p *PostRepoMongoDB) findComments(ctx context.Context, filter any) ([]*posts.PostComment, error) {
+	sort := bson.D{{Key: "created", Value: 1}, {Key: "_id", Value: 1}}
+	cur, err := p.comments.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	comments := make([]*posts.PostComment, 0)
+	if err = cur.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// LoadCommentThread returns the post with the comment, the comments above it with the replies to them and all the
+// replies under the comment attached. The post comes back without comments if the comment is not under it
+func (p *PostRepoMongoDB) LoadCommentThread(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	source := "LoadCommentThread"
+	path := make([]*posts.PostComment, 0)
+	for id := commentID; id != ""; {
+		comment := new(posts.PostComment)
+		err := p.comments.FindOne(ctx, bson.M{"uuid": id, "postId": post.ID}).Decode(comment)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+		path = append(path, comment)
+		id = comment.ParentID
+	}
+	if len(path) == 0 {
+		return post.WithComments(make([]*posts.PostComment, 0)), nil
+	}
+
+	pathIDs := make([]users.ID, 0, len(path))
+	for _, comment := range path {
+		pathIDs = append(pathIDs, comment.ID)
+	}
+	siblings, err := p.findComments(ctx, bson.M{"parentId": bson.M{"$in": pathIDs[1:]}})
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	replies, err := p.replies(ctx, path[:1])
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	seen := make(map[users.ID]struct{})
+	thread := make([]*posts.PostComment, 0, len(path)+len(siblings)+len(replies))
+	for _, comment := range slices.Concat(path, siblings, replies) {
+		if _, ok := seen[comment.ID]; !ok {
+			seen[comment.ID] = struct{}{}
+			thread = append(thread, comment)
+		}
+	}
+	slices.SortStableFunc(thread, func(a, b *posts.PostComment) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return post.WithComments(thread), nil
+}
+
+func (p *PostRepoMongoDB) GetCommentsByIDs(ctx context.Context, commentIDs []users.ID) ([]*posts.PostComment, error) {
+	comments, err := p.findComments(ctx, bson.M{"uuid": bson.M{"$in": commentIDs}})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetCommentsByIDs")
+	}
+
+	return comments, nil
+}
+
+// MigrateComments moves the comments embedded in the posts before into their own collection and counts them.
+// The moved comments are upserted by id, so a migration interrupted halfway is safe to run again. Returns the number of migrated posts
+func (p *PostRepoMongoDB) MigrateComments(ctx context.Context) (int64, error) {
+	source := "MigrateComments"
+	filter := bson.M{"comments": bson.M{"$exists": true}}
+	projection := bson.M{"uuid": 1, "comments": 1}
+	cur, err := p.collection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return 0, errors.Wrap(err, source)
+	}
+	embedded := make([]struct {
+		ID       users.ID             `bson:"uuid"`
+		Comments []*posts.PostComment `bson:"comments"`
+	}, 0)
+	if err = cur.All(ctx, &embedded); err != nil {
+		return 0, errors.Wrap(err, source)
+	}
+
+	var migrated int64
+	for _, post := range embedded {
+		models := make([]mongo.WriteModel, 0, len(post.Comments))
+		for _, comment := range post.Comments {
+			comment.PostID = post.ID
+			models = append(models, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"uuid": comment.ID}).
+				SetReplacement(comment).
+				SetUpsert(true))
+		}
+		if len(models) != 0 {
+			if _, err = p.comments.BulkWrite(ctx, models); err != nil {
+				return migrated, errors.Wrap(err, source)
+			}
+		}
+
+		update := bson.M{
+			"$set":   bson.M{"commentCount": len(post.Comments)},
+			"$unset": bson.M{"comments": ""},
+		}
+		if _, err = p.collection.UpdateOne(ctx, bson.M{"uuid": post.ID}, update); err != nil {
+			return migrated, errors.Wrap(err, source)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+func (p *PostRepoMongoDB) AddComment(ctx context.Context, post *posts.Post, comment posts.Comment) (*posts.Post, error) {
+	source := "AddComment"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	newComment, err := post.AddComment(*author, comment)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if _, err = p.comments.InsertOne(ctx, newComment); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = p.countComments(ctx, post); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) DeleteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	source := "DeleteComment"
+	removed, err := post.DeleteComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	if len(removed) == 0 {
+		err = p.blankComment(ctx, post, commentID)
+	} else if _, err = p.comments.DeleteMany(ctx, bson.M{"uuid": bson.M{"$in": removed}}); err == nil {
+		err = p.countComments(ctx, post)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
+
+// countComments recounts the comments of the post and stores the number with it. Unlike an increment, the recount
+// also heals the number left wrong by a write that failed between the comments and the post
+func (p *PostRepoMongoDB) countComments(ctx context.Context, post *posts.Post) error {
+	count, err := p.comments.CountDocuments(ctx, bson.M{"postId": post.ID})
+	if err != nil {
+		return err
+	}
+	if _, err = p.collection.UpdateOne(ctx, bson.M{"uuid": post.ID}, bson.M{"$set": bson.M{"commentCount": count}}); err != nil {
+		return err
+	}
+	post.CommentCount = int(count)
+
+	return nil
+}
+
+// blankComment stores the comment as the placeholder it was turned into
+func (p *PostRepoMongoDB) blankComment(ctx context.Context, post *posts.Post, commentID users.ID) error {
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"author":  comment.Author,
+			"body":    comment.Body,
+			"score":   0,
+			"deleted": true,
+		},
+		"$unset": bson.M{
+			"bodyHtml":  "",
+			"mentions":  "",
+			"edited":    "",
+			"revisions": "",
+			"votes":     "",
+		},
+	}
+	_, err = p.comments.UpdateOne(ctx, bson.M{"uuid": commentID}, update)
+
+	return err
+}
+
+// EditComment replaces the body of the comment and pushes the previous one to its revisions
+func (p *PostRepoMongoDB) EditComment(ctx context.Context, post *posts.Post, commentID users.ID, comment posts.Comment) (*posts.Post, error) {
+	source := "EditComment"
+	edited, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	revision := edited.Edit(comment, posts.Now())
+	update := bson.M{
+		"$set": bson.M{
+			"body":     edited.Body,
+			"bodyHtml": edited.BodyHTML,
+			"mentions": edited.Mentions,
+			"edited":   edited.Edited,
+		},
+		"$push": bson.M{"revisions": revision},
+	}
+	if _, err = p.comments.UpdateOne(ctx, bson.M{"uuid": commentID}, update); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return post, nil
+}
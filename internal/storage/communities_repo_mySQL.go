@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type CommunityRepoMySQL struct {
+	db *sql.DB
+}
+
+func NewCommunityRepoMySQL(db *sql.DB) *CommunityRepoMySQL {
+	return &CommunityRepoMySQL{
+		db: db,
+	}
+}
+
+func (repo *CommunityRepoMySQL) GetAllCommunities(ctx context.Context) ([]*communities.Community, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT uuid, name, description, creator_uuid, creator_login, nsfw FROM communities ORDER BY name",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	communityList := make([]*communities.Community, 0)
+	for rows.Next() {
+		community := &communities.Community{}
+		if err = rows.Scan(
+			&community.ID,
+			&community.Name,
+			&community.Description,
+			&community.Creator.ID,
+			&community.Creator.Login,
+			&community.NSFW,
+		); err != nil {
+			return nil, err
+		}
+		communityList = append(communityList, community)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, community := range communityList {
+		if err = repo.fillCommunity(ctx, community); err != nil {
+			return nil, err
+		}
+	}
+
+	return communityList, nil
+}
+
+func (repo *CommunityRepoMySQL) GetCommunityByName(ctx context.Context, name string) (*communities.Community, error) {
+	source := "GetCommunityByName"
+	community := &communities.Community{}
+	err := repo.db.
+		QueryRowContext(
+			ctx,
+			"SELECT uuid, name, description, creator_uuid, creator_login, nsfw FROM communities WHERE name = ?",
+			name,
+		).Scan(
+		&community.ID,
+		&community.Name,
+		&community.Description,
+		&community.Creator.ID,
+		&community.Creator.Login,
+		&community.NSFW,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errors.Wrap(errs.ErrCommunityNotFound, source)
+	case err != nil:
+		return nil, err
+	}
+
+	if err = repo.fillCommunity(ctx, community); err != nil {
+		return nil, err
+	}
+
+	return community, nil
+}
+
+func (repo *CommunityRepoMySQL) CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error) {
+	source := "CreateCommunity"
+	creator, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	var communityExists bool
+	err := repo.db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM communities WHERE name = ?)",
+		payload.Name,
+	).Scan(&communityExists)
+
+	switch {
+	case err != nil:
+		return nil, err
+	case communityExists:
+		return nil, errors.Wrap(errs.ErrCommunityExists, source)
+	}
+
+	newCommunity := communities.NewCommunity(*creator, payload)
+	err = repo.insertCommunity(ctx, newCommunity)
+	switch {
+	case isDuplicateEntry(err):
+		return nil, errors.Wrap(errs.ErrCommunityExists, source)
+	case err != nil:
+		return nil, errors.Wrap(err, source)
+	}
+
+	return newCommunity, nil
+}
+
+func (repo *CommunityRepoMySQL) AddFlair(ctx context.Context, community *communities.Community, flair communities.Flair) (*communities.Community, error) {
+	if _, err := repo.db.ExecContext(
+		ctx,
+		"INSERT INTO community_flairs (`uuid`, `community_uuid`, `text`, `color`) VALUES (?, ?, ?, ?)",
+		flair.ID,
+		community.ID,
+		flair.Text,
+		flair.Color,
+	); err != nil {
+		return nil, err
+	}
+	community.Flairs = append(community.Flairs, flair)
+
+	return community, nil
+}
+
+func (repo *CommunityRepoMySQL) DeleteFlair(ctx context.Context, community *communities.Community, flairID users.ID) (*communities.Community, error) {
+	source := "DeleteFlair"
+	res, err := repo.db.ExecContext(
+		ctx,
+		"DELETE FROM community_flairs WHERE community_uuid = ? AND uuid = ?",
+		community.ID,
+		flairID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, errors.Wrap(errs.ErrInvalidFlair, source)
+	}
+	community.Flairs = slices.DeleteFunc(community.Flairs, func(flair communities.Flair) bool {
+		return flair.ID == flairID
+	})
+
+	return community, nil
+}
+
+func (repo *CommunityRepoMySQL) insertCommunity(ctx context.Context, community *communities.Community) (err error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint:errcheck
+		}
+	}()
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO communities (`uuid`, `name`, `description`, `creator_uuid`, `creator_login`, `nsfw`) VALUES (?, ?, ?, ?, ?, ?)",
+		community.ID,
+		community.Name,
+		community.Description,
+		community.Creator.ID,
+		community.Creator.Login,
+		community.NSFW,
+	); err != nil {
+		return err
+	}
+
+	for _, moderator := range community.Moderators {
+		if _, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO community_moderators (`community_uuid`, `user_uuid`, `user_login`) VALUES (?, ?, ?)",
+			community.ID,
+			moderator.ID,
+			moderator.Login,
+		); err != nil {
+			return err
+		}
+	}
+
+	for position, rule := range community.Rules {
+		if _, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO community_rules (`community_uuid`, `position`, `title`, `description`) VALUES (?, ?, ?, ?)",
+			community.ID,
+			position,
+			rule.Title,
+			rule.Description,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *CommunityRepoMySQL) fillCommunity(ctx context.Context, community *communities.Community) error {
+	moderators, err := repo.db.QueryContext(
+		ctx,
+		"SELECT user_uuid, user_login FROM community_moderators WHERE community_uuid = ?",
+		community.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer moderators.Close()
+
+	community.Moderators = make([]jwt.TokenPayload, 0)
+	for moderators.Next() {
+		moderator := jwt.TokenPayload{}
+		if err = moderators.Scan(&moderator.ID, &moderator.Login); err != nil {
+			return err
+		}
+		community.Moderators = append(community.Moderators, moderator)
+	}
+	if err = moderators.Err(); err != nil {
+		return err
+	}
+
+	rules, err := repo.db.QueryContext(
+		ctx,
+		"SELECT title, description FROM community_rules WHERE community_uuid = ? ORDER BY position",
+		community.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rules.Close()
+
+	community.Rules = make([]communities.Rule, 0)
+	for rules.Next() {
+		rule := communities.Rule{}
+		if err = rules.Scan(&rule.Title, &rule.Description); err != nil {
+			return err
+		}
+		community.Rules = append(community.Rules, rule)
+	}
+	if err = rules.Err(); err != nil {
+		return err
+	}
+
+	flairs, err := repo.db.QueryContext(
+		ctx,
+		"SELECT uuid, text, color FROM community_flairs WHERE community_uuid = ? ORDER BY id",
+		community.ID,
+	)
+	if err != nil {
+		return err
+	}
+	defer flairs.Close()
+
+	community.Flairs = make([]communities.Flair, 0)
+	for flairs.Next() {
+		flair := communities.Flair{}
+		if err = flairs.Scan(&flair.ID, &flair.Text, &flair.Color); err != nil {
+			return err
+		}
+		community.Flairs = append(community.Flairs, flair)
+	}
+
+	return flairs.Err()
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type DraftRepoMongoDB struct {
+	collection AbstractCollection
+}
+
+func NewDraftRepoMongoDB(collection AbstractCollection) *DraftRepoMongoDB {
+	return &DraftRepoMongoDB{
+		collection: collection,
+	}
+}
+
+// CreateIndexes creates the indexes the repository relies on. It is safe to call it on every start of the app
+func (d *DraftRepoMongoDB) CreateIndexes(ctx context.Context) error {
+	source := "CreateIndexes"
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "author.id", Value: 1}, {Key: "updated", Value: -1}},
+		Options: options.Index().SetName("drafts_author"),
+	}
+	if _, err := d.collection.CreateIndex(ctx, index); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+func (d *DraftRepoMongoDB) AddDraft(ctx context.Context, draft *posts.Draft) error {
+	_, err := d.collection.InsertOne(ctx, draft)
+	return err
+}
+
+func (d *DraftRepoMongoDB) GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) {
+	res := d.collection.FindOne(ctx, bson.M{"uuid": draftID})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, errs.ErrDraftNotFound
+	}
+
+	draft := new(posts.Draft)
+	if err := res.Decode(draft); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// GetDrafts returns the drafts of the author, the most recently saved first
+func (d *DraftRepoMongoDB) GetDrafts(ctx context.Context, authorID users.ID) ([]*posts.Draft, error) {
+	draftList := make([]*posts.Draft, 0)
+	opts := options.Find().SetSort(bson.D{{Key: "updated", Value: -1}})
+	cur, err := d.collection.Find(ctx, bson.M{"author.id": authorID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &draftList); err != nil {
+		return nil, err
+	}
+
+	return draftList, nil
+}
+
+func (d *DraftRepoMongoDB) UpdateDraft(ctx context.Context, draft *posts.Draft) error {
+	source := "UpdateDraft"
+	filter := bson.M{"uuid": draft.ID}
+	update := bson.M{"$set": bson.M{"post": draft.Post, "updated": draft.Updated}}
+	matchedCount, err := d.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return errors.Wrap(errs.ErrDraftNotFound, source)
+	}
+
+	return nil
+}
+
+func (d *DraftRepoMongoDB) DeleteDraft(ctx context.Context, draftID users.ID) error {
+	deletedCount, err := d.collection.DeleteOne(ctx, bson.M{"uuid": draftID})
+	if err != nil {
+		return err
+	}
+	if deletedCount == 0 {
+		return errs.ErrDraftNotFound
+	}
+
+	return nil
+}
+
+// ClaimDraft removes the draft and returns it. Only one of the concurrent callers gets the draft
+func (d *DraftRepoMongoDB) ClaimDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) {
+	res := d.collection.FindOneAndDelete(ctx, bson.M{"uuid": draftID})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, errs.ErrDraftNotFound
+	}
+
+	draft := new(posts.Draft)
+	if err := res.Decode(draft); err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
@@ -0,0 +1,79 @@
+package inmem
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+func (p *PostRepo) UpvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, post, commentID, (*posts.PostComment).Upvote)
+}
+
+func (p *PostRepo) DownvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	return p.voteComment(ctx, post, commentID, (*posts.PostComment).Downvote)
+}
+
+func (p *PostRepo) voteComment(
+	ctx context.Context,
+	post *posts.Post,
+	commentID users.ID,
+	vote func(*posts.PostComment, users.ID) (*posts.PostVote, bool),
+) (*posts.Post, error) {
+	source := "voteComment"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = comment.CheckVotable(); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	vote(comment, author.ID)
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) UnvoteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
+	source := "UnvoteComment"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	comment, err := post.GetComment(commentID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if err = comment.Unvote(author.ID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error) { //nolint:unparam
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	postList := make([]*posts.Post, 0, len(p.storage))
+	comments := make([]*posts.PostComment, 0)
+	for _, post := range p.storage {
+		postList = append(postList, post)
+		comments = append(comments, post.Comments...)
+	}
+
+	return posts.Karma(userLogin, postList, comments), nil
+}
@@ -0,0 +1,47 @@
+package inmem
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+func (p *PostRepo) GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error) { //nolint:unparam
+	source := "GetComments"
+	post, err := p.getPostByID(postID)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	page, err := post.CommentPage(query)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return page, nil
+}
+
+// LoadCommentThread has nothing to load, the stored posts carry all their comments
+func (p *PostRepo) LoadCommentThread(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) { //nolint:unparam
+	return post, nil
+}
+
+func (p *PostRepo) GetCommentsByIDs(ctx context.Context, commentIDs []users.ID) ([]*posts.PostComment, error) { //nolint:unparam
+	comments := make([]*posts.PostComment, 0, len(commentIDs))
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, post := range p.storage {
+		for _, commentID := range commentIDs {
+			if comment, err := post.GetComment(commentID); err == nil {
+				comments = append(comments, comment)
+			}
+		}
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,114 @@
+package inmem
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type CommunityRepo struct {
+	storage map[string]*communities.Community
+	mu      *sync.RWMutex
+}
+
+func NewCommunityRepo() *CommunityRepo {
+	repo := &CommunityRepo{
+		storage: make(map[string]*communities.Community),
+		mu:      &sync.RWMutex{},
+	}
+
+	admin := jwt.TokenPayload{
+		Login: "admin",
+		ID:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
+	}
+	for _, category := range []posts.PostCategory{
+		posts.Music,
+		posts.Funny,
+		posts.Videos,
+		posts.Programming,
+		posts.News,
+		posts.Fashion,
+	} {
+		community := communities.NewCommunity(admin, communities.CommunityPayload{Name: category.String()})
+		repo.storage[community.Name] = community
+	}
+
+	return repo
+}
+
+func (repo *CommunityRepo) GetAllCommunities(ctx context.Context) ([]*communities.Community, error) { //nolint:unparam
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	communityList := make([]*communities.Community, 0, len(repo.storage))
+	for _, community := range repo.storage {
+		communityList = append(communityList, community)
+	}
+	slices.SortFunc(communityList, func(a, b *communities.Community) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return communityList, nil
+}
+
+func (repo *CommunityRepo) GetCommunityByName(ctx context.Context, name string) (*communities.Community, error) { //nolint:unparam
+	source := "GetCommunityByName"
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	community, ok := repo.storage[name]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrCommunityNotFound, source)
+	}
+
+	return community, nil
+}
+
+func (repo *CommunityRepo) CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error) {
+	source := "CreateCommunity"
+	creator, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if _, ok = repo.storage[payload.Name]; ok {
+		return nil, errors.Wrap(errs.ErrCommunityExists, source)
+	}
+
+	newCommunity := communities.NewCommunity(*creator, payload)
+	repo.storage[newCommunity.Name] = newCommunity
+
+	return newCommunity, nil
+}
+
+func (repo *CommunityRepo) AddFlair(ctx context.Context, community *communities.Community, flair communities.Flair) (*communities.Community, error) { //nolint:unparam
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	community.Flairs = append(community.Flairs, flair)
+
+	return community, nil
+}
+
+func (repo *CommunityRepo) DeleteFlair(ctx context.Context, community *communities.Community, flairID users.ID) (*communities.Community, error) { //nolint:unparam
+	source := "DeleteFlair"
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	flairsBefore := len(community.Flairs)
+	community.Flairs = slices.DeleteFunc(community.Flairs, func(flair communities.Flair) bool {
+		return flair.ID == flairID
+	})
+	if flairsBefore == len(community.Flairs) {
+		return nil, errors.Wrap(errs.ErrInvalidFlair, source)
+	}
+
+	return community, nil
+}
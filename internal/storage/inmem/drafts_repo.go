@@ -0,0 +1,100 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type DraftRepo struct {
+	storage map[users.ID]posts.Draft
+	mu      *sync.RWMutex
+}
+
+func NewDraftRepo() *DraftRepo {
+	return &DraftRepo{
+		storage: make(map[users.ID]posts.Draft),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+func (d *DraftRepo) AddDraft(ctx context.Context, draft *posts.Draft) error { //nolint:unparam
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.storage[draft.ID] = *draft
+
+	return nil
+}
+
+func (d *DraftRepo) GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) { //nolint:unparam
+	source := "GetDraft"
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	draft, ok := d.storage[draftID]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrDraftNotFound, source)
+	}
+
+	return &draft, nil
+}
+
+// GetDrafts returns copies of the drafts of the author, the most recently saved first
+func (d *DraftRepo) GetDrafts(ctx context.Context, authorID users.ID) ([]*posts.Draft, error) { //nolint:unparam
+	d.mu.RLock()
+	draftList := make([]*posts.Draft, 0)
+	for _, draft := range d.storage {
+		if draft.Author.ID == authorID {
+			draftList = append(draftList, &draft)
+		}
+	}
+	d.mu.RUnlock()
+
+	slices.SortFunc(draftList, func(a, b *posts.Draft) int {
+		return b.Updated.Compare(a.Updated)
+	})
+
+	return draftList, nil
+}
+
+func (d *DraftRepo) UpdateDraft(ctx context.Context, draft *posts.Draft) error { //nolint:unparam
+	source := "UpdateDraft"
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.storage[draft.ID]; !ok {
+		return errors.Wrap(errs.ErrDraftNotFound, source)
+	}
+	d.storage[draft.ID] = *draft
+
+	return nil
+}
+
+func (d *DraftRepo) DeleteDraft(ctx context.Context, draftID users.ID) error { //nolint:unparam
+	source := "DeleteDraft"
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.storage[draftID]; !ok {
+		return errors.Wrap(errs.ErrDraftNotFound, source)
+	}
+	delete(d.storage, draftID)
+
+	return nil
+}
+
+func (d *DraftRepo) ClaimDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) { //nolint:unparam
+	source := "ClaimDraft"
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	draft, ok := d.storage[draftID]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrDraftNotFound, source)
+	}
+	delete(d.storage, draftID)
+
+	return &draft, nil
+}
@@ -0,0 +1,42 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type MentionRepo struct {
+	storage map[users.ID][]*posts.MentionEvent // Mentions of every user, the most recent first
+	mu      *sync.RWMutex
+}
+
+func NewMentionRepo() *MentionRepo {
+	return &MentionRepo{
+		storage: make(map[users.ID][]*posts.MentionEvent),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+func (m *MentionRepo) AddMentions(ctx context.Context, events []*posts.MentionEvent) error { //nolint:unparam
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, event := range events {
+		m.storage[event.UserID] = slices.Insert(m.storage[event.UserID], 0, event)
+	}
+
+	return nil
+}
+
+func (m *MentionRepo) GetMentions(ctx context.Context, userID users.ID, page posts.Page) ([]*posts.MentionEvent, error) { //nolint:unparam
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	events := m.storage[userID]
+	start := min(page.Offset, len(events))
+	end := min(start+page.Limit, len(events))
+
+	return slices.Clone(events[start:end]), nil
+}
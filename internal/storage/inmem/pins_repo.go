@@ -0,0 +1,44 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+type PinRepo struct {
+	storage map[posts.PostCategory]posts.Pins
+	mu      *sync.RWMutex
+}
+
+func NewPinRepo() *PinRepo {
+	return &PinRepo{
+		storage: make(map[posts.PostCategory]posts.Pins),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+func (p *PinRepo) GetPins(ctx context.Context, scope posts.PostCategory) (posts.Pins, error) { //nolint:unparam
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Clone(p.storage[scope]), nil
+}
+
+func (p *PinRepo) UpdatePins(ctx context.Context, scope posts.PostCategory, update func(pins posts.Pins) (posts.Pins, error)) error { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pins, err := update(slices.Clone(p.storage[scope]))
+	if err != nil {
+		return err
+	}
+	if len(pins) > posts.MaxPins {
+		return errs.ErrTooManyPins
+	}
+	p.storage[scope] = slices.Clone(pins)
+
+	return nil
+}
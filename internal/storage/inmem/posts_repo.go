@@ -5,6 +5,7 @@ import (
 	"context"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -16,33 +17,39 @@ import (
 
 type PostRepo struct {
 	storage []*posts.Post
+	index   *searchIndex
 	mu      *sync.RWMutex
 }
 
 func NewPostRepo() *PostRepo {
 	return &PostRepo{
 		storage: make([]*posts.Post, 0),
+		index:   newSearchIndex(),
 		mu:      &sync.RWMutex{},
 	}
 }
 
-func (p *PostRepo) GetAllPosts(ctx context.Context) ([]*posts.Post, error) { //nolint:unparam
+func (p *PostRepo) GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error) { //nolint:unparam
 	postList := make([]*posts.Post, 0, len(p.storage))
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	viewer := viewerID(ctx)
 	for _, post := range p.storage {
-		postList = append(postList, &(*post))
+		if !post.IsHiddenBy(viewer) && query.Matches(post) {
+			postList = append(postList, &(*post))
+		}
 	}
 
 	return postList, nil
 }
 
-func (p *PostRepo) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error) { //nolint:unparam
-	postList := make([]*posts.Post, 0, len(p.storage)/posts.CategoryCount)
+func (p *PostRepo) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error) { //nolint:unparam
+	postList := make([]*posts.Post, 0)
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	viewer := viewerID(ctx)
 	for _, post := range p.storage {
-		if post.Category == postCategory {
+		if post.Category == postCategory && !post.IsHiddenBy(viewer) && query.Matches(post) {
 			postList = append(postList, &(*post))
 		}
 	}
@@ -50,12 +57,26 @@ func (p *PostRepo) GetPostsByCategory(ctx context.Context, postCategory posts.Po
 	return postList, nil
 }
 
-func (p *PostRepo) GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error) { //nolint:unparam
+func (p *PostRepo) GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error) { //nolint:unparam
 	postList := make([]*posts.Post, 0)
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	viewer := viewerID(ctx)
 	for _, post := range p.storage {
-		if post.Author.Login == userLogin {
+		if post.Author.Login == userLogin && !post.IsHiddenBy(viewer) && query.Matches(post) {
+			postList = append(postList, &(*post))
+		}
+	}
+
+	return postList, nil
+}
+
+func (p *PostRepo) GetPostsByURL(ctx context.Context, canonicalURL string) ([]*posts.Post, error) { //nolint:unparam
+	postList := make([]*posts.Post, 0)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, post := range p.storage {
+		if post.CanonicalURL == canonicalURL {
 			postList = append(postList, &(*post))
 		}
 	}
@@ -70,16 +91,20 @@ func (p *PostRepo) GetPostByID(ctx context.Context, postID users.ID) (*posts.Pos
 		return nil, errors.Wrap(err, source)
 	}
 
-	//original
-	//return &(*post.UpdateViews()), nil
+	return post, nil
+}
 
-	// fix
-	//pst := &(*post)
-	//post.UpdateViews()
-	//return pst, nil
+func (p *PostRepo) GetPostsByIDs(ctx context.Context, postIDs []users.ID) ([]*posts.Post, error) { //nolint:unparam
+	postList := make([]*posts.Post, 0, len(postIDs))
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, post := range p.storage {
+		if slices.Contains(postIDs, post.ID) {
+			postList = append(postList, &(*post))
+		}
+	}
 
-	// another fix
-	return post, nil
+	return postList, nil
 }
 
 func (p *PostRepo) CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
@@ -91,6 +116,7 @@ func (p *PostRepo) CreatePost(ctx context.Context, postPayload posts.PostPayload
 	defer p.sortPosts()
 
 	newPost := posts.NewPost(*author, postPayload)
+	p.index.index(newPost)
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.storage = append(p.storage, newPost)
@@ -108,6 +134,50 @@ func (p *PostRepo) DeletePost(ctx context.Context, postID users.ID) error { //no
 	if lenBeforeDelete == len(p.storage) {
 		return errs.ErrPostNotFound
 	}
+	p.index.unindex(postID)
+
+	return nil
+}
+
+func (p *PostRepo) CreateCrosspost(ctx context.Context, parent *posts.Post, payload posts.CrosspostPayload) (*posts.Post, error) {
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	defer p.sortPosts()
+
+	crosspost := posts.NewCrosspost(*author, parent, payload)
+	p.index.index(crosspost)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.storage = append(p.storage, crosspost)
+	parent.AddCrosspost(crosspost)
+
+	return &(*crosspost), nil
+}
+
+func (p *PostRepo) RemoveCrosspostSource(ctx context.Context, parentID users.ID) error { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, post := range p.storage {
+		if post.CrosspostParent != nil && post.CrosspostParent.ID == parentID {
+			post.RemoveSource()
+		}
+	}
+
+	return nil
+}
+
+func (p *PostRepo) RemoveCrosspostRef(ctx context.Context, parentID, crosspostID users.ID) error { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	postIdx := slices.IndexFunc(p.storage, func(post *posts.Post) bool {
+		return post.ID == parentID
+	})
+	if postIdx != -1 {
+		p.storage[postIdx].RemoveCrosspost(crosspostID)
+	}
 
 	return nil
 }
@@ -129,13 +199,16 @@ func (p *PostRepo) DeletePost(ctx context.Context, postID users.ID) error { //no
 //}
 
 func (p *PostRepo) AddComment(ctx context.Context, post *posts.Post, comment posts.Comment) (*posts.Post, error) {
-	//source := "AddComment"
+	source := "AddComment"
 	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
 	if !ok {
 		return nil, errs.ErrBadPayload
 	}
 
-	post.AddComment(*author, comment.Body)
+	if _, err := post.AddComment(*author, comment); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	p.index.index(post)
 
 	return &(*post), nil
 }
@@ -155,9 +228,24 @@ func (p *PostRepo) AddComment(ctx context.Context, post *posts.Post, comment pos
 
 func (p *PostRepo) DeleteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) { //nolint:unparam
 	source := "DeleteComment"
-	if err := post.DeleteComment(commentID); err != nil {
+	if _, err := post.DeleteComment(commentID); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	p.index.index(post)
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) EditComment(ctx context.Context, post *posts.Post, commentID users.ID, comment posts.Comment) (*posts.Post, error) { //nolint:unparam
+	source := "EditComment"
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	edited, err := post.GetComment(commentID)
+	if err != nil {
 		return nil, errors.Wrap(err, source)
 	}
+	edited.Edit(comment, posts.Now())
+	p.index.index(post)
 
 	return &(*post), nil
 }
@@ -257,6 +345,115 @@ func (p *PostRepo) Unvote(ctx context.Context, post *posts.Post) (*posts.Post, e
 	return &(*post), nil
 }
 
+func (p *PostRepo) CastBallot(ctx context.Context, post *posts.Post, choice []int) (*posts.Post, error) {
+	source := "CastBallot"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+	if post.Poll == nil {
+		return nil, errors.Wrap(errs.ErrNotAPoll, source)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := post.Poll.Cast(author.ID, choice, time.Now()); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) HidePost(ctx context.Context, post *posts.Post) (*posts.Post, error) {
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post.Hide(user.ID)
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) UnhidePost(ctx context.Context, post *posts.Post) (*posts.Post, error) {
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post.Unhide(user.ID)
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error) {
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	postList := make([]*posts.Post, 0)
+	p.mu.RLock()
+	for _, post := range p.storage {
+		if post.IsHiddenBy(user.ID) {
+			postList = append(postList, &(*post))
+		}
+	}
+	p.mu.RUnlock()
+	slices.SortStableFunc(postList, func(a, b *posts.Post) int {
+		return b.Created.Compare(a.Created)
+	})
+
+	return page.Apply(postList), nil
+}
+
+func (p *PostRepo) SetFlair(ctx context.Context, post *posts.Post, flair *posts.Flair) (*posts.Post, error) { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post.Flair = flair
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) SetFlags(ctx context.Context, post *posts.Post, flags posts.FlagsPayload) (*posts.Post, error) { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post.NSFW, post.Spoiler = flags.NSFW, flags.Spoiler
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) SetLock(ctx context.Context, post *posts.Post, locked, votesFrozen bool) (*posts.Post, error) { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post.Locked, post.VotesFrozen = locked, votesFrozen
+
+	return &(*post), nil
+}
+
+func (p *PostRepo) SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error) { //nolint:unparam
+	relevance := p.index.search(query.Text)
+	postList := make([]*posts.Post, 0, len(relevance))
+	viewer := viewerID(ctx)
+	p.mu.RLock()
+	for _, post := range p.storage {
+		if _, ok := relevance[post.ID]; ok && !post.IsHiddenBy(viewer) && query.Matches(post) {
+			postList = append(postList, post)
+		}
+	}
+	p.mu.RUnlock()
+
+	slices.SortStableFunc(postList, func(a, b *posts.Post) int {
+		return -cmp.Compare(relevance[a.ID], relevance[b.ID])
+	})
+
+	return query.Page.Apply(postList), nil
+}
+
 func (p *PostRepo) getPostByID(postID users.ID) (*posts.Post, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -270,11 +467,39 @@ func (p *PostRepo) getPostByID(postID users.ID) (*posts.Post, error) {
 	return &(*p.storage[postIdx]), nil
 }
 
-func (p *PostRepo) UpdateViews(ctx context.Context, postID users.ID) error { //nolint:unparam
-	// stub
+func (p *PostRepo) AddViews(ctx context.Context, views map[users.ID]uint) error { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, post := range p.storage {
+		post.Views += views[post.ID]
+	}
+
+	return nil
+}
+
+func (p *PostRepo) SetLinkPreview(ctx context.Context, postID users.ID, preview *posts.LinkPreview) error { //nolint:unparam
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	postIdx := slices.IndexFunc(p.storage, func(post *posts.Post) bool {
+		return post.ID == postID
+	})
+	if postIdx == -1 {
+		return errs.ErrPostNotFound
+	}
+	p.storage[postIdx].Preview = preview
+
 	return nil
 }
 
+// viewerID returns the id of the user the request is made by, if the request is authenticated
+func viewerID(ctx context.Context) users.ID {
+	if viewer, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload); ok {
+		return viewer.ID
+	}
+
+	return ""
+}
+
 func (p *PostRepo) sortPosts() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
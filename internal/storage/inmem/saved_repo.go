@@ -0,0 +1,101 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type SavedRepo struct {
+	storage map[users.ID][]posts.SavedItem // Saved items of every user, the most recently saved first
+	mu      *sync.RWMutex
+}
+
+func NewSavedRepo() *SavedRepo {
+	return &SavedRepo{
+		storage: make(map[users.ID][]posts.SavedItem),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+func (s *SavedRepo) SaveItem(ctx context.Context, item posts.SavedItem) error { //nolint:unparam
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := s.storage[item.UserID]
+	if slices.ContainsFunc(items, func(saved posts.SavedItem) bool {
+		return saved.ItemID == item.ItemID
+	}) {
+		return nil
+	}
+
+	// Items saved within the same millisecond keep the newest first as well
+	idx := slices.IndexFunc(items, func(saved posts.SavedItem) bool {
+		return !saved.Saved.After(item.Saved)
+	})
+	if idx == -1 {
+		idx = len(items)
+	}
+	s.storage[item.UserID] = slices.Insert(items, idx, item)
+
+	return nil
+}
+
+func (s *SavedRepo) UnsaveItem(ctx context.Context, userID, itemID users.ID) error { //nolint:unparam
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage[userID] = slices.DeleteFunc(s.storage[userID], func(saved posts.SavedItem) bool {
+		return saved.ItemID == itemID
+	})
+
+	return nil
+}
+
+func (s *SavedRepo) GetSavedItems(ctx context.Context, userID users.ID, query posts.SavedQuery) ([]posts.SavedItem, error) { //nolint:unparam
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]posts.SavedItem, 0)
+	skipped := 0
+	for _, item := range s.storage[userID] {
+		if query.Type != "" && item.Type != query.Type {
+			continue
+		}
+		if skipped < query.Offset {
+			skipped++
+			continue
+		}
+		if len(items) == query.Limit {
+			break
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (s *SavedRepo) GetSavedIDs(ctx context.Context, userID users.ID, itemIDs []users.ID) (posts.SavedSet, error) { //nolint:unparam
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	saved := make(posts.SavedSet)
+	for _, item := range s.storage[userID] {
+		if slices.Contains(itemIDs, item.ItemID) {
+			saved[item.ItemID] = struct{}{}
+		}
+	}
+
+	return saved, nil
+}
+
+func (s *SavedRepo) DeleteSavedItems(ctx context.Context, itemID users.ID) error { //nolint:unparam
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for userID, items := range s.storage {
+		s.storage[userID] = slices.DeleteFunc(items, func(saved posts.SavedItem) bool {
+			return saved.ItemID == itemID || saved.PostID == itemID
+		})
+	}
+
+	return nil
+}
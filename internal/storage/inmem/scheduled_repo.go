@@ -0,0 +1,118 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type ScheduledRepo struct {
+	storage map[users.ID]posts.ScheduledPost
+	mu      *sync.RWMutex
+}
+
+func NewScheduledRepo() *ScheduledRepo {
+	return &ScheduledRepo{
+		storage: make(map[users.ID]posts.ScheduledPost),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+func (s *ScheduledRepo) AddScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error { //nolint:unparam
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage[scheduled.ID] = *scheduled
+
+	return nil
+}
+
+func (s *ScheduledRepo) GetScheduledPost(ctx context.Context, scheduledID users.ID) (*posts.ScheduledPost, error) { //nolint:unparam
+	source := "GetScheduledPost"
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	scheduled, ok := s.storage[scheduledID]
+	if !ok {
+		return nil, errors.Wrap(errs.ErrScheduledPostNotFound, source)
+	}
+
+	return &scheduled, nil
+}
+
+func (s *ScheduledRepo) GetScheduledPosts(ctx context.Context, authorID users.ID) ([]*posts.ScheduledPost, error) { //nolint:unparam
+	return s.filter(func(scheduled posts.ScheduledPost) bool {
+		return scheduled.Author.ID == authorID
+	}, -1), nil
+}
+
+func (s *ScheduledRepo) GetDueScheduledPosts(ctx context.Context, now time.Time, limit int) ([]*posts.ScheduledPost, error) { //nolint:unparam
+	return s.filter(func(scheduled posts.ScheduledPost) bool {
+		return !scheduled.PublishAt.After(now) && scheduled.Claimable(now)
+	}, limit), nil
+}
+
+func (s *ScheduledRepo) UpdateScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error { //nolint:unparam
+	source := "UpdateScheduledPost"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.storage[scheduled.ID]; !ok {
+		return errors.Wrap(errs.ErrScheduledPostNotFound, source)
+	}
+	s.storage[scheduled.ID] = *scheduled
+
+	return nil
+}
+
+func (s *ScheduledRepo) DeleteScheduledPost(ctx context.Context, scheduledID users.ID) error { //nolint:unparam
+	source := "DeleteScheduledPost"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.storage[scheduledID]; !ok {
+		return errors.Wrap(errs.ErrScheduledPostNotFound, source)
+	}
+	delete(s.storage, scheduledID)
+
+	return nil
+}
+
+func (s *ScheduledRepo) ClaimScheduledPost(ctx context.Context, scheduledID users.ID, now, until time.Time) (*posts.ScheduledPost, error) { //nolint:unparam
+	source := "ClaimScheduledPost"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scheduled, ok := s.storage[scheduledID]
+	if !ok || !scheduled.Claimable(now) {
+		return nil, errors.Wrap(errs.ErrScheduledPostNotFound, source)
+	}
+	scheduled.LockedUntil = until
+	scheduled.Attempts++
+	s.storage[scheduledID] = scheduled
+
+	return &scheduled, nil
+}
+
+// filter returns copies of the matching posts, the earliest to be published first. A negative limit means no limit
+func (s *ScheduledRepo) filter(match func(scheduled posts.ScheduledPost) bool, limit int) []*posts.ScheduledPost {
+	s.mu.RLock()
+	scheduledList := make([]*posts.ScheduledPost, 0)
+	for _, scheduled := range s.storage {
+		if match(scheduled) {
+			scheduledList = append(scheduledList, &scheduled)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(scheduledList, func(a, b *posts.ScheduledPost) int {
+		return a.PublishAt.Compare(b.PublishAt)
+	})
+	if limit >= 0 && len(scheduledList) > limit {
+		scheduledList = scheduledList[:limit]
+	}
+
+	return scheduledList
+}
@@ -0,0 +1,97 @@
+package inmem
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// Weights of the terms found in the different parts of the post
+const (
+	titleWeight   = 10
+	textWeight    = 5
+	commentWeight = 1
+)
+
+// searchIndex is an inverted index of the words used in posts and their comments
+type searchIndex struct {
+	postings map[string]map[users.ID]int // term -> post -> weighted frequency
+	terms    map[users.ID][]string       // post -> indexed terms, needed to unindex the post
+	mu       *sync.RWMutex
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings: make(map[string]map[users.ID]int),
+		terms:    make(map[users.ID][]string),
+		mu:       &sync.RWMutex{},
+	}
+}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// index (re)indexes the post, so it is also used after the post has been edited
+func (idx *searchIndex) index(post *posts.Post) {
+	weights := make(map[string]int)
+	for _, term := range tokenize(post.Title) {
+		weights[term] += titleWeight
+	}
+	for _, term := range tokenize(post.Text) {
+		weights[term] += textWeight
+	}
+	for _, comment := range post.Comments {
+		for _, term := range tokenize(comment.Body) {
+			weights[term] += commentWeight
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.unindexLocked(post.ID)
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		if _, ok := idx.postings[term]; !ok {
+			idx.postings[term] = make(map[users.ID]int)
+		}
+		idx.postings[term][post.ID] = weight
+		terms = append(terms, term)
+	}
+	idx.terms[post.ID] = terms
+}
+
+func (idx *searchIndex) unindex(postID users.ID) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.unindexLocked(postID)
+}
+
+func (idx *searchIndex) unindexLocked(postID users.ID) {
+	for _, term := range idx.terms[postID] {
+		delete(idx.postings[term], postID)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.terms, postID)
+}
+
+// search returns the relevance of every post containing at least one of the words of the text
+func (idx *searchIndex) search(text string) map[users.ID]int {
+	relevance := make(map[users.ID]int)
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	for _, term := range tokenize(text) {
+		for postID, weight := range idx.postings[term] {
+			relevance[postID] += weight
+		}
+	}
+
+	return relevance
+}
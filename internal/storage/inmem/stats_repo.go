@@ -0,0 +1,85 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type StatsRepo struct {
+	buckets   map[users.ID][]*posts.StatsBucket // Buckets of every post, the oldest first
+	retention time.Duration
+	mu        *sync.RWMutex
+}
+
+// NewStatsRepo creates the repository keeping the buckets for the retention, forever if it is zero
+func NewStatsRepo(retention time.Duration) *StatsRepo {
+	return &StatsRepo{
+		buckets:   make(map[users.ID][]*posts.StatsBucket),
+		retention: retention,
+		mu:        &sync.RWMutex{},
+	}
+}
+
+func (s *StatsRepo) AddStats(ctx context.Context, buckets []*posts.StatsBucket) error { //nolint:unparam
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, bucket := range buckets {
+		stored := s.buckets[bucket.PostID]
+		idx := slices.IndexFunc(stored, func(b *posts.StatsBucket) bool {
+			return b.Start.Equal(bucket.Start)
+		})
+		if idx == -1 {
+			added := *bucket
+			stored = append(stored, &added)
+			slices.SortFunc(stored, func(a, b *posts.StatsBucket) int {
+				return a.Start.Compare(b.Start)
+			})
+		} else {
+			stored[idx].Add(bucket.StatsCounts)
+		}
+		s.buckets[bucket.PostID] = stored
+	}
+	s.purge(time.Now())
+
+	return nil
+}
+
+func (s *StatsRepo) GetStats(ctx context.Context, postID users.ID, from, to time.Time) ([]*posts.StatsBucket, error) { //nolint:unparam
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.retention > 0 && from.Before(time.Now().Add(-s.retention)) {
+		from = time.Now().Add(-s.retention)
+	}
+
+	buckets := make([]*posts.StatsBucket, 0)
+	for _, bucket := range s.buckets[postID] {
+		if !bucket.Start.Before(from) && !bucket.Start.After(to) {
+			found := *bucket
+			buckets = append(buckets, &found)
+		}
+	}
+
+	return buckets, nil
+}
+
+// purge drops the buckets older than the retention
+func (s *StatsRepo) purge(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	for postID, stored := range s.buckets {
+		stored = slices.DeleteFunc(stored, func(b *posts.StatsBucket) bool {
+			return now.Sub(b.Start) > s.retention
+		})
+		if len(stored) == 0 {
+			delete(s.buckets, postID)
+			continue
+		}
+		s.buckets[postID] = stored
+	}
+}
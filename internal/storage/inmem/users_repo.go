@@ -2,6 +2,7 @@ package inmem
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -11,14 +12,16 @@ import (
 )
 
 type UserRepo struct {
-	storage map[users.Username]*users.User
-	mu      *sync.RWMutex
+	storage     map[users.Username]*users.User
+	preferences map[users.ID]users.Preferences
+	mu          *sync.RWMutex
 }
 
 func NewUserRepo() *UserRepo {
 	return &UserRepo{
-		storage: make(map[users.Username]*users.User, 42),
-		mu:      &sync.RWMutex{},
+		storage:     make(map[users.Username]*users.User, 42),
+		preferences: make(map[users.ID]users.Preferences),
+		mu:          &sync.RWMutex{},
 	}
 }
 
@@ -60,3 +63,38 @@ func (repo *UserRepo) createUser(authData users.AuthUserInfo) *users.User {
 
 	return newUser
 }
+
+// GetUsersByLogins returns the users with the logins in any case, the passwords left out. Unknown logins are skipped
+func (repo *UserRepo) GetUsersByLogins(ctx context.Context, logins []users.Username) ([]*users.User, error) { //nolint:unparam
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	// Matches the logins the way the case-insensitive collation of the MySQL table does
+	wanted := make(map[string]struct{}, len(logins))
+	for _, login := range logins {
+		wanted[strings.ToLower(string(login))] = struct{}{}
+	}
+	userList := make([]*users.User, 0, len(logins))
+	for login, user := range repo.storage {
+		if _, ok := wanted[strings.ToLower(string(login))]; ok {
+			userList = append(userList, &users.User{ID: user.ID, Username: user.Username})
+		}
+	}
+
+	return userList, nil
+}
+
+func (repo *UserRepo) GetPreferences(ctx context.Context, userID users.ID) (users.Preferences, error) { //nolint:unparam
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return repo.preferences[userID], nil
+}
+
+func (repo *UserRepo) SetPreferences(ctx context.Context, userID users.ID, prefs users.Preferences) error { //nolint:unparam
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.preferences[userID] = prefs
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type viewerSet struct {
+	viewers map[string]struct{}
+	expires time.Time
+}
+
+// ViewCounter keeps the exact sets of viewers of a single instance of the app
+type ViewCounter struct {
+	sets map[string]*viewerSet
+	mu   *sync.Mutex
+}
+
+func NewViewCounter() *ViewCounter {
+	return &ViewCounter{
+		sets: make(map[string]*viewerSet),
+		mu:   &sync.Mutex{},
+	}
+}
+
+func (c *ViewCounter) AddViewer(ctx context.Context, key, viewer string, ttl time.Duration) (bool, error) { //nolint:unparam
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	set, ok := c.sets[key]
+	if !ok || !now.Before(set.expires) {
+		c.purge(now)
+		set = &viewerSet{viewers: make(map[string]struct{}), expires: now.Add(ttl)}
+		c.sets[key] = set
+	}
+	if _, seen := set.viewers[viewer]; seen {
+		return false, nil
+	}
+	set.viewers[viewer] = struct{}{}
+
+	return true, nil
+}
+
+// purge drops the sets of the windows that are over
+func (c *ViewCounter) purge(now time.Time) {
+	for key, set := range c.sets {
+		if !now.Before(set.expires) {
+			delete(c.sets, key)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type MentionRepoMongoDB struct {
+	collection AbstractCollection
+}
+
+func NewMentionRepoMongoDB(collection AbstractCollection) *MentionRepoMongoDB {
+	return &MentionRepoMongoDB{
+		collection: collection,
+	}
+}
+
+// CreateIndexes creates the indexes the repository relies on. It is safe to call it on every start of the app
+func (m *MentionRepoMongoDB) CreateIndexes(ctx context.Context) error {
+	source := "CreateIndexes"
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "user", Value: 1}, {Key: "created", Value: -1}},
+		Options: options.Index().SetName("mentions_user"),
+	}
+	if _, err := m.collection.CreateIndex(ctx, index); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+func (m *MentionRepoMongoDB) AddMentions(ctx context.Context, events []*posts.MentionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, mongo.NewInsertOneModel().SetDocument(event))
+	}
+	_, err := m.collection.BulkWrite(ctx, models)
+
+	return err
+}
+
+// GetMentions returns the page of the mentions of the user, the most recent first
+func (m *MentionRepoMongoDB) GetMentions(ctx context.Context, userID users.ID, page posts.Page) ([]*posts.MentionEvent, error) {
+	events := make([]*posts.MentionEvent, 0)
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created", Value: -1}}).
+		SetSkip(int64(page.Offset)).
+		SetLimit(int64(page.Limit))
+	cur, err := m.collection.Find(ctx, bson.M{"user": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
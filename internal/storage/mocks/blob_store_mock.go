@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: media.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	media "github.com/Benzogang-Tape/Reddit/internal/models/media"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaAPI is a mock of MediaAPI interface.
+type MockMediaAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaAPIMockRecorder
+}
+
+// MockMediaAPIMockRecorder is the mock recorder for MockMediaAPI.
+type MockMediaAPIMockRecorder struct {
+	mock *MockMediaAPI
+}
+
+// NewMockMediaAPI creates a new mock instance.
+func NewMockMediaAPI(ctrl *gomock.Controller) *MockMediaAPI {
+	mock := &MockMediaAPI{ctrl: ctrl}
+	mock.recorder = &MockMediaAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaAPI) EXPECT() *MockMediaAPIMockRecorder {
+	return m.recorder
+}
+
+// GetBlob mocks base method.
+func (m *MockMediaAPI) GetBlob(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlob", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*media.BlobInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlob indicates an expected call of GetBlob.
+func (mr *MockMediaAPIMockRecorder) GetBlob(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlob", reflect.TypeOf((*MockMediaAPI)(nil).GetBlob), ctx, key)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: community.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	communities "github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	users "github.com/Benzogang-Tape/Reddit/internal/models/users"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommunityAPI is a mock of CommunityAPI interface.
+type MockCommunityAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommunityAPIMockRecorder
+}
+
+// MockCommunityAPIMockRecorder is the mock recorder for MockCommunityAPI.
+type MockCommunityAPIMockRecorder struct {
+	mock *MockCommunityAPI
+}
+
+// NewMockCommunityAPI creates a new mock instance.
+func NewMockCommunityAPI(ctrl *gomock.Controller) *MockCommunityAPI {
+	mock := &MockCommunityAPI{ctrl: ctrl}
+	mock.recorder = &MockCommunityAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommunityAPI) EXPECT() *MockCommunityAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateCommunity mocks base method.
+func (m *MockCommunityAPI) CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCommunity", ctx, payload)
+	ret0, _ := ret[0].(*communities.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCommunity indicates an expected call of CreateCommunity.
+func (mr *MockCommunityAPIMockRecorder) CreateCommunity(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommunity", reflect.TypeOf((*MockCommunityAPI)(nil).CreateCommunity), ctx, payload)
+}
+
+// CreateFlair mocks base method.
+func (m *MockCommunityAPI) CreateFlair(ctx context.Context, name string, payload communities.FlairPayload) (*communities.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFlair", ctx, name, payload)
+	ret0, _ := ret[0].(*communities.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFlair indicates an expected call of CreateFlair.
+func (mr *MockCommunityAPIMockRecorder) CreateFlair(ctx, name, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlair", reflect.TypeOf((*MockCommunityAPI)(nil).CreateFlair), ctx, name, payload)
+}
+
+// DeleteFlair mocks base method.
+func (m *MockCommunityAPI) DeleteFlair(ctx context.Context, name string, flairID users.ID) (*communities.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlair", ctx, name, flairID)
+	ret0, _ := ret[0].(*communities.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFlair indicates an expected call of DeleteFlair.
+func (mr *MockCommunityAPIMockRecorder) DeleteFlair(ctx, name, flairID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlair", reflect.TypeOf((*MockCommunityAPI)(nil).DeleteFlair), ctx, name, flairID)
+}
+
+// GetAllCommunities mocks base method.
+func (m *MockCommunityAPI) GetAllCommunities(ctx context.Context) ([]*communities.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCommunities", ctx)
+	ret0, _ := ret[0].([]*communities.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCommunities indicates an expected call of GetAllCommunities.
+func (mr *MockCommunityAPIMockRecorder) GetAllCommunities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCommunities", reflect.TypeOf((*MockCommunityAPI)(nil).GetAllCommunities), ctx)
+}
+
+// GetCommunityByName mocks base method.
+func (m *MockCommunityAPI) GetCommunityByName(ctx context.Context, name string) (*communities.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommunityByName", ctx, name)
+	ret0, _ := ret[0].(*communities.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommunityByName indicates an expected call of GetCommunityByName.
+func (mr *MockCommunityAPIMockRecorder) GetCommunityByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommunityByName", reflect.TypeOf((*MockCommunityAPI)(nil).GetCommunityByName), ctx, name)
+}
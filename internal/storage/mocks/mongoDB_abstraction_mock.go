@@ -10,6 +10,7 @@ import (
 
 	storage "github.com/Benzogang-Tape/Reddit/internal/storage"
 	gomock "github.com/golang/mock/gomock"
+	mongo "go.mongodb.org/mongo-driver/mongo"
 	options "go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -36,6 +37,106 @@ func (m *MockAbstractCollection) EXPECT() *MockAbstractCollectionMockRecorder {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockAbstractCollection) Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (storage.AbstractCursor, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, pipeline}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Aggregate", varargs...)
+	ret0, _ := ret[0].(storage.AbstractCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockAbstractCollectionMockRecorder) Aggregate(ctx, pipeline interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, pipeline}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockAbstractCollection)(nil).Aggregate), varargs...)
+}
+
+// BulkWrite mocks base method.
+func (m *MockAbstractCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, models}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BulkWrite", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockAbstractCollectionMockRecorder) BulkWrite(ctx, models interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, models}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockAbstractCollection)(nil).BulkWrite), varargs...)
+}
+
+// CountDocuments mocks base method.
+func (m *MockAbstractCollection) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountDocuments", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDocuments indicates an expected call of CountDocuments.
+func (mr *MockAbstractCollectionMockRecorder) CountDocuments(ctx, filter interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDocuments", reflect.TypeOf((*MockAbstractCollection)(nil).CountDocuments), varargs...)
+}
+
+// CreateIndex mocks base method.
+func (m *MockAbstractCollection) CreateIndex(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, model}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateIndex", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockAbstractCollectionMockRecorder) CreateIndex(ctx, model interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, model}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockAbstractCollection)(nil).CreateIndex), varargs...)
+}
+
+// DeleteMany mocks base method.
+func (m *MockAbstractCollection) DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMany", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockAbstractCollectionMockRecorder) DeleteMany(ctx, filter interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockAbstractCollection)(nil).DeleteMany), varargs...)
+}
+
 // DeleteOne mocks base method.
 func (m *MockAbstractCollection) DeleteOne(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +157,20 @@ func (mr *MockAbstractCollectionMockRecorder) DeleteOne(ctx, filter interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOne", reflect.TypeOf((*MockAbstractCollection)(nil).DeleteOne), varargs...)
 }
 
+// DropIndex mocks base method.
+func (m *MockAbstractCollection) DropIndex(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropIndex", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropIndex indicates an expected call of DropIndex.
+func (mr *MockAbstractCollectionMockRecorder) DropIndex(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropIndex", reflect.TypeOf((*MockAbstractCollection)(nil).DropIndex), ctx, name)
+}
+
 // Find mocks base method.
 func (m *MockAbstractCollection) Find(ctx context.Context, filter any, opts ...*options.FindOptions) (storage.AbstractCursor, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +210,44 @@ func (mr *MockAbstractCollectionMockRecorder) FindOne(ctx, filter interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockAbstractCollection)(nil).FindOne), varargs...)
 }
 
+// FindOneAndDelete mocks base method.
+func (m *MockAbstractCollection) FindOneAndDelete(ctx context.Context, filter any, opts ...*options.FindOneAndDeleteOptions) storage.AbstractSingleResult {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAndDelete", varargs...)
+	ret0, _ := ret[0].(storage.AbstractSingleResult)
+	return ret0
+}
+
+// FindOneAndDelete indicates an expected call of FindOneAndDelete.
+func (mr *MockAbstractCollectionMockRecorder) FindOneAndDelete(ctx, filter interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndDelete", reflect.TypeOf((*MockAbstractCollection)(nil).FindOneAndDelete), varargs...)
+}
+
+// FindOneAndUpdate mocks base method.
+func (m *MockAbstractCollection) FindOneAndUpdate(ctx context.Context, filter, update any, opts ...*options.FindOneAndUpdateOptions) storage.AbstractSingleResult {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter, update}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAndUpdate", varargs...)
+	ret0, _ := ret[0].(storage.AbstractSingleResult)
+	return ret0
+}
+
+// FindOneAndUpdate indicates an expected call of FindOneAndUpdate.
+func (mr *MockAbstractCollectionMockRecorder) FindOneAndUpdate(ctx, filter, update interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter, update}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndUpdate", reflect.TypeOf((*MockAbstractCollection)(nil).FindOneAndUpdate), varargs...)
+}
+
 // InsertOne mocks base method.
 func (m *MockAbstractCollection) InsertOne(ctx context.Context, document any, opts ...*options.InsertOneOptions) (any, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +268,26 @@ func (mr *MockAbstractCollectionMockRecorder) InsertOne(ctx, document interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockAbstractCollection)(nil).InsertOne), varargs...)
 }
 
+// UpdateMany mocks base method.
+func (m *MockAbstractCollection) UpdateMany(ctx context.Context, filter, update any, opts ...*options.UpdateOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, filter, update}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMany", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockAbstractCollectionMockRecorder) UpdateMany(ctx, filter, update interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, filter, update}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockAbstractCollection)(nil).UpdateMany), varargs...)
+}
+
 // UpdateOne mocks base method.
 func (m *MockAbstractCollection) UpdateOne(ctx context.Context, filter, update any, opts ...*options.UpdateOptions) (int64, error) {
 	m.ctrl.T.Helper()
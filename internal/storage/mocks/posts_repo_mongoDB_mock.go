@@ -51,6 +51,65 @@ func (mr *MockPostAPIMockRecorder) AddComment(ctx, postID, comment interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockPostAPI)(nil).AddComment), ctx, postID, comment)
 }
 
+// CancelScheduledPost mocks base method.
+func (m *MockPostAPI) CancelScheduledPost(ctx context.Context, scheduledID users.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledPost", ctx, scheduledID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduledPost indicates an expected call of CancelScheduledPost.
+func (mr *MockPostAPIMockRecorder) CancelScheduledPost(ctx, scheduledID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledPost", reflect.TypeOf((*MockPostAPI)(nil).CancelScheduledPost), ctx, scheduledID)
+}
+
+// CastBallot mocks base method.
+func (m *MockPostAPI) CastBallot(ctx context.Context, postID users.ID, ballot posts.BallotPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CastBallot", ctx, postID, ballot)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CastBallot indicates an expected call of CastBallot.
+func (mr *MockPostAPIMockRecorder) CastBallot(ctx, postID, ballot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CastBallot", reflect.TypeOf((*MockPostAPI)(nil).CastBallot), ctx, postID, ballot)
+}
+
+// CreateDraft mocks base method.
+func (m *MockPostAPI) CreateDraft(ctx context.Context, postPayload posts.PostPayload) (*posts.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDraft", ctx, postPayload)
+	ret0, _ := ret[0].(*posts.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDraft indicates an expected call of CreateDraft.
+func (mr *MockPostAPIMockRecorder) CreateDraft(ctx, postPayload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDraft", reflect.TypeOf((*MockPostAPI)(nil).CreateDraft), ctx, postPayload)
+}
+
+// CreateImagePost mocks base method.
+func (m *MockPostAPI) CreateImagePost(ctx context.Context, postPayload posts.PostPayload, image []byte) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImagePost", ctx, postPayload, image)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImagePost indicates an expected call of CreateImagePost.
+func (mr *MockPostAPIMockRecorder) CreateImagePost(ctx, postPayload, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImagePost", reflect.TypeOf((*MockPostAPI)(nil).CreateImagePost), ctx, postPayload, image)
+}
+
 // CreatePost mocks base method.
 func (m *MockPostAPI) CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +125,21 @@ func (mr *MockPostAPIMockRecorder) CreatePost(ctx, postPayload interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockPostAPI)(nil).CreatePost), ctx, postPayload)
 }
 
+// Crosspost mocks base method.
+func (m *MockPostAPI) Crosspost(ctx context.Context, postID users.ID, payload posts.CrosspostPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Crosspost", ctx, postID, payload)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Crosspost indicates an expected call of Crosspost.
+func (mr *MockPostAPIMockRecorder) Crosspost(ctx, postID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Crosspost", reflect.TypeOf((*MockPostAPI)(nil).Crosspost), ctx, postID, payload)
+}
+
 // DeleteComment mocks base method.
 func (m *MockPostAPI) DeleteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +155,20 @@ func (mr *MockPostAPIMockRecorder) DeleteComment(ctx, postID, commentID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockPostAPI)(nil).DeleteComment), ctx, postID, commentID)
 }
 
+// DeleteDraft mocks base method.
+func (m *MockPostAPI) DeleteDraft(ctx context.Context, draftID users.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", ctx, draftID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockPostAPIMockRecorder) DeleteDraft(ctx, draftID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockPostAPI)(nil).DeleteDraft), ctx, draftID)
+}
+
 // DeletePost mocks base method.
 func (m *MockPostAPI) DeletePost(ctx context.Context, postID users.ID) error {
 	m.ctrl.T.Helper()
@@ -110,19 +198,169 @@ func (mr *MockPostAPIMockRecorder) Downvote(ctx, postID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Downvote", reflect.TypeOf((*MockPostAPI)(nil).Downvote), ctx, postID)
 }
 
+// DownvoteComment mocks base method.
+func (m *MockPostAPI) DownvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownvoteComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownvoteComment indicates an expected call of DownvoteComment.
+func (mr *MockPostAPIMockRecorder) DownvoteComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownvoteComment", reflect.TypeOf((*MockPostAPI)(nil).DownvoteComment), ctx, postID, commentID)
+}
+
+// EditComment mocks base method.
+func (m *MockPostAPI) EditComment(ctx context.Context, postID, commentID users.ID, comment posts.Comment) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditComment", ctx, postID, commentID, comment)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditComment indicates an expected call of EditComment.
+func (mr *MockPostAPIMockRecorder) EditComment(ctx, postID, commentID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditComment", reflect.TypeOf((*MockPostAPI)(nil).EditComment), ctx, postID, commentID, comment)
+}
+
 // GetAllPosts mocks base method.
-func (m *MockPostAPI) GetAllPosts(ctx context.Context) ([]*posts.Post, error) {
+func (m *MockPostAPI) GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPosts", ctx)
+	ret := m.ctrl.Call(m, "GetAllPosts", ctx, query)
 	ret0, _ := ret[0].([]*posts.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllPosts indicates an expected call of GetAllPosts.
-func (mr *MockPostAPIMockRecorder) GetAllPosts(ctx interface{}) *gomock.Call {
+func (mr *MockPostAPIMockRecorder) GetAllPosts(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPosts", reflect.TypeOf((*MockPostAPI)(nil).GetAllPosts), ctx, query)
+}
+
+// GetCommentRevisions mocks base method.
+func (m *MockPostAPI) GetCommentRevisions(ctx context.Context, postID, commentID users.ID) ([]*posts.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentRevisions", ctx, postID, commentID)
+	ret0, _ := ret[0].([]*posts.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentRevisions indicates an expected call of GetCommentRevisions.
+func (mr *MockPostAPIMockRecorder) GetCommentRevisions(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentRevisions", reflect.TypeOf((*MockPostAPI)(nil).GetCommentRevisions), ctx, postID, commentID)
+}
+
+// GetCommentThread mocks base method.
+func (m *MockPostAPI) GetCommentThread(ctx context.Context, postID, commentID users.ID) (*posts.PostComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentThread", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.PostComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentThread indicates an expected call of GetCommentThread.
+func (mr *MockPostAPIMockRecorder) GetCommentThread(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentThread", reflect.TypeOf((*MockPostAPI)(nil).GetCommentThread), ctx, postID, commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockPostAPI) GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, postID, query)
+	ret0, _ := ret[0].(*posts.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockPostAPIMockRecorder) GetComments(ctx, postID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockPostAPI)(nil).GetComments), ctx, postID, query)
+}
+
+// GetDraft mocks base method.
+func (m *MockPostAPI) GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, draftID)
+	ret0, _ := ret[0].(*posts.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockPostAPIMockRecorder) GetDraft(ctx, draftID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockPostAPI)(nil).GetDraft), ctx, draftID)
+}
+
+// GetDrafts mocks base method.
+func (m *MockPostAPI) GetDrafts(ctx context.Context) ([]*posts.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrafts", ctx)
+	ret0, _ := ret[0].([]*posts.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrafts indicates an expected call of GetDrafts.
+func (mr *MockPostAPIMockRecorder) GetDrafts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrafts", reflect.TypeOf((*MockPostAPI)(nil).GetDrafts), ctx)
+}
+
+// GetHiddenPosts mocks base method.
+func (m *MockPostAPI) GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHiddenPosts", ctx, page)
+	ret0, _ := ret[0].([]*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHiddenPosts indicates an expected call of GetHiddenPosts.
+func (mr *MockPostAPIMockRecorder) GetHiddenPosts(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHiddenPosts", reflect.TypeOf((*MockPostAPI)(nil).GetHiddenPosts), ctx, page)
+}
+
+// GetKarma mocks base method.
+func (m *MockPostAPI) GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKarma", ctx, userLogin)
+	ret0, _ := ret[0].(users.Karma)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKarma indicates an expected call of GetKarma.
+func (mr *MockPostAPIMockRecorder) GetKarma(ctx, userLogin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKarma", reflect.TypeOf((*MockPostAPI)(nil).GetKarma), ctx, userLogin)
+}
+
+// GetMentions mocks base method.
+func (m *MockPostAPI) GetMentions(ctx context.Context, page posts.Page) ([]*posts.MentionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", ctx, page)
+	ret0, _ := ret[0].([]*posts.MentionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockPostAPIMockRecorder) GetMentions(ctx, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPosts", reflect.TypeOf((*MockPostAPI)(nil).GetAllPosts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockPostAPI)(nil).GetMentions), ctx, page)
 }
 
 // GetPostByID mocks base method.
@@ -140,34 +378,334 @@ func (mr *MockPostAPIMockRecorder) GetPostByID(ctx, postID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByID", reflect.TypeOf((*MockPostAPI)(nil).GetPostByID), ctx, postID)
 }
 
+// GetPostStats mocks base method.
+func (m *MockPostAPI) GetPostStats(ctx context.Context, postID users.ID, query posts.StatsQuery) (*posts.PostStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostStats", ctx, postID, query)
+	ret0, _ := ret[0].(*posts.PostStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostStats indicates an expected call of GetPostStats.
+func (mr *MockPostAPIMockRecorder) GetPostStats(ctx, postID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostStats", reflect.TypeOf((*MockPostAPI)(nil).GetPostStats), ctx, postID, query)
+}
+
 // GetPostsByCategory mocks base method.
-func (m *MockPostAPI) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error) {
+func (m *MockPostAPI) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPostsByCategory", ctx, postCategory)
+	ret := m.ctrl.Call(m, "GetPostsByCategory", ctx, postCategory, query)
 	ret0, _ := ret[0].([]*posts.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPostsByCategory indicates an expected call of GetPostsByCategory.
-func (mr *MockPostAPIMockRecorder) GetPostsByCategory(ctx, postCategory interface{}) *gomock.Call {
+func (mr *MockPostAPIMockRecorder) GetPostsByCategory(ctx, postCategory, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByCategory", reflect.TypeOf((*MockPostAPI)(nil).GetPostsByCategory), ctx, postCategory, query)
+}
+
+// GetPostsByURL mocks base method.
+func (m *MockPostAPI) GetPostsByURL(ctx context.Context, rawURL string) ([]*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostsByURL", ctx, rawURL)
+	ret0, _ := ret[0].([]*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostsByURL indicates an expected call of GetPostsByURL.
+func (mr *MockPostAPIMockRecorder) GetPostsByURL(ctx, rawURL interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByCategory", reflect.TypeOf((*MockPostAPI)(nil).GetPostsByCategory), ctx, postCategory)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByURL", reflect.TypeOf((*MockPostAPI)(nil).GetPostsByURL), ctx, rawURL)
 }
 
 // GetPostsByUser mocks base method.
-func (m *MockPostAPI) GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error) {
+func (m *MockPostAPI) GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPostsByUser", ctx, userLogin)
+	ret := m.ctrl.Call(m, "GetPostsByUser", ctx, userLogin, query)
 	ret0, _ := ret[0].([]*posts.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPostsByUser indicates an expected call of GetPostsByUser.
-func (mr *MockPostAPIMockRecorder) GetPostsByUser(ctx, userLogin interface{}) *gomock.Call {
+func (mr *MockPostAPIMockRecorder) GetPostsByUser(ctx, userLogin, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByUser", reflect.TypeOf((*MockPostAPI)(nil).GetPostsByUser), ctx, userLogin, query)
+}
+
+// GetPreferences mocks base method.
+func (m *MockPostAPI) GetPreferences(ctx context.Context) (users.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx)
+	ret0, _ := ret[0].(users.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPostAPIMockRecorder) GetPreferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPostAPI)(nil).GetPreferences), ctx)
+}
+
+// GetSaved mocks base method.
+func (m *MockPostAPI) GetSaved(ctx context.Context, query posts.SavedQuery) ([]*posts.SavedEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSaved", ctx, query)
+	ret0, _ := ret[0].([]*posts.SavedEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSaved indicates an expected call of GetSaved.
+func (mr *MockPostAPIMockRecorder) GetSaved(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostsByUser", reflect.TypeOf((*MockPostAPI)(nil).GetPostsByUser), ctx, userLogin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSaved", reflect.TypeOf((*MockPostAPI)(nil).GetSaved), ctx, query)
+}
+
+// GetScheduledPosts mocks base method.
+func (m *MockPostAPI) GetScheduledPosts(ctx context.Context) ([]*posts.ScheduledPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledPosts", ctx)
+	ret0, _ := ret[0].([]*posts.ScheduledPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledPosts indicates an expected call of GetScheduledPosts.
+func (mr *MockPostAPIMockRecorder) GetScheduledPosts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledPosts", reflect.TypeOf((*MockPostAPI)(nil).GetScheduledPosts), ctx)
+}
+
+// HidePost mocks base method.
+func (m *MockPostAPI) HidePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HidePost", ctx, postID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HidePost indicates an expected call of HidePost.
+func (mr *MockPostAPIMockRecorder) HidePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HidePost", reflect.TypeOf((*MockPostAPI)(nil).HidePost), ctx, postID)
+}
+
+// LockPost mocks base method.
+func (m *MockPostAPI) LockPost(ctx context.Context, postID users.ID, payload posts.LockPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPost", ctx, postID, payload)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPost indicates an expected call of LockPost.
+func (mr *MockPostAPIMockRecorder) LockPost(ctx, postID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPost", reflect.TypeOf((*MockPostAPI)(nil).LockPost), ctx, postID, payload)
+}
+
+// PinPost mocks base method.
+func (m *MockPostAPI) PinPost(ctx context.Context, postID users.ID, payload posts.PinPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinPost", ctx, postID, payload)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinPost indicates an expected call of PinPost.
+func (mr *MockPostAPIMockRecorder) PinPost(ctx, postID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinPost", reflect.TypeOf((*MockPostAPI)(nil).PinPost), ctx, postID, payload)
+}
+
+// PublishDraft mocks base method.
+func (m *MockPostAPI) PublishDraft(ctx context.Context, draftID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDraft", ctx, draftID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDraft indicates an expected call of PublishDraft.
+func (mr *MockPostAPIMockRecorder) PublishDraft(ctx, draftID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDraft", reflect.TypeOf((*MockPostAPI)(nil).PublishDraft), ctx, draftID)
+}
+
+// SaveComment mocks base method.
+func (m *MockPostAPI) SaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveComment indicates an expected call of SaveComment.
+func (mr *MockPostAPIMockRecorder) SaveComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveComment", reflect.TypeOf((*MockPostAPI)(nil).SaveComment), ctx, postID, commentID)
+}
+
+// SavePost mocks base method.
+func (m *MockPostAPI) SavePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePost", ctx, postID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePost indicates an expected call of SavePost.
+func (mr *MockPostAPIMockRecorder) SavePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePost", reflect.TypeOf((*MockPostAPI)(nil).SavePost), ctx, postID)
+}
+
+// SchedulePost mocks base method.
+func (m *MockPostAPI) SchedulePost(ctx context.Context, postPayload posts.PostPayload) (*posts.ScheduledPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePost", ctx, postPayload)
+	ret0, _ := ret[0].(*posts.ScheduledPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePost indicates an expected call of SchedulePost.
+func (mr *MockPostAPIMockRecorder) SchedulePost(ctx, postPayload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePost", reflect.TypeOf((*MockPostAPI)(nil).SchedulePost), ctx, postPayload)
+}
+
+// SearchPosts mocks base method.
+func (m *MockPostAPI) SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", ctx, query)
+	ret0, _ := ret[0].([]*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockPostAPIMockRecorder) SearchPosts(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockPostAPI)(nil).SearchPosts), ctx, query)
+}
+
+// SetPostFlags mocks base method.
+func (m *MockPostAPI) SetPostFlags(ctx context.Context, postID users.ID, flags posts.FlagsPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostFlags", ctx, postID, flags)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPostFlags indicates an expected call of SetPostFlags.
+func (mr *MockPostAPIMockRecorder) SetPostFlags(ctx, postID, flags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostFlags", reflect.TypeOf((*MockPostAPI)(nil).SetPostFlags), ctx, postID, flags)
+}
+
+// SetPostFlair mocks base method.
+func (m *MockPostAPI) SetPostFlair(ctx context.Context, postID users.ID, payload posts.FlairPayload) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostFlair", ctx, postID, payload)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPostFlair indicates an expected call of SetPostFlair.
+func (mr *MockPostAPIMockRecorder) SetPostFlair(ctx, postID, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostFlair", reflect.TypeOf((*MockPostAPI)(nil).SetPostFlair), ctx, postID, payload)
+}
+
+// UnhidePost mocks base method.
+func (m *MockPostAPI) UnhidePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhidePost", ctx, postID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnhidePost indicates an expected call of UnhidePost.
+func (mr *MockPostAPIMockRecorder) UnhidePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhidePost", reflect.TypeOf((*MockPostAPI)(nil).UnhidePost), ctx, postID)
+}
+
+// UnlockPost mocks base method.
+func (m *MockPostAPI) UnlockPost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockPost", ctx, postID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockPost indicates an expected call of UnlockPost.
+func (mr *MockPostAPIMockRecorder) UnlockPost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockPost", reflect.TypeOf((*MockPostAPI)(nil).UnlockPost), ctx, postID)
+}
+
+// UnpinPost mocks base method.
+func (m *MockPostAPI) UnpinPost(ctx context.Context, postID users.ID, frontPage bool) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinPost", ctx, postID, frontPage)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinPost indicates an expected call of UnpinPost.
+func (mr *MockPostAPIMockRecorder) UnpinPost(ctx, postID, frontPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinPost", reflect.TypeOf((*MockPostAPI)(nil).UnpinPost), ctx, postID, frontPage)
+}
+
+// UnsaveComment mocks base method.
+func (m *MockPostAPI) UnsaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsaveComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsaveComment indicates an expected call of UnsaveComment.
+func (mr *MockPostAPIMockRecorder) UnsaveComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsaveComment", reflect.TypeOf((*MockPostAPI)(nil).UnsaveComment), ctx, postID, commentID)
+}
+
+// UnsavePost mocks base method.
+func (m *MockPostAPI) UnsavePost(ctx context.Context, postID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsavePost", ctx, postID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsavePost indicates an expected call of UnsavePost.
+func (mr *MockPostAPIMockRecorder) UnsavePost(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsavePost", reflect.TypeOf((*MockPostAPI)(nil).UnsavePost), ctx, postID)
 }
 
 // Unvote mocks base method.
@@ -185,6 +723,66 @@ func (mr *MockPostAPIMockRecorder) Unvote(ctx, postID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unvote", reflect.TypeOf((*MockPostAPI)(nil).Unvote), ctx, postID)
 }
 
+// UnvoteComment mocks base method.
+func (m *MockPostAPI) UnvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnvoteComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnvoteComment indicates an expected call of UnvoteComment.
+func (mr *MockPostAPIMockRecorder) UnvoteComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnvoteComment", reflect.TypeOf((*MockPostAPI)(nil).UnvoteComment), ctx, postID, commentID)
+}
+
+// UpdateDraft mocks base method.
+func (m *MockPostAPI) UpdateDraft(ctx context.Context, draftID users.ID, postPayload posts.PostPayload) (*posts.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDraft", ctx, draftID, postPayload)
+	ret0, _ := ret[0].(*posts.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDraft indicates an expected call of UpdateDraft.
+func (mr *MockPostAPIMockRecorder) UpdateDraft(ctx, draftID, postPayload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDraft", reflect.TypeOf((*MockPostAPI)(nil).UpdateDraft), ctx, draftID, postPayload)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockPostAPI) UpdatePreferences(ctx context.Context, prefs users.Preferences) (users.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, prefs)
+	ret0, _ := ret[0].(users.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockPostAPIMockRecorder) UpdatePreferences(ctx, prefs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockPostAPI)(nil).UpdatePreferences), ctx, prefs)
+}
+
+// UpdateScheduledPost mocks base method.
+func (m *MockPostAPI) UpdateScheduledPost(ctx context.Context, scheduledID users.ID, postPayload posts.PostPayload) (*posts.ScheduledPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledPost", ctx, scheduledID, postPayload)
+	ret0, _ := ret[0].(*posts.ScheduledPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledPost indicates an expected call of UpdateScheduledPost.
+func (mr *MockPostAPIMockRecorder) UpdateScheduledPost(ctx, scheduledID, postPayload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledPost", reflect.TypeOf((*MockPostAPI)(nil).UpdateScheduledPost), ctx, scheduledID, postPayload)
+}
+
 // Upvote mocks base method.
 func (m *MockPostAPI) Upvote(ctx context.Context, postID users.ID) (*posts.Post, error) {
 	m.ctrl.T.Helper()
@@ -199,3 +797,18 @@ func (mr *MockPostAPIMockRecorder) Upvote(ctx, postID interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upvote", reflect.TypeOf((*MockPostAPI)(nil).Upvote), ctx, postID)
 }
+
+// UpvoteComment mocks base method.
+func (m *MockPostAPI) UpvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpvoteComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(*posts.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpvoteComment indicates an expected call of UpvoteComment.
+func (mr *MockPostAPIMockRecorder) UpvoteComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpvoteComment", reflect.TypeOf((*MockPostAPI)(nil).UpvoteComment), ctx, postID, commentID)
+}
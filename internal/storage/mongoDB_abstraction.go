@@ -11,9 +11,18 @@ import (
 type AbstractCollection interface {
 	Find(ctx context.Context, filter any, opts ...*options.FindOptions) (AbstractCursor, error)
 	FindOne(ctx context.Context, filter any, opts ...*options.FindOneOptions) AbstractSingleResult
+	FindOneAndDelete(ctx context.Context, filter any, opts ...*options.FindOneAndDeleteOptions) AbstractSingleResult
+	FindOneAndUpdate(ctx context.Context, filter any, update any, opts ...*options.FindOneAndUpdateOptions) AbstractSingleResult
+	Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (AbstractCursor, error)
 	InsertOne(ctx context.Context, document any, opts ...*options.InsertOneOptions) (any, error)
 	UpdateOne(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (int64, error)
+	UpdateMany(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (int64, error)
 	DeleteOne(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error)
+	DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (int64, error)
+	CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error)
+	CreateIndex(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error)
+	DropIndex(ctx context.Context, name string) error
 }
 
 type AbstractCursor interface {
@@ -54,6 +63,21 @@ func (c *mongoCollection) FindOne(ctx context.Context, filter any, opts ...*opti
 	return c.collection.FindOne(ctx, filter, opts...)
 }
 
+func (c *mongoCollection) FindOneAndDelete(ctx context.Context, filter any, opts ...*options.FindOneAndDeleteOptions) AbstractSingleResult {
+	return c.collection.FindOneAndDelete(ctx, filter, opts...)
+}
+
+func (c *mongoCollection) FindOneAndUpdate(ctx context.Context, filter any, update any, opts ...*options.FindOneAndUpdateOptions) AbstractSingleResult {
+	return c.collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+func (c *mongoCollection) Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (AbstractCursor, error) {
+	cursor, err := c.collection.Aggregate(ctx, pipeline, opts...)
+	return &mongoCursor{
+		cursor: cursor,
+	}, err
+}
+
 func (c *mongoCollection) InsertOne(ctx context.Context, document any, opts ...*options.InsertOneOptions) (any, error) {
 	return c.collection.InsertOne(ctx, document, opts...)
 }
@@ -67,6 +91,15 @@ func (c *mongoCollection) UpdateOne(ctx context.Context, filter any, update any,
 	return result.MatchedCount, nil
 }
 
+func (c *mongoCollection) UpdateMany(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (int64, error) {
+	result, err := c.collection.UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
 func (c *mongoCollection) DeleteOne(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error) {
 	result, err := c.collection.DeleteOne(ctx, filter, opts...)
 	if err != nil {
@@ -76,6 +109,37 @@ func (c *mongoCollection) DeleteOne(ctx context.Context, filter any, opts ...*op
 	return result.DeletedCount, nil
 }
 
+func (c *mongoCollection) DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (int64, error) {
+	result, err := c.collection.DeleteMany(ctx, filter, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (c *mongoCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (int64, error) {
+	result, err := c.collection.BulkWrite(ctx, models, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.MatchedCount, nil
+}
+
+func (c *mongoCollection) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
+	return c.collection.CountDocuments(ctx, filter, opts...)
+}
+
+func (c *mongoCollection) CreateIndex(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
+	return c.collection.Indexes().CreateOne(ctx, model, opts...)
+}
+
+func (c *mongoCollection) DropIndex(ctx context.Context, name string) error {
+	_, err := c.collection.Indexes().DropOne(ctx, name)
+	return err
+}
+
 func (c *mongoCursor) All(ctx context.Context, result any) error {
 	return c.cursor.All(ctx, result)
 }
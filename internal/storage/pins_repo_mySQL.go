@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// PinRepoMySQL keeps the pinned posts of every category and of the front page, whose scope is an empty string.
+// Times are stored as unix milliseconds, a zero expiration time means the pin never expires
+type PinRepoMySQL struct {
+	db *sql.DB
+}
+
+func NewPinRepoMySQL(db *sql.DB) *PinRepoMySQL {
+	return &PinRepoMySQL{
+		db: db,
+	}
+}
+
+func (repo *PinRepoMySQL) GetPins(ctx context.Context, scope posts.PostCategory) (posts.Pins, error) {
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT post_uuid, pinned_at, expires_at FROM pinned_posts WHERE scope = ? ORDER BY position",
+		scope,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPins(rows, scope)
+}
+
+// UpdatePins replaces the pins of the scope within a transaction. Reading the pins for update locks the scope,
+// the gap the new pins go into included, so the other updates of the scope wait until the transaction ends
+func (repo *PinRepoMySQL) UpdatePins(
+	ctx context.Context,
+	scope posts.PostCategory,
+	update func(pins posts.Pins) (posts.Pins, error),
+) (err error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint:errcheck
+		}
+	}()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		"SELECT post_uuid, pinned_at, expires_at FROM pinned_posts WHERE scope = ? ORDER BY position FOR UPDATE",
+		scope,
+	)
+	if err != nil {
+		return err
+	}
+	pins, err := scanPins(rows, scope)
+	if err != nil {
+		return err
+	}
+	if pins, err = update(pins); err != nil {
+		return err
+	}
+	if len(pins) > posts.MaxPins {
+		return errs.ErrTooManyPins
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM pinned_posts WHERE scope = ?", scope); err != nil {
+		return err
+	}
+	for position, pin := range pins {
+		var expiresAt int64
+		if pin.Expires != nil {
+			expiresAt = pin.Expires.UnixMilli()
+		}
+		if _, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO pinned_posts (scope, post_uuid, position, pinned_at, expires_at) VALUES (?, ?, ?, ?, ?)",
+			scope,
+			pin.PostID,
+			position,
+			pin.Pinned.UnixMilli(),
+			expiresAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// scanPins reads the pins of the scope from the rows and closes them
+func scanPins(rows *sql.Rows, scope posts.PostCategory) (posts.Pins, error) {
+	defer rows.Close()
+
+	pins := make(posts.Pins, 0)
+	for rows.Next() {
+		pin := posts.Pin{Scope: scope}
+		var pinnedAt, expiresAt int64
+		if err := rows.Scan(&pin.PostID, &pinnedAt, &expiresAt); err != nil {
+			return nil, err
+		}
+		pin.Pinned = time.UnixMilli(pinnedAt).UTC()
+		if expiresAt != 0 {
+			expires := time.UnixMilli(expiresAt).UTC()
+			pin.Expires = &expires
+		}
+		pins = append(pins, pin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
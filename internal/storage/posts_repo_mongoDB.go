@@ -1,8 +1,11 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,20 +18,134 @@ import (
 	"github.com/Benzogang-Tape/Reddit/internal/models/users"
 )
 
+// Codes of the errors MongoDB reports when an index of the same name exists with another definition
+const (
+	indexOptionsConflict  int32 = 85
+	indexKeySpecsConflict int32 = 86
+)
+
 type PostRepoMongoDB struct {
 	collection AbstractCollection
+	comments   AbstractCollection
 }
 
-func NewPostRepoMongoDB(collection AbstractCollection) *PostRepoMongoDB {
+// NewPostRepoMongoDB returns the repository keeping the posts and the comments on them in two separate collections
+func NewPostRepoMongoDB(collection, comments AbstractCollection) *PostRepoMongoDB {
 	return &PostRepoMongoDB{
 		collection: collection,
+		comments:   comments,
+	}
+}
+
+// CreateIndexes creates the indexes the repository relies on. It is safe to call it on every start of the app
+func (p *PostRepoMongoDB) CreateIndexes(ctx context.Context) error {
+	source := "CreateIndexes"
+	textIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "text", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("posts_text").
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "text", Value: 5},
+			}),
+	}
+	hiddenIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "hiddenBy", Value: 1}},
+		Options: options.Index().SetName("posts_hidden_by"),
+	}
+	canonicalURLIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "canonicalUrl", Value: 1}},
+		Options: options.Index().SetName("posts_canonical_url"),
+	}
+	if err := replaceIndex(ctx, p.collection, textIndex); err != nil {
+		return errors.Wrap(err, source)
+	}
+	for _, index := range []mongo.IndexModel{hiddenIndex, canonicalURLIndex} {
+		if _, err := p.collection.CreateIndex(ctx, index); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+	if err := p.createCommentIndexes(ctx); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+// replaceIndex creates the index, dropping the index of the same name first if its definition has changed
+func replaceIndex(ctx context.Context, collection AbstractCollection, index mongo.IndexModel) error {
+	_, err := collection.CreateIndex(ctx, index)
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) || (cmdErr.Code != indexOptionsConflict && cmdErr.Code != indexKeySpecsConflict) {
+		return err
+	}
+	if err = collection.DropIndex(ctx, *index.Options.Name); err != nil {
+		return err
+	}
+	_, err = collection.CreateIndex(ctx, index)
+
+	return err
+}
+
+// MigrateCreated converts the creation timestamps of posts, their comments and crosspost parents
+// from the strings they were stored as before into dates. Posts converted already are left untouched,
+// so it is safe to call it on every start of the app. The strings that are not dates are kept as they are.
+// Returns the number of converted posts and the number of posts left with such strings
+func (p *PostRepoMongoDB) MigrateCreated(ctx context.Context) (int64, int64, error) {
+	source := "MigrateCreated"
+	isString := func(field string) bson.M {
+		return bson.M{"$eq": bson.A{bson.M{"$type": field}, "string"}}
+	}
+	toDate := func(field string) bson.M {
+		return bson.M{"$cond": bson.M{
+			"if":   isString(field),
+			"then": bson.M{"$dateFromString": bson.M{"dateString": field, "onError": field}},
+			"else": field,
+		}}
+	}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"created": bson.M{"$type": "string"}},
+		bson.M{"comments.created": bson.M{"$type": "string"}},
+		bson.M{"crosspostParent.created": bson.M{"$type": "string"}},
+	}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"created": toDate("$created"),
+		"comments": bson.M{"$map": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$comments", bson.A{}}},
+			"as":    "comment",
+			"in": bson.M{"$mergeObjects": bson.A{
+				"$$comment",
+				bson.M{"created": toDate("$$comment.created")},
+			}},
+		}},
+		"crosspostParent": bson.M{"$cond": bson.M{
+			"if": isString("$crosspostParent.created"),
+			"then": bson.M{"$mergeObjects": bson.A{
+				"$crosspostParent",
+				bson.M{"created": toDate("$crosspostParent.created")},
+			}},
+			"else": "$crosspostParent",
+		}},
+	}}}}
+	matched, err := p.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, source)
+	}
+	unparsed, err := p.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, source)
 	}
+
+	return matched - unparsed, unparsed, nil
 }
 
-func (p *PostRepoMongoDB) GetAllPosts(ctx context.Context) ([]*posts.Post, error) {
+func (p *PostRepoMongoDB) GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error) {
 	posts := make(posts.Posts, 0)
 	sort := bson.D{{Key: "score", Value: -1}}
-	cur, err := p.collection.Find(ctx, bson.M{}, options.Find().SetSort(sort))
+	cur, err := p.collection.Find(ctx, feedFilter(ctx, query, bson.M{}), options.Find().SetSort(sort))
 	if err != nil {
 		return nil, err
 	}
@@ -40,9 +157,9 @@ func (p *PostRepoMongoDB) GetAllPosts(ctx context.Context) ([]*posts.Post, error
 	return posts, nil
 }
 
-func (p *PostRepoMongoDB) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error) {
+func (p *PostRepoMongoDB) GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error) {
 	posts := make(posts.Posts, 0)
-	filter := bson.M{"category": postCategory}
+	filter := feedFilter(ctx, query, bson.M{"category": postCategory})
 	sort := bson.D{{Key: "score", Value: -1}}
 	cur, err := p.collection.Find(ctx, filter, options.Find().SetSort(sort))
 	if err != nil {
@@ -56,9 +173,9 @@ func (p *PostRepoMongoDB) GetPostsByCategory(ctx context.Context, postCategory p
 	return posts, nil
 }
 
-func (p *PostRepoMongoDB) GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error) {
+func (p *PostRepoMongoDB) GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error) {
 	postList := make([]*posts.Post, 0)
-	filter := bson.M{"author.username": userLogin}
+	filter := feedFilter(ctx, query, bson.M{"author.username": userLogin})
 	sort := bson.D{{Key: "created", Value: -1}}
 	cur, err := p.collection.Find(ctx, filter, options.Find().SetSort(sort))
 	if err != nil {
@@ -72,6 +189,21 @@ func (p *PostRepoMongoDB) GetPostsByUser(ctx context.Context, userLogin users.Us
 	return postList, nil
 }
 
+func (p *PostRepoMongoDB) GetPostsByURL(ctx context.Context, canonicalURL string) ([]*posts.Post, error) {
+	postList := make([]*posts.Post, 0)
+	sort := bson.D{{Key: "created", Value: -1}}
+	cur, err := p.collection.Find(ctx, bson.M{"canonicalUrl": canonicalURL}, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &postList); err != nil {
+		return nil, err
+	}
+
+	return postList, nil
+}
+
 func (p *PostRepoMongoDB) GetPostByID(ctx context.Context, postID users.ID) (*posts.Post, error) {
 	filter := bson.M{"uuid": postID}
 	res := p.collection.FindOne(ctx, filter)
@@ -87,6 +219,21 @@ func (p *PostRepoMongoDB) GetPostByID(ctx context.Context, postID users.ID) (*po
 	return post, nil
 }
 
+func (p *PostRepoMongoDB) GetPostsByIDs(ctx context.Context, postIDs []users.ID) ([]*posts.Post, error) {
+	postList := make([]*posts.Post, 0, len(postIDs))
+	filter := bson.M{"uuid": bson.M{"$in": postIDs}}
+	cur, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &postList); err != nil {
+		return nil, err
+	}
+
+	return postList, nil
+}
+
 func (p *PostRepoMongoDB) CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error) {
 	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
 	if !ok {
@@ -112,43 +259,65 @@ func (p *PostRepoMongoDB) DeletePost(ctx context.Context, postID users.ID) error
 	if deletedCount == 0 {
 		return errs.ErrPostNotFound
 	}
+	if _, err = p.comments.DeleteMany(ctx, bson.M{"postId": postID}); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func (p *PostRepoMongoDB) AddComment(ctx context.Context, post *posts.Post, comment posts.Comment) (*posts.Post, error) {
+func (p *PostRepoMongoDB) CreateCrosspost(ctx context.Context, parent *posts.Post, payload posts.CrosspostPayload) (*posts.Post, error) {
+	source := "CreateCrosspost"
 	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
 	if !ok {
 		return nil, errs.ErrBadPayload
 	}
 
-	newComment := post.AddComment(*author, comment.Body)
-	if _, err := p.collection.UpdateOne(
-		ctx,
-		bson.M{"uuid": post.ID},
-		bson.M{"$push": bson.M{"comments": newComment}},
-	); err != nil {
-		return nil, err
+	crosspost := posts.NewCrosspost(*author, parent, payload)
+	if _, err := p.collection.InsertOne(ctx, crosspost); err != nil {
+		return nil, errors.Wrap(err, source)
 	}
 
-	return post, nil
+	ref := parent.AddCrosspost(crosspost)
+	filter := bson.M{"uuid": parent.ID}
+	update := bson.M{
+		"$push": bson.M{"crossposts": ref},
+		"$inc":  bson.M{"crosspostCount": 1},
+	}
+	if _, err := p.collection.UpdateOne(ctx, filter, update); err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+
+	return crosspost, nil
 }
 
-func (p *PostRepoMongoDB) DeleteComment(ctx context.Context, post *posts.Post, commentID users.ID) (*posts.Post, error) {
-	source := "DeleteComment"
-	if err := post.DeleteComment(commentID); err != nil {
-		return nil, errors.Wrap(err, source)
+// RemoveCrosspostSource puts every crosspost of the deleted post into the "source removed" state
+func (p *PostRepoMongoDB) RemoveCrosspostSource(ctx context.Context, parentID users.ID) error {
+	source := "RemoveCrosspostSource"
+	filter := bson.M{"crosspostParent.uuid": parentID}
+	update := bson.M{
+		"$set":   bson.M{"crosspostParent": posts.RemovedCrosspostParent(parentID)},
+		"$unset": bson.M{"text": "", "image": ""},
+	}
+	if _, err := p.collection.UpdateMany(ctx, filter, update); err != nil {
+		return errors.Wrap(err, source)
 	}
 
-	if _, err := p.collection.UpdateOne(
-		ctx,
-		bson.M{"uuid": post.ID},
-		bson.M{"$pull": bson.M{"comments": bson.M{"uuid": commentID}}},
-	); err != nil {
-		return nil, errors.Wrap(err, source)
+	return nil
+}
+
+func (p *PostRepoMongoDB) RemoveCrosspostRef(ctx context.Context, parentID, crosspostID users.ID) error {
+	source := "RemoveCrosspostRef"
+	filter := bson.M{"uuid": parentID, "crossposts.uuid": crosspostID}
+	update := bson.M{
+		"$pull": bson.M{"crossposts": bson.M{"uuid": crosspostID}},
+		"$inc":  bson.M{"crosspostCount": -1},
+	}
+	if _, err := p.collection.UpdateOne(ctx, filter, update); err != nil {
+		return errors.Wrap(err, source)
 	}
 
-	return post, nil
+	return nil
 }
 
 func (p *PostRepoMongoDB) Upvote(ctx context.Context, post *posts.Post) (*posts.Post, error) {
@@ -267,13 +436,354 @@ func (p *PostRepoMongoDB) Unvote(ctx context.Context, post *posts.Post) (*posts.
 	return post, nil
 }
 
-func (p *PostRepoMongoDB) UpdateViews(ctx context.Context, postID users.ID) error {
-	source := "UpdateViews"
+func (p *PostRepoMongoDB) CastBallot(ctx context.Context, post *posts.Post, choice []int) (*posts.Post, error) {
+	source := "CastBallot"
+	author, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+	if post.Poll == nil {
+		return nil, errors.Wrap(errs.ErrNotAPoll, source)
+	}
+
+	previous, err := post.Poll.Cast(author.ID, choice, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	added, removed := posts.BallotDiff(previous, choice)
+	if len(added) == 0 && len(removed) == 0 {
+		return post, nil
+	}
+
+	// The current ballot is part of the filter, so a concurrent ballot of the same user can't be counted twice
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{}
+	if previous == nil {
+		filter["poll.ballots.user"] = bson.M{"$ne": author.ID}
+		update["$push"] = bson.M{"poll.ballots": &posts.Ballot{UserID: author.ID, Options: choice}}
+	} else {
+		filter["poll.ballots"] = bson.M{"$elemMatch": bson.M{"user": author.ID, "options": previous}}
+		update["$set"] = bson.M{"poll.ballots.$.options": choice}
+	}
+
+	inc := bson.M{}
+	arrayFilters := make([]any, 0, 2)
+	if len(added) > 0 {
+		inc["poll.options.$[added].votes"] = 1
+		arrayFilters = append(arrayFilters, bson.M{"added.id": bson.M{"$in": added}})
+	}
+	if len(removed) > 0 {
+		inc["poll.options.$[removed].votes"] = -1
+		arrayFilters = append(arrayFilters, bson.M{"removed.id": bson.M{"$in": removed}})
+	}
+	update["$inc"] = inc
+
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update,
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: arrayFilters}))
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrBallotConflict, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) HidePost(ctx context.Context, post *posts.Post) (*posts.Post, error) {
+	source := "HidePost"
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	post.Hide(user.ID)
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{"$addToSet": bson.M{"hiddenBy": user.ID}}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrPostNotFound, source)
+	}
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) UnhidePost(ctx context.Context, post *posts.Post) (*posts.Post, error) {
+	source := "UnhidePost"
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	post.Unhide(user.ID)
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{"$pull": bson.M{"hiddenBy": user.ID}}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrPostNotFound, source)
+	}
+
+	return post, nil
+}
+
+// GetHiddenPosts returns the posts hidden by the user from the request context, the newest first
+func (p *PostRepoMongoDB) GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error) {
+	user, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload)
+	if !ok {
+		return nil, errs.ErrBadPayload
+	}
+
+	postList := make([]*posts.Post, 0)
+	filter := bson.M{"hiddenBy": user.ID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created", Value: -1}}).
+		SetSkip(int64(page.Offset)).
+		SetLimit(int64(page.Limit))
+	cur, err := p.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &postList); err != nil {
+		return nil, err
+	}
+
+	return postList, nil
+}
+
+func (p *PostRepoMongoDB) SetFlair(ctx context.Context, post *posts.Post, flair *posts.Flair) (*posts.Post, error) {
+	source := "SetFlair"
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{"$set": bson.M{"flair": flair}}
+	if flair == nil {
+		update = bson.M{"$unset": bson.M{"flair": ""}}
+	}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrPostNotFound, source)
+	}
+	post.Flair = flair
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) SetFlags(ctx context.Context, post *posts.Post, flags posts.FlagsPayload) (*posts.Post, error) {
+	source := "SetFlags"
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{"$set": bson.M{"nsfw": flags.NSFW, "spoiler": flags.Spoiler}}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrPostNotFound, source)
+	}
+	post.NSFW, post.Spoiler = flags.NSFW, flags.Spoiler
+
+	return post, nil
+}
+
+func (p *PostRepoMongoDB) SetLock(ctx context.Context, post *posts.Post, locked, votesFrozen bool) (*posts.Post, error) {
+	source := "SetLock"
+	filter := bson.M{"uuid": post.ID}
+	update := bson.M{"$set": bson.M{"locked": locked, "votesFrozen": votesFrozen}}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return nil, errors.Wrap(errs.ErrPostNotFound, source)
+	}
+	post.Locked, post.VotesFrozen = locked, votesFrozen
+
+	return post, nil
+}
+
+// SearchPosts looks the text up in the posts and in the comments on them, the comments are searched
+// in their own collection and their hits are merged with the posts by postId. The relevance of a post
+// adds up the scores of its title, its text and all of its comments matching the text
+func (p *PostRepoMongoDB) SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error) {
+	source := "SearchPosts"
+	// Only the first pages of both kinds of hits may be among the requested page of the merged ones
+	window := int64(query.Offset + query.Limit)
+	commentHits, err := p.searchComments(ctx, query.Text, window)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	postIDs := make([]users.ID, 0, len(commentHits))
+	for postID := range commentHits {
+		postIDs = append(postIDs, postID)
+	}
+
+	found, err := p.findSearched(ctx, query, bson.M{"$text": bson.M{"$search": query.Text}}, window)
+	if err != nil {
+		return nil, errors.Wrap(err, source)
+	}
+	var commented []searchedPost
+	if len(postIDs) != 0 {
+		if commented, err = p.findSearched(ctx, query, bson.M{"uuid": bson.M{"$in": postIDs}}, 0); err != nil {
+			return nil, errors.Wrap(err, source)
+		}
+	}
+
+	relevance := make(map[users.ID]float64, len(found)+len(commented))
+	postList := make([]*posts.Post, 0, len(found)+len(commented))
+	hits := append(found, commented...)
+	for i := range hits {
+		hit := &hits[i]
+		if _, ok := relevance[hit.ID]; !ok {
+			relevance[hit.ID] = commentHits[hit.ID]
+			postList = append(postList, &hit.Post)
+		}
+		relevance[hit.ID] += hit.Relevance
+	}
+	slices.SortStableFunc(postList, func(a, b *posts.Post) int {
+		return -cmp.Compare(relevance[a.ID], relevance[b.ID])
+	})
+	postList = postList[min(query.Offset, len(postList)):]
+	if query.Limit > 0 && len(postList) > query.Limit {
+		postList = postList[:query.Limit]
+	}
+
+	return postList, nil
+}
+
+// searchedPost is a post found by SearchPosts along with the relevance of its own text
+type searchedPost struct {
+	posts.Post `bson:",inline"`
+	Relevance  float64 `bson:"relevance"`
+}
+
+// findSearched returns the posts matching the filter and the filters of the query besides the text,
+// the most relevant ones first. A zero limit means no limit
+func (p *PostRepoMongoDB) findSearched(ctx context.Context, query posts.SearchQuery, filter bson.M, limit int64) ([]searchedPost, error) {
+	filter = feedFilter(ctx, posts.FeedQuery{NSFW: query.NSFW, From: query.From, To: query.To}, filter)
+	if query.Category != "" {
+		filter["category"] = query.Category
+	}
+	if query.Author != "" {
+		filter["author.username"] = query.Author
+	}
+
+	opts := options.Find().SetLimit(limit)
+	if _, ok := filter["$text"]; ok {
+		relevance := bson.M{"relevance": bson.M{"$meta": "textScore"}}
+		opts.SetProjection(relevance).SetSort(relevance)
+	}
+	cur, err := p.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]searchedPost, 0)
+	if err = cur.All(ctx, &hits); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+// searchComments returns the summed up scores of the comments matching the text by the posts they are on,
+// limited to the most relevant posts. The placeholders of deleted comments are never found
+func (p *PostRepoMongoDB) searchComments(ctx context.Context, text string, limit int64) (map[users.ID]float64, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"$text": bson.M{"$search": text}, "deleted": bson.M{"$ne": true}}},
+		bson.M{"$addFields": bson.M{"relevance": bson.M{"$meta": "textScore"}}},
+		bson.M{"$group": bson.M{"_id": "$postId", "relevance": bson.M{"$sum": "$relevance"}}},
+		bson.M{"$sort": bson.D{{Key: "relevance", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": limit})
+	}
+	cur, err := p.comments.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var hits []struct {
+		PostID    users.ID `bson:"_id"`
+		Relevance float64  `bson:"relevance"`
+	}
+	if err = cur.All(ctx, &hits); err != nil {
+		return nil, err
+	}
+	relevance := make(map[users.ID]float64, len(hits))
+	for _, hit := range hits {
+		relevance[hit.PostID] = hit.Relevance
+	}
+
+	return relevance, nil
+}
+
+// AddViews increments the view counters of all the posts in a single round trip
+func (p *PostRepoMongoDB) AddViews(ctx context.Context, views map[users.ID]uint) error {
+	source := "AddViews"
+	models := make([]mongo.WriteModel, 0, len(views))
+	for postID, count := range views {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"uuid": postID}).
+			SetUpdate(bson.M{"$inc": bson.M{"views": count}}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	if _, err := p.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+func (p *PostRepoMongoDB) SetLinkPreview(ctx context.Context, postID users.ID, preview *posts.LinkPreview) error {
+	source := "SetLinkPreview"
 	filter := bson.M{"uuid": postID}
-	update := bson.M{"$inc": bson.M{"views": 1}}
-	if _, err := p.collection.UpdateOne(ctx, filter, update); err != nil {
+	update := bson.M{"$set": bson.M{"preview": preview}}
+	matchedCount, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
 		return errors.Wrap(err, source)
 	}
+	if matchedCount == 0 {
+		return errors.Wrap(errs.ErrPostNotFound, source)
+	}
 
 	return nil
 }
+
+// feedFilter adds the filters of the query to the filter of a feed and excludes the posts
+// the user from the request context has hidden. Anonymous requests see everything
+func feedFilter(ctx context.Context, query posts.FeedQuery, filter bson.M) bson.M {
+	if viewer, ok := ctx.Value(jwt.Payload).(*jwt.TokenPayload); ok {
+		filter["hiddenBy"] = bson.M{"$ne": viewer.ID}
+	}
+	if query.Flair != "" {
+		filter["flair.text"] = query.Flair
+	}
+	if !query.NSFW {
+		filter["nsfw"] = bson.M{"$ne": true}
+	}
+	if created := createdRange(query.From, query.To); len(created) != 0 {
+		filter["created"] = created
+	}
+
+	return filter
+}
+
+func createdRange(from, to time.Time) bson.M {
+	created := bson.M{}
+	if !from.IsZero() {
+		created["$gte"] = from
+	}
+	if !to.IsZero() {
+		created["$lte"] = to
+	}
+
+	return created
+}
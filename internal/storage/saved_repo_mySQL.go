@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// SavedRepoMySQL keeps the posts and comments saved by users. The time of saving is stored
+// as unix milliseconds, so the repository does not depend on the parseTime option of the dsn
+type SavedRepoMySQL struct {
+	db *sql.DB
+}
+
+func NewSavedRepoMySQL(db *sql.DB) *SavedRepoMySQL {
+	return &SavedRepoMySQL{
+		db: db,
+	}
+}
+
+func (repo *SavedRepoMySQL) SaveItem(ctx context.Context, item posts.SavedItem) error { //nolint:unparam
+	_, err := repo.db.Exec(
+		"INSERT INTO saved_items (user_uuid, item_uuid, item_type, post_uuid, saved_at) VALUES (?, ?, ?, ?, ?) "+
+			"ON DUPLICATE KEY UPDATE saved_at = saved_at",
+		item.UserID,
+		item.ItemID,
+		item.Type,
+		item.PostID,
+		item.Saved.UnixMilli(),
+	)
+
+	return err
+}
+
+func (repo *SavedRepoMySQL) UnsaveItem(ctx context.Context, userID, itemID users.ID) error { //nolint:unparam
+	_, err := repo.db.Exec(
+		"DELETE FROM saved_items WHERE user_uuid = ? AND item_uuid = ?",
+		userID,
+		itemID,
+	)
+
+	return err
+}
+
+func (repo *SavedRepoMySQL) GetSavedItems(ctx context.Context, userID users.ID, query posts.SavedQuery) ([]posts.SavedItem, error) { //nolint:unparam
+	stmt := "SELECT item_uuid, item_type, post_uuid, saved_at FROM saved_items WHERE user_uuid = ?"
+	args := []any{userID}
+	if query.Type != "" {
+		stmt += " AND item_type = ?"
+		args = append(args, query.Type)
+	}
+	stmt += " ORDER BY saved_at DESC LIMIT ? OFFSET ?"
+	args = append(args, query.Limit, query.Offset)
+
+	rows, err := repo.db.Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]posts.SavedItem, 0)
+	for rows.Next() {
+		item := posts.SavedItem{UserID: userID}
+		var savedAt int64
+		if err = rows.Scan(&item.ItemID, &item.Type, &item.PostID, &savedAt); err != nil {
+			return nil, err
+		}
+		item.Saved = time.UnixMilli(savedAt).UTC()
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *SavedRepoMySQL) GetSavedIDs(ctx context.Context, userID users.ID, itemIDs []users.ID) (posts.SavedSet, error) { //nolint:unparam
+	saved := make(posts.SavedSet)
+	if len(itemIDs) == 0 {
+		return saved, nil
+	}
+
+	args := make([]any, 0, len(itemIDs)+1)
+	args = append(args, userID)
+	for _, itemID := range itemIDs {
+		args = append(args, itemID)
+	}
+	rows, err := repo.db.Query(
+		"SELECT item_uuid FROM saved_items WHERE user_uuid = ? AND item_uuid IN (?"+strings.Repeat(", ?", len(itemIDs)-1)+")",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID users.ID
+		if err = rows.Scan(&itemID); err != nil {
+			return nil, err
+		}
+		saved[itemID] = struct{}{}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// DeleteSavedItems forgets the item for all the users. For a post, its comments are forgotten as well
+func (repo *SavedRepoMySQL) DeleteSavedItems(ctx context.Context, itemID users.ID) error { //nolint:unparam
+	_, err := repo.db.Exec(
+		"DELETE FROM saved_items WHERE item_uuid = ? OR post_uuid = ?",
+		itemID,
+		itemID,
+	)
+
+	return err
+}
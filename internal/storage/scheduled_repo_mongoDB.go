@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type ScheduledRepoMongoDB struct {
+	collection AbstractCollection
+}
+
+func NewScheduledRepoMongoDB(collection AbstractCollection) *ScheduledRepoMongoDB {
+	return &ScheduledRepoMongoDB{
+		collection: collection,
+	}
+}
+
+// CreateIndexes creates the indexes the repository relies on. It is safe to call it on every start of the app
+func (s *ScheduledRepoMongoDB) CreateIndexes(ctx context.Context) error {
+	source := "CreateIndexes"
+	for _, index := range []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "publishAt", Value: 1}},
+			Options: options.Index().SetName("scheduled_publish_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "author.id", Value: 1}, {Key: "publishAt", Value: 1}},
+			Options: options.Index().SetName("scheduled_author"),
+		},
+	} {
+		if _, err := s.collection.CreateIndex(ctx, index); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+
+	return nil
+}
+
+func (s *ScheduledRepoMongoDB) AddScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error {
+	_, err := s.collection.InsertOne(ctx, scheduled)
+	return err
+}
+
+func (s *ScheduledRepoMongoDB) GetScheduledPost(ctx context.Context, scheduledID users.ID) (*posts.ScheduledPost, error) {
+	res := s.collection.FindOne(ctx, bson.M{"uuid": scheduledID})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, errs.ErrScheduledPostNotFound
+	}
+
+	scheduled := new(posts.ScheduledPost)
+	if err := res.Decode(scheduled); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+// GetScheduledPosts returns the posts scheduled by the author, the earliest to be published first
+func (s *ScheduledRepoMongoDB) GetScheduledPosts(ctx context.Context, authorID users.ID) ([]*posts.ScheduledPost, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "publishAt", Value: 1}})
+	return s.find(ctx, bson.M{"author.id": authorID}, opts)
+}
+
+// GetDueScheduledPosts returns at most limit claimable posts that had to be published by now, the most overdue first
+func (s *ScheduledRepoMongoDB) GetDueScheduledPosts(ctx context.Context, now time.Time, limit int) ([]*posts.ScheduledPost, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "publishAt", Value: 1}}).
+		SetLimit(int64(limit))
+	filter := claimable(now)
+	filter["publishAt"] = bson.M{"$lte": now}
+	return s.find(ctx, filter, opts)
+}
+
+// claimable matches the posts no scheduler holds at now and that have not been given up on, the same way
+// ScheduledPost.Claimable does. The negations match the posts never tried as well
+func claimable(now time.Time) bson.M {
+	return bson.M{
+		"attempts":    bson.M{"$not": bson.M{"$gte": posts.MaxPublishAttempts}},
+		"lockedUntil": bson.M{"$not": bson.M{"$gt": now}},
+	}
+}
+
+func (s *ScheduledRepoMongoDB) UpdateScheduledPost(ctx context.Context, scheduled *posts.ScheduledPost) error {
+	source := "UpdateScheduledPost"
+	filter := bson.M{"uuid": scheduled.ID}
+	update := bson.M{"$set": bson.M{"post": scheduled.Post, "publishAt": scheduled.PublishAt, "attempts": scheduled.Attempts}}
+	matchedCount, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, source)
+	}
+	if matchedCount == 0 {
+		return errors.Wrap(errs.ErrScheduledPostNotFound, source)
+	}
+
+	return nil
+}
+
+func (s *ScheduledRepoMongoDB) DeleteScheduledPost(ctx context.Context, scheduledID users.ID) error {
+	deletedCount, err := s.collection.DeleteOne(ctx, bson.M{"uuid": scheduledID})
+	if err != nil {
+		return err
+	}
+	if deletedCount == 0 {
+		return errs.ErrScheduledPostNotFound
+	}
+
+	return nil
+}
+
+// ClaimScheduledPost holds the post until the time given and returns it. Only one of the concurrent callers gets the post,
+// the others find it held. A post held by a scheduler that crashed is claimable again once the hold runs out
+func (s *ScheduledRepoMongoDB) ClaimScheduledPost(ctx context.Context, scheduledID users.ID, now, until time.Time) (*posts.ScheduledPost, error) {
+	filter := claimable(now)
+	filter["uuid"] = scheduledID
+	update := bson.M{
+		"$set": bson.M{"lockedUntil": until},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	res := s.collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, errs.ErrScheduledPostNotFound
+	}
+
+	scheduled := new(posts.ScheduledPost)
+	if err := res.Decode(scheduled); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+func (s *ScheduledRepoMongoDB) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*posts.ScheduledPost, error) {
+	scheduledList := make([]*posts.ScheduledPost, 0)
+	cur, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &scheduledList); err != nil {
+		return nil, err
+	}
+
+	return scheduledList, nil
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// StatsRepoMongoDB keeps a document per post and bucket. Buckets older than the retention are removed by Mongo
+type StatsRepoMongoDB struct {
+	collection AbstractCollection
+	retention  time.Duration
+}
+
+// NewStatsRepoMongoDB creates the repository keeping the buckets for the retention, forever if it is zero
+func NewStatsRepoMongoDB(collection AbstractCollection, retention time.Duration) *StatsRepoMongoDB {
+	return &StatsRepoMongoDB{
+		collection: collection,
+		retention:  retention,
+	}
+}
+
+// CreateIndexes creates the indexes the repository relies on. It is safe to call it on every start of the app,
+// but a changed retention only applies once the stats_retention index has been dropped
+func (s *StatsRepoMongoDB) CreateIndexes(ctx context.Context) error {
+	source := "CreateIndexes"
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "postId", Value: 1}, {Key: "start", Value: 1}},
+		Options: options.Index().SetName("stats_post_start").SetUnique(true),
+	}}
+	if s.retention > 0 {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "start", Value: 1}},
+			Options: options.Index().SetName("stats_retention").SetExpireAfterSeconds(int32(s.retention.Seconds())),
+		})
+	}
+	for _, index := range indexes {
+		if _, err := s.collection.CreateIndex(ctx, index); err != nil {
+			return errors.Wrap(err, source)
+		}
+	}
+
+	return nil
+}
+
+// AddStats adds the counts of the buckets to the stored ones in a single round trip
+func (s *StatsRepoMongoDB) AddStats(ctx context.Context, buckets []*posts.StatsBucket) error {
+	source := "AddStats"
+	models := make([]mongo.WriteModel, 0, len(buckets))
+	for _, bucket := range buckets {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"postId": bucket.PostID, "start": bucket.Start}).
+			SetUpdate(bson.M{"$inc": bson.M{
+				"views":     bucket.Views,
+				"upvotes":   bucket.Upvotes,
+				"downvotes": bucket.Downvotes,
+				"comments":  bucket.Comments,
+			}}).
+			SetUpsert(true))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	if _, err := s.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return errors.Wrap(err, source)
+	}
+
+	return nil
+}
+
+// GetStats returns the buckets of the post starting between from and to, the oldest first
+func (s *StatsRepoMongoDB) GetStats(ctx context.Context, postID users.ID, from, to time.Time) ([]*posts.StatsBucket, error) {
+	// Mongo removes the expired documents once a minute, so the retention is applied to the reads as well
+	if s.retention > 0 {
+		from = latest(from, time.Now().Add(-s.retention))
+	}
+
+	buckets := make([]*posts.StatsBucket, 0)
+	filter := bson.M{"postId": postID, "start": bson.M{"$gte": from, "$lte": to}}
+	sort := bson.D{{Key: "start", Value: 1}}
+	cur, err := s.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
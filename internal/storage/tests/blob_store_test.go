@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/service"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+)
+
+var (
+	blobKey  = "12345678-9abc-def1-2345-6789abcdef12.png"
+	blobData = []byte("not really a png")
+)
+
+// authTemplate matches the Authorization header of a request signed with AWS Signature Version 4
+var authTemplate = regexp.MustCompile(
+	`^AWS4-HMAC-SHA256 Credential=([^/]+)/([0-9]{8})/([^/]+)/s3/aws4_request, SignedHeaders=([a-z0-9;-]+), Signature=([0-9a-f]{64})$`,
+)
+
+// fakeS3 is a local stand-in for an S3 bucket keeping objects in memory
+type fakeS3 struct {
+	secrets map[string]string // Access key -> secret key
+	objects map[string][]byte
+	types   map[string]string
+	mu      sync.Mutex
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		secrets: map[string]string{"access": "secret"},
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+}
+
+// verify recomputes the signature of the request the way S3 does
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	matches := authTemplate.FindStringSubmatch(r.Header.Get("Authorization"))
+	if matches == nil {
+		return false
+	}
+	accessKey, date, region, signedHeaders, signature := matches[1], matches[2], matches[3], matches[4], matches[5]
+	secret, ok := f.secrets[accessKey]
+	if !ok {
+		return false
+	}
+
+	bodyHash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(bodyHash[:])
+	amzDate := r.Header.Get("X-Amz-Date")
+	headers := strings.Split(signedHeaders, ";")
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash || !strings.HasPrefix(amzDate, date+"T") ||
+		!slices.Contains(headers, "host") || !slices.Contains(headers, "x-amz-date") {
+		return false
+	}
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range headers {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.Query().Encode(), canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secret)
+	for _, part := range []string{date, region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	expected, err := hex.DecodeString(signature)
+
+	return err == nil && hmac.Equal(key, expected)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || !f.verify(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		object, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[r.URL.Path])
+		w.Write(object) //nolint:errcheck
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func testBlobStore(t *testing.T, blobs service.BlobStore) {
+	ctx := context.Background()
+
+	// Not found
+	_, _, err := blobs.Get(ctx, blobKey)
+	assert.ErrorIs(t, err, errs.ErrBlobNotFound)
+
+	// Put and get
+	assert.NoError(t, blobs.Put(ctx, blobKey, blobData, "image/png"))
+	blob, info, err := blobs.Get(ctx, blobKey)
+	assert.NoError(t, err)
+	data, err := io.ReadAll(blob)
+	assert.NoError(t, err)
+	assert.NoError(t, blob.Close())
+	assert.Equal(t, blobData, data)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, int64(len(blobData)), info.Size)
+
+	// Delete, twice
+	assert.NoError(t, blobs.Delete(ctx, blobKey))
+	assert.NoError(t, blobs.Delete(ctx, blobKey))
+	_, _, err = blobs.Get(ctx, blobKey)
+	assert.ErrorIs(t, err, errs.ErrBlobNotFound)
+}
+
+func TestBlobStoreFS(t *testing.T) {
+	blobs, err := storage.NewBlobStoreFS(t.TempDir())
+	assert.NoError(t, err)
+
+	testBlobStore(t, blobs)
+
+	// Path traversal
+	_, _, err = blobs.Get(context.Background(), "../../etc/passwd")
+	assert.ErrorIs(t, err, errs.ErrBlobNotFound)
+	assert.Error(t, blobs.Put(context.Background(), "../escape.png", blobData, "image/png"))
+}
+
+func TestBlobStoreS3(t *testing.T) {
+	server := httptest.NewServer(newFakeS3())
+	defer server.Close()
+
+	blobs := storage.NewBlobStoreS3(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "media",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, server.Client())
+
+	testBlobStore(t, blobs)
+
+	// Bad credentials
+	for _, credentials := range [][2]string{{"intruder", ""}, {"access", "guess"}} {
+		blobs = storage.NewBlobStoreS3(storage.S3Config{
+			Endpoint:  server.URL,
+			Region:    "us-east-1",
+			Bucket:    "media",
+			AccessKey: credentials[0],
+			SecretKey: credentials[1],
+		}, server.Client())
+
+		err := blobs.Put(context.Background(), blobKey, blobData, "image/png")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	}
+
+	// Tampered with after signing
+	client := server.Client()
+	client.Transport = tamper{client.Transport}
+	blobs = storage.NewBlobStoreS3(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "media",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, client)
+
+	err := blobs.Put(context.Background(), blobKey, blobData, "image/png")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
+
+// tamper changes the signed content type of the requests it sends
+type tamper struct {
+	http.RoundTripper
+}
+
+func (t tamper) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Content-Type", "text/html")
+
+	return t.RoundTripper.RoundTrip(r)
+}
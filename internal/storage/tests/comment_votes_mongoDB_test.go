@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+func TestVoteComment(t *testing.T) { //nolint:funlen
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	post := deepCopyPost(expectedPosts[0])
+	commentID := post.Comments[0].ID
+	noVote := bson.M{"uuid": commentID, "deleted": bson.M{"$ne": true}, "votes.user": bson.M{"$ne": tokenPayloadUser.ID}}
+	votedFilter := func(vote posts.Vote) bson.M {
+		return bson.M{"uuid": commentID, "votes": bson.M{"$elemMatch": bson.M{"user": tokenPayloadUser.ID, "vote": vote}}}
+	}
+	voted := func(vote posts.Vote) bson.M {
+		filter := votedFilter(vote)
+		filter["deleted"] = bson.M{"$ne": true}
+		return filter
+	}
+
+	// A new vote is pushed
+	commentCollection.EXPECT().UpdateOne(ctx, noVote, bson.M{
+		"$inc":  bson.M{"score": 1},
+		"$push": bson.M{"votes": &posts.PostVote{UserID: tokenPayloadUser.ID, Vote: 1}},
+	}).Return(int64(1), nil)
+
+	updated, err := postRepo.UpvoteComment(ctx, post, commentID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.Comments[0].Score)
+
+	// The same vote again changes nothing
+	_, err = postRepo.UpvoteComment(ctx, post, commentID)
+	assert.NoError(t, err)
+
+	// A changed vote is set in place
+	commentCollection.EXPECT().UpdateOne(ctx, voted(1), bson.M{
+		"$inc": bson.M{"score": -2},
+		"$set": bson.M{"votes.$.vote": posts.Vote(-1)},
+	}).Return(int64(1), nil)
+
+	updated, err = postRepo.DownvoteComment(ctx, post, commentID)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, updated.Comments[0].Score)
+
+	// A withdrawn vote is pulled
+	commentCollection.EXPECT().UpdateOne(ctx, votedFilter(-1), bson.M{
+		"$inc":  bson.M{"score": 1},
+		"$pull": bson.M{"votes": bson.M{"user": tokenPayloadUser.ID}},
+	}).Return(int64(1), nil)
+
+	updated, err = postRepo.UnvoteComment(ctx, post, commentID)
+	assert.NoError(t, err)
+	assert.Zero(t, updated.Comments[0].Score)
+
+	_, err = postRepo.UnvoteComment(ctx, post, commentID)
+	assert.ErrorIs(t, err, errs.ErrVoteNotFound)
+
+	// Missing comment
+	_, err = postRepo.UpvoteComment(ctx, post, post.ID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Placeholders of deleted comments are not voted on
+	deleted := deepCopyPost(expectedPosts[0])
+	deleted.Comments[0].Deleted = true
+	_, err = postRepo.DownvoteComment(ctx, deleted, commentID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Bad payload
+	_, err = postRepo.DownvoteComment(context.Background(), post, commentID)
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+
+	// Update error
+	commentCollection.EXPECT().UpdateOne(ctx, noVote, gomock.Any()).Return(int64(0), errSimulatedErr)
+	_, err = postRepo.UpvoteComment(ctx, deepCopyPost(expectedPosts[0]), commentID)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// The vote has been changed by a concurrent request of the same user
+	commentCollection.EXPECT().UpdateOne(ctx, noVote, gomock.Any()).Return(int64(0), nil)
+	_, err = postRepo.UpvoteComment(ctx, deepCopyPost(expectedPosts[0]), commentID)
+	assert.ErrorIs(t, err, errs.ErrVoteConflict)
+
+	post = deepCopyPost(expectedPosts[0])
+	post.Comments[0].Votes = posts.Votes{tokenPayloadUser.ID: &posts.PostVote{UserID: tokenPayloadUser.ID, Vote: 1}}
+	commentCollection.EXPECT().UpdateOne(ctx, voted(1), gomock.Any()).Return(int64(0), nil)
+	_, err = postRepo.DownvoteComment(ctx, post, commentID)
+	assert.ErrorIs(t, err, errs.ErrVoteConflict)
+
+	post = deepCopyPost(expectedPosts[0])
+	post.Comments[0].Votes = posts.Votes{tokenPayloadUser.ID: &posts.PostVote{UserID: tokenPayloadUser.ID, Vote: 1}}
+	commentCollection.EXPECT().UpdateOne(ctx, votedFilter(1), gomock.Any()).Return(int64(0), nil)
+	_, err = postRepo.UnvoteComment(ctx, post, commentID)
+	assert.ErrorIs(t, err, errs.ErrVoteConflict)
+}
+
+func TestGetKarma(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		post := deepCopyPost(expectedPosts[0])
+		post.Comments[0].Votes = posts.Votes{
+			tokenPayloadUser.ID: &posts.PostVote{UserID: tokenPayloadUser.ID, Vote: -1},
+		}
+		post.Comments[0].Score = -1
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, toBSON(post)),
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, toBSON(post.Comments[0])),
+		)
+
+		// The vote of the author on the post does not count, the vote of another user on the comment does
+		karma, err := postRepo.GetKarma(context.Background(), tokenPayloadAdmin.Login)
+		assert.NoError(t, err)
+		assert.Equal(t, users.Karma{Post: 1, Comment: -1, Total: 0}, karma)
+	})
+
+	mt.Run(t.Name()+"_find_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
+			Message: findInternalErr,
+		}))
+
+		_, err := postRepo.GetKarma(context.Background(), tokenPayloadAdmin.Login)
+		assert.ErrorContains(t, err, findInternalErr)
+	})
+}
@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+// commentTree is a top-level comment with a reply and a reply to the reply, and one more top-level comment
+func commentTree(postID users.ID) (top, reply, nested, other *posts.PostComment) {
+	created := time.Date(2024, time.February, 20, 10, 0, 0, 0, time.UTC)
+	comment := func(id, parentID users.ID, depth, minutes int) *posts.PostComment {
+		return &posts.PostComment{
+			ID:       id,
+			PostID:   postID,
+			ParentID: parentID,
+			Depth:    depth,
+			Author:   *tokenPayloadUser,
+			Body:     string(id),
+			Created:  created.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+
+	top = comment("33333333-3333-3333-3333-333333333333", "", 0, 0)
+	reply = comment("44444444-4444-4444-4444-444444444444", top.ID, 1, 1)
+	nested = comment("55555555-5555-5555-5555-555555555555", reply.ID, 2, 2)
+	other = comment("66666666-6666-6666-6666-666666666666", "", 0, 3)
+
+	return top, reply, nested, other
+}
+
+func TestGetComments(t *testing.T) { //nolint:funlen
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	postID := expectedPosts[0].ID
+	top, reply, nested, other := commentTree(postID)
+	query := posts.CommentQuery{Sort: posts.SortTop, Limit: 1}
+
+	// The top-level comments are sorted and paged in the database, one more is asked for to tell a next page
+	commentCollection.EXPECT().Aggregate(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, pipeline any, _ ...*options.AggregateOptions) (storage.AbstractCursor, error) {
+			stages := pipeline.(mongo.Pipeline)
+			assert.Equal(t, bson.D{{Key: "$match", Value: bson.M{"postId": postID, "parentId": bson.M{"$in": bson.A{nil, ""}}}}}, stages[0])
+			assert.Equal(t, bson.D{{Key: "$addFields", Value: bson.M{"sortKey": "$score"}}}, stages[1])
+			assert.Equal(t, bson.D{{Key: "$sort", Value: bson.D{
+				{Key: "sortKey", Value: -1}, {Key: "created", Value: 1}, {Key: "uuid", Value: 1},
+			}}}, stages[2])
+			assert.Equal(t, bson.D{{Key: "$limit", Value: 2}}, stages[3])
+			return cursor, nil
+		})
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{top, other}).Return(nil)
+	// The replies are loaded level by level
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{top.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{reply}).Return(nil)
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{reply.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{nested}).Return(nil)
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{nested.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{}).Return(nil)
+
+	page, err := postRepo.GetComments(ctx, postID, query)
+	assert.NoError(t, err)
+	assert.Equal(t, []*posts.PostComment{top, reply, nested}, page.Comments)
+	assert.Equal(t, top.ID, page.After)
+
+	// The last page has no cursor
+	commentCollection.EXPECT().Aggregate(ctx, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{}).Return(nil)
+
+	page, err = postRepo.GetComments(ctx, postID, query)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Comments)
+	assert.Empty(t, page.After)
+
+	// Errors
+	commentCollection.EXPECT().Aggregate(ctx, gomock.Any()).Return(nil, errSimulatedErr)
+	_, err = postRepo.GetComments(ctx, postID, query)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	commentCollection.EXPECT().Aggregate(ctx, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(errSimulatedErr)
+	_, err = postRepo.GetComments(ctx, postID, query)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	commentCollection.EXPECT().Aggregate(ctx, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{top}).Return(nil)
+	commentCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, errSimulatedErr)
+	_, err = postRepo.GetComments(ctx, postID, query)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestGetCommentsAfter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	postID := expectedPosts[0].ID
+	_, _, _, other := commentTree(postID)
+
+	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, bson.D{
+				{Key: "uuid", Value: "33333333-3333-3333-3333-333333333333"},
+				{Key: "created", Value: other.Created.Add(-3 * time.Minute)},
+				{Key: "sortKey", Value: 0.5},
+			}),
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, toBSON(other)),
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch),
+		)
+
+		page, err := postRepo.GetComments(context.Background(), postID, posts.CommentQuery{
+			Sort:  posts.SortBest,
+			Limit: 1,
+			After: "33333333-3333-3333-3333-333333333333",
+		})
+		assert.NoError(t, err)
+		assert.Len(t, page.Comments, 1)
+		assert.Equal(t, other.ID, page.Comments[0].ID)
+		assert.Empty(t, page.After)
+	})
+
+	mt.Run(t.Name()+"_bad_cursor", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch))
+
+		page, err := postRepo.GetComments(context.Background(), postID, posts.CommentQuery{
+			Sort:  posts.SortNew,
+			Limit: 1,
+			After: other.ID,
+		})
+		assert.Nil(t, page)
+		assert.ErrorIs(t, err, errs.ErrBadCommentCursor)
+	})
+}
+
+func TestLoadCommentThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	singleResult := mocks.NewMockAbstractSingleResult(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	post := withoutComments(expectedPosts[0])[0]
+	top, reply, nested, _ := commentTree(post.ID)
+	sibling := *reply
+	sibling.ID = "77777777-7777-7777-7777-777777777777"
+	sibling.Created = nested.Created.Add(time.Minute)
+
+	// The comment and the comments above it
+	commentCollection.EXPECT().FindOne(ctx, bson.M{"uuid": reply.ID, "postId": post.ID}).Return(singleResult)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, *reply).Return(nil)
+	commentCollection.EXPECT().FindOne(ctx, bson.M{"uuid": top.ID, "postId": post.ID}).Return(singleResult)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, *top).Return(nil)
+	// The replies to the comments above it
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{top.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{reply, &sibling}).Return(nil)
+	// All the replies under it
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{reply.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{nested}).Return(nil)
+	commentCollection.EXPECT().Find(ctx, bson.M{"parentId": bson.M{"$in": []users.ID{nested.ID}}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{}).Return(nil)
+
+	loaded, err := postRepo.LoadCommentThread(ctx, post, reply.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []users.ID{top.ID, reply.ID, nested.ID, sibling.ID}, commentIDs(loaded.Comments))
+	assert.Empty(t, post.Comments)
+
+	// A comment under another post
+	commentCollection.EXPECT().FindOne(ctx, gomock.Any()).Return(singleResult)
+	singleResult.EXPECT().Decode(gomock.Any()).Return(mongo.ErrNoDocuments)
+
+	loaded, err = postRepo.LoadCommentThread(ctx, post, reply.ID)
+	assert.NoError(t, err)
+	_, err = loaded.GetComment(reply.ID)
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	commentCollection.EXPECT().FindOne(ctx, gomock.Any()).Return(singleResult)
+	singleResult.EXPECT().Decode(gomock.Any()).Return(errSimulatedErr)
+
+	loaded, err = postRepo.LoadCommentThread(ctx, post, reply.ID)
+	assert.Nil(t, loaded)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestGetCommentsByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	top, _, _, other := commentTree(expectedPosts[0].ID)
+	ids := []users.ID{top.ID, other.ID}
+
+	commentCollection.EXPECT().Find(ctx, bson.M{"uuid": bson.M{"$in": ids}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.PostComment{top, other}).Return(nil)
+
+	comments, err := postRepo.GetCommentsByIDs(ctx, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []*posts.PostComment{top, other}, comments)
+
+	commentCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, errSimulatedErr)
+
+	comments, err = postRepo.GetCommentsByIDs(ctx, ids)
+	assert.Nil(t, comments)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func commentIDs(comments []*posts.PostComment) []users.ID {
+	ids := make([]users.ID, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,300 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+)
+
+const (
+	selectCommunityQuery  = "SELECT uuid, name, description, creator_uuid, creator_login, nsfw FROM communities WHERE name = ?"
+	selectModeratorsQuery = "SELECT user_uuid, user_login FROM community_moderators WHERE community_uuid = ?"
+	selectRulesQuery      = "SELECT title, description FROM community_rules WHERE community_uuid = ? ORDER BY position"
+	selectFlairsQuery     = "SELECT uuid, text, color FROM community_flairs WHERE community_uuid = ? ORDER BY id"
+)
+
+var (
+	expectedCommunity = &communities.Community{
+		ID:          "00000000-0000-0000-0000-000000000001",
+		Name:        "music",
+		Description: "Everything about music",
+		Creator:     *tokenPayloadAdmin,
+		Moderators:  []jwt.TokenPayload{*tokenPayloadAdmin},
+		Rules: []communities.Rule{
+			{Title: "Be nice", Description: "No insults"},
+		},
+		Flairs: []communities.Flair{
+			{ID: "00000000-0000-0000-0000-00000000f1a1", Text: "Discussion", Color: "#ff4500"},
+		},
+	}
+	communityPayload = communities.CommunityPayload{
+		Name:        "golang",
+		Description: "Gophers only",
+		Rules: []communities.Rule{
+			{Title: "Be nice"},
+		},
+	}
+)
+
+func expectCommunityDetails(mock sqlmock.Sqlmock, community *communities.Community) {
+	moderators := sqlmock.NewRows([]string{"user_uuid", "user_login"})
+	for _, moderator := range community.Moderators {
+		moderators.AddRow(moderator.ID, moderator.Login)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(selectModeratorsQuery)).
+		WithArgs(community.ID).
+		WillReturnRows(moderators)
+
+	rules := sqlmock.NewRows([]string{"title", "description"})
+	for _, rule := range community.Rules {
+		rules.AddRow(rule.Title, rule.Description)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(selectRulesQuery)).
+		WithArgs(community.ID).
+		WillReturnRows(rules)
+
+	flairs := sqlmock.NewRows([]string{"uuid", "text", "color"})
+	for _, flair := range community.Flairs {
+		flairs.AddRow(flair.ID, flair.Text, flair.Color)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(selectFlairsQuery)).
+		WithArgs(community.ID).
+		WillReturnRows(flairs)
+}
+
+func TestGetCommunityByName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	communityRepo := storage.NewCommunityRepoMySQL(db)
+
+	// Success
+	rows := sqlmock.NewRows([]string{"uuid", "name", "description", "creator_uuid", "creator_login", "nsfw"}).
+		AddRow(
+			expectedCommunity.ID,
+			expectedCommunity.Name,
+			expectedCommunity.Description,
+			expectedCommunity.Creator.ID,
+			expectedCommunity.Creator.Login,
+			expectedCommunity.NSFW,
+		)
+	mock.ExpectQuery(regexp.QuoteMeta(selectCommunityQuery)).
+		WithArgs(expectedCommunity.Name).
+		WillReturnRows(rows)
+	expectCommunityDetails(mock, expectedCommunity)
+
+	community, err := communityRepo.GetCommunityByName(context.Background(), expectedCommunity.Name)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, expectedCommunity, community)
+
+	// No rows
+	mock.ExpectQuery(regexp.QuoteMeta(selectCommunityQuery)).
+		WithArgs("ski").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = communityRepo.GetCommunityByName(context.Background(), "ski")
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, errs.ErrCommunityNotFound)
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta(selectCommunityQuery)).
+		WithArgs(expectedCommunity.Name).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = communityRepo.GetCommunityByName(context.Background(), expectedCommunity.Name)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.EqualError(t, err, "db_error")
+}
+
+func TestGetAllCommunities(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	communityRepo := storage.NewCommunityRepoMySQL(db)
+
+	// Success
+	rows := sqlmock.NewRows([]string{"uuid", "name", "description", "creator_uuid", "creator_login", "nsfw"}).
+		AddRow(
+			expectedCommunity.ID,
+			expectedCommunity.Name,
+			expectedCommunity.Description,
+			expectedCommunity.Creator.ID,
+			expectedCommunity.Creator.Login,
+			expectedCommunity.NSFW,
+		)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid, name, description, creator_uuid, creator_login, nsfw FROM communities ORDER BY name")).
+		WillReturnRows(rows)
+	expectCommunityDetails(mock, expectedCommunity)
+
+	communityList, err := communityRepo.GetAllCommunities(context.Background())
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []*communities.Community{expectedCommunity}, communityList)
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid, name, description, creator_uuid, creator_login, nsfw FROM communities ORDER BY name")).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = communityRepo.GetAllCommunities(context.Background())
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.EqualError(t, err, "db_error")
+}
+
+func TestCreateCommunity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	communityRepo := storage.NewCommunityRepoMySQL(db)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+
+	// Bad payload
+	_, err = communityRepo.CreateCommunity(context.Background(), communityPayload)
+
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+
+	// Already exists
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM communities WHERE name = ?)")).
+		WithArgs(communityPayload.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	_, err = communityRepo.CreateCommunity(ctx, communityPayload)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, errs.ErrCommunityExists)
+
+	// Insert error
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM communities WHERE name = ?)")).
+		WithArgs(communityPayload.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO communities")).
+		WillReturnError(errors.New("db_error"))
+	mock.ExpectRollback()
+
+	_, err = communityRepo.CreateCommunity(ctx, communityPayload)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Contains(t, err.Error(), "db_error")
+
+	// Created concurrently after the check
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM communities WHERE name = ?)")).
+		WithArgs(communityPayload.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO communities")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	mock.ExpectRollback()
+
+	_, err = communityRepo.CreateCommunity(ctx, communityPayload)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, errs.ErrCommunityExists)
+
+	// Success
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM communities WHERE name = ?)")).
+		WithArgs(communityPayload.Name).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO communities")).
+		WithArgs(sqlmock.AnyArg(), communityPayload.Name, communityPayload.Description, tokenPayloadUser.ID, tokenPayloadUser.Login, communityPayload.NSFW).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO community_moderators")).
+		WithArgs(sqlmock.AnyArg(), tokenPayloadUser.ID, tokenPayloadUser.Login).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO community_rules")).
+		WithArgs(sqlmock.AnyArg(), 0, communityPayload.Rules[0].Title, communityPayload.Rules[0].Description).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	community, err := communityRepo.CreateCommunity(ctx, communityPayload)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, communityPayload.Name, community.Name)
+	assert.Equal(t, []jwt.TokenPayload{*tokenPayloadUser}, community.Moderators)
+}
+
+func TestCommunityFlairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	communityRepo := storage.NewCommunityRepoMySQL(db)
+	community := &communities.Community{ID: expectedCommunity.ID, Flairs: make([]communities.Flair, 0)}
+	flair := communities.Flair{ID: "00000000-0000-0000-0000-00000000f1a2", Text: "News", Color: "#0079d3"}
+
+	// Add error
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO community_flairs")).
+		WithArgs(flair.ID, community.ID, flair.Text, flair.Color).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = communityRepo.AddFlair(context.Background(), community, flair)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Add
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO community_flairs")).
+		WithArgs(flair.ID, community.ID, flair.Text, flair.Color).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	community, err = communityRepo.AddFlair(context.Background(), community, flair)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []communities.Flair{flair}, community.Flairs)
+
+	// Delete unknown flair
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM community_flairs WHERE community_uuid = ? AND uuid = ?")).
+		WithArgs(community.ID, expectedCommunity.Flairs[0].ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = communityRepo.DeleteFlair(context.Background(), community, expectedCommunity.Flairs[0].ID)
+
+	assert.ErrorIs(t, err, errs.ErrInvalidFlair)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Delete
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM community_flairs WHERE community_uuid = ? AND uuid = ?")).
+		WithArgs(community.ID, flair.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	community, err = communityRepo.DeleteFlair(context.Background(), community, flair.ID)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Empty(t, community.Flairs)
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+func TestDraftRepoMongoDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	singleResult := mocks.NewMockAbstractSingleResult(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	draftRepo := storage.NewDraftRepoMongoDB(abstractCollection)
+	ctx := context.Background()
+	draft := posts.NewDraft(*tokenPayloadUser, posts.PostPayload{Title: "Work in progress"})
+	filter := bson.M{"uuid": draft.ID}
+
+	// Add
+	abstractCollection.EXPECT().InsertOne(ctx, draft).Return(nil, nil)
+	assert.NoError(t, draftRepo.AddDraft(ctx, draft))
+
+	abstractCollection.EXPECT().InsertOne(ctx, draft).Return(nil, errSimulatedErr)
+	assert.ErrorIs(t, draftRepo.AddDraft(ctx, draft), errSimulatedErr)
+
+	// Get
+	abstractCollection.EXPECT().FindOne(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(nil)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, *draft).Return(nil)
+
+	found, err := draftRepo.GetDraft(ctx, draft.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, draft, found)
+
+	abstractCollection.EXPECT().FindOne(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(mongo.ErrNoDocuments)
+
+	_, err = draftRepo.GetDraft(ctx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+
+	// List
+	abstractCollection.EXPECT().Find(ctx, bson.M{"author.id": tokenPayloadUser.ID}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+
+	_, err = draftRepo.GetDrafts(ctx, tokenPayloadUser.ID)
+	assert.NoError(t, err)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(errSimulatedErr)
+
+	_, err = draftRepo.GetDrafts(ctx, tokenPayloadUser.ID)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Update
+	update := bson.M{"$set": bson.M{"post": draft.Post, "updated": draft.Updated}}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+	assert.NoError(t, draftRepo.UpdateDraft(ctx, draft))
+
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), nil)
+	assert.ErrorIs(t, draftRepo.UpdateDraft(ctx, draft), errs.ErrDraftNotFound)
+
+	// Delete
+	abstractCollection.EXPECT().DeleteOne(ctx, filter).Return(int64(1), nil)
+	assert.NoError(t, draftRepo.DeleteDraft(ctx, draft.ID))
+
+	abstractCollection.EXPECT().DeleteOne(ctx, filter).Return(int64(0), nil)
+	assert.ErrorIs(t, draftRepo.DeleteDraft(ctx, draft.ID), errs.ErrDraftNotFound)
+
+	// Claim
+	abstractCollection.EXPECT().FindOneAndDelete(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(nil)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, *draft).Return(nil)
+
+	claimed, err := draftRepo.ClaimDraft(ctx, draft.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, draft, claimed)
+
+	abstractCollection.EXPECT().FindOneAndDelete(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(mongo.ErrNoDocuments)
+
+	_, err = draftRepo.ClaimDraft(ctx, draft.ID)
+	assert.ErrorIs(t, err, errs.ErrDraftNotFound)
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+func TestMentionRepoMongoDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	mentionRepo := storage.NewMentionRepoMongoDB(abstractCollection)
+	ctx := context.Background()
+	events := posts.NewMentionEvents(*tokenPayloadAdmin, expectedPosts[0].ID, "", []jwt.TokenPayload{*tokenPayloadUser}, nil)
+
+	// Indexes
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("mentions_user", nil)
+	assert.NoError(t, mentionRepo.CreateIndexes(ctx))
+
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("", errSimulatedErr)
+	assert.ErrorIs(t, mentionRepo.CreateIndexes(ctx), errSimulatedErr)
+
+	// Add
+	abstractCollection.EXPECT().BulkWrite(ctx, []mongo.WriteModel{mongo.NewInsertOneModel().SetDocument(events[0])}).Return(int64(0), nil)
+	assert.NoError(t, mentionRepo.AddMentions(ctx, events))
+
+	abstractCollection.EXPECT().BulkWrite(ctx, gomock.Any()).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, mentionRepo.AddMentions(ctx, events), errSimulatedErr)
+
+	// Nothing to add
+	assert.NoError(t, mentionRepo.AddMentions(ctx, nil))
+
+	// List
+	abstractCollection.EXPECT().Find(ctx, bson.M{"user": tokenPayloadUser.ID}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, events).Return(nil)
+
+	found, err := mentionRepo.GetMentions(ctx, tokenPayloadUser.ID, posts.NewPage(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, events, found)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, errSimulatedErr)
+	_, err = mentionRepo.GetMentions(ctx, tokenPayloadUser.ID, posts.NewPage(0, 0))
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(errSimulatedErr)
+	_, err = mentionRepo.GetMentions(ctx, tokenPayloadUser.ID, posts.NewPage(0, 0))
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
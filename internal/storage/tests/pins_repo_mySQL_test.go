@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+)
+
+var (
+	pinExpires = time.UnixMilli(1704251045123).UTC()
+	musicPins  = posts.Pins{
+		{
+			PostID:  "12345678-9abc-def1-2345-6789abcdef12",
+			Scope:   posts.Music,
+			Pinned:  time.UnixMilli(1704164645123).UTC(),
+			Expires: &pinExpires,
+		},
+		{
+			PostID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			Scope:  posts.Music,
+			Pinned: time.UnixMilli(1704164645456).UTC(),
+		},
+	}
+)
+
+func TestGetPins(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	pinRepo := storage.NewPinRepoMySQL(db)
+	selectQuery := "SELECT post_uuid, pinned_at, expires_at FROM pinned_posts WHERE scope = ? ORDER BY position"
+
+	// Success
+	rows := sqlmock.NewRows([]string{"post_uuid", "pinned_at", "expires_at"}).
+		AddRow(musicPins[0].PostID, musicPins[0].Pinned.UnixMilli(), musicPins[0].Expires.UnixMilli()).
+		AddRow(musicPins[1].PostID, musicPins[1].Pinned.UnixMilli(), 0)
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.Music).WillReturnRows(rows)
+
+	pins, err := pinRepo.GetPins(context.Background(), posts.Music)
+
+	assert.NoError(t, err)
+	assert.Equal(t, musicPins, pins)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.FrontPage).WillReturnError(errors.New("db_error"))
+
+	_, err = pinRepo.GetPins(context.Background(), posts.FrontPage)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePins(t *testing.T) { //nolint:funlen
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	pinRepo := storage.NewPinRepoMySQL(db)
+	selectQuery := "SELECT post_uuid, pinned_at, expires_at FROM pinned_posts WHERE scope = ? ORDER BY position FOR UPDATE"
+	deleteQuery := "DELETE FROM pinned_posts WHERE scope = ?"
+	insertQuery := "INSERT INTO pinned_posts (scope, post_uuid, position, pinned_at, expires_at) VALUES (?, ?, ?, ?, ?)"
+	pinned := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"post_uuid", "pinned_at", "expires_at"}).
+			AddRow(musicPins[0].PostID, musicPins[0].Pinned.UnixMilli(), musicPins[0].Expires.UnixMilli())
+	}
+
+	// Success, the update gets the pins locked in the transaction
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.Music).WillReturnRows(pinned())
+	mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(posts.Music).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(posts.Music, musicPins[0].PostID, 0, musicPins[0].Pinned.UnixMilli(), musicPins[0].Expires.UnixMilli()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(posts.Music, musicPins[1].PostID, 1, musicPins[1].Pinned.UnixMilli(), 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = pinRepo.UpdatePins(context.Background(), posts.Music, func(pins posts.Pins) (posts.Pins, error) {
+		assert.Equal(t, musicPins[:1], pins)
+		return append(pins, musicPins[1]), nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Too many pins are refused
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.Music).WillReturnRows(pinned())
+	mock.ExpectRollback()
+
+	err = pinRepo.UpdatePins(context.Background(), posts.Music, func(pins posts.Pins) (posts.Pins, error) {
+		return make(posts.Pins, posts.MaxPins+1), nil
+	})
+
+	assert.ErrorIs(t, err, errs.ErrTooManyPins)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Update error
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.Music).WillReturnRows(pinned())
+	mock.ExpectRollback()
+
+	err = pinRepo.UpdatePins(context.Background(), posts.Music, func(pins posts.Pins) (posts.Pins, error) {
+		return nil, errs.ErrPinNotFound
+	})
+
+	assert.ErrorIs(t, err, errs.ErrPinNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Insert error rolls the transaction back
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs(posts.Music).WillReturnRows(pinned())
+	mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(posts.Music).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(posts.Music, musicPins[0].PostID, 0, musicPins[0].Pinned.UnixMilli(), musicPins[0].Expires.UnixMilli()).
+		WillReturnError(errors.New("db_error"))
+	mock.ExpectRollback()
+
+	err = pinRepo.UpdatePins(context.Background(), posts.Music, func(pins posts.Pins) (posts.Pins, error) {
+		return pins, nil
+	})
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Begin error
+	mock.ExpectBegin().WillReturnError(errors.New("db_error"))
+
+	err = pinRepo.UpdatePins(context.Background(), posts.Music, nil)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
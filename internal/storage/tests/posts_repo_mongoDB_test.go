@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
 	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
 	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
 	"github.com/Benzogang-Tape/Reddit/internal/storage"
 	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
 )
@@ -41,16 +45,17 @@ var (
 			},
 			Comments: []*posts.PostComment{
 				{
-					Created: "2024-02-20T10:21:54.716Z",
+					Created: time.Date(2024, 2, 20, 10, 21, 54, 716e6, time.UTC),
 					Author: jwt.TokenPayload{
 						Login: "admin",
 						ID:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
 					},
-					Body: "We love music of Kartik.\nThanks.",
-					ID:   "22222222-2222-2222-2222-222222222222",
+					Body:   "We love music of Kartik.\nThanks.",
+					ID:     "22222222-2222-2222-2222-222222222222",
+					PostID: "11111111-1111-1111-1111-111111111111",
 				},
 			},
-			Created:          "2024-02-20T10:21:04.716Z",
+			Created:          time.Date(2024, 2, 20, 10, 21, 4, 716e6, time.UTC),
 			UpvotePercentage: 100,
 		},
 		{
@@ -73,7 +78,7 @@ var (
 				},
 			},
 			Comments:         []*posts.PostComment{},
-			Created:          "1984-02-20T10:21:04.716Z",
+			Created:          time.Date(1984, 2, 20, 10, 21, 4, 716e6, time.UTC),
 			UpvotePercentage: 100,
 		},
 	}
@@ -103,6 +108,18 @@ func toBSON(data any) bson.D {
 	return doc
 }
 
+// withoutComments returns copies of the posts the way the posts collection keeps them, the comments are stored apart
+func withoutComments(postList ...*posts.Post) []*posts.Post {
+	stored := make([]*posts.Post, 0, len(postList))
+	for _, post := range postList {
+		cpy := *post
+		cpy.Comments = nil
+		stored = append(stored, &cpy)
+	}
+
+	return stored
+}
+
 func deepCopyPost(src *posts.Post) *posts.Post {
 	cpy := *src
 	comms := make([]*posts.PostComment, 0, len(src.Comments))
@@ -113,8 +130,11 @@ func deepCopyPost(src *posts.Post) *posts.Post {
 				Login: comm.Author.Login,
 				ID:    comm.Author.ID,
 			},
-			Body: comm.Body,
-			ID:   comm.ID,
+			Body:     comm.Body,
+			ID:       comm.ID,
+			PostID:   comm.PostID,
+			ParentID: comm.ParentID,
+			Depth:    comm.Depth,
 		})
 	}
 	cpy.Comments = comms
@@ -137,7 +157,7 @@ func TestGetAllPosts(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		responses := make([]bson.D, 0, len(expectedPosts)+1)
 
 		responses = append(responses,
@@ -151,30 +171,30 @@ func TestGetAllPosts(t *testing.T) {
 		responses = append(responses, mtest.CreateCursorResponse(1, "db.test", mtest.NextBatch))
 		mt.AddMockResponses(responses...)
 
-		posts, err := postRepo.GetAllPosts(context.Background())
+		posts, err := postRepo.GetAllPosts(context.Background(), posts.FeedQuery{})
 		assert.NoError(t, err)
 		assert.Equal(t, len(expectedPosts), len(posts))
-		assert.Equal(t, expectedPosts, posts)
+		assert.Equal(t, withoutComments(expectedPosts...), posts)
 	})
 
 	mt.Run(t.Name()+"_find_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		mt.AddMockResponses(mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
 			Message: findInternalErr,
 		}))
 
-		posts, err := postRepo.GetAllPosts(context.Background())
+		posts, err := postRepo.GetAllPosts(context.Background(), posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), findInternalErr)
 	})
 
 	mt.Run(t.Name()+"_cursor_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		badRecord := mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(nil))
 		mt.AddMockResponses(badRecord)
 
-		posts, err := postRepo.GetAllPosts(context.Background())
+		posts, err := postRepo.GetAllPosts(context.Background(), posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), "no responses remaining")
@@ -188,7 +208,7 @@ func TestGetPostsByCategory(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		responses := make([]bson.D, 0, len(expectedPosts)+1)
 		responses = append(responses,
 			mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(expectedPosts[0])),
@@ -196,8 +216,8 @@ func TestGetPostsByCategory(t *testing.T) {
 		responses = append(responses, mtest.CreateCursorResponse(1, "db.test", mtest.NextBatch))
 		mt.AddMockResponses(responses...)
 
-		expected := []*posts.Post{expectedPosts[0]}
-		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music)
+		expected := withoutComments(expectedPosts[0])
+		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(expected), len(posts))
@@ -205,23 +225,23 @@ func TestGetPostsByCategory(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_find_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		mt.AddMockResponses(mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
 			Message: findInternalErr,
 		}))
 
-		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music)
+		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), findInternalErr)
 	})
 
 	mt.Run(t.Name()+"_cursor_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		badRecord := mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(nil))
 		mt.AddMockResponses(badRecord)
 
-		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music)
+		posts, err := postRepo.GetPostsByCategory(context.Background(), posts.Music, posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), "no responses remaining")
@@ -236,7 +256,7 @@ func TestGetPostsByUser(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		responses := make([]bson.D, 0, len(expectedPosts)+1)
 		responses = append(responses,
 			mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(expectedPosts[0])),
@@ -245,8 +265,8 @@ func TestGetPostsByUser(t *testing.T) {
 		responses = append(responses, mtest.CreateCursorResponse(1, "db.test", mtest.NextBatch))
 		mt.AddMockResponses(responses...)
 
-		expected := []*posts.Post{expectedPosts[0], expectedPosts[1]}
-		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login)
+		expected := withoutComments(expectedPosts[0], expectedPosts[1])
+		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login, posts.FeedQuery{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(expected), len(posts))
@@ -254,23 +274,23 @@ func TestGetPostsByUser(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_find_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		mt.AddMockResponses(mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
 			Message: findInternalErr,
 		}))
 
-		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login)
+		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login, posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), findInternalErr)
 	})
 
 	mt.Run(t.Name()+"_cursor_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		badRecord := mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(nil))
 		mt.AddMockResponses(badRecord)
 
-		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login)
+		posts, err := postRepo.GetPostsByUser(context.Background(), tokenPayloadAdmin.Login, posts.FeedQuery{})
 		assert.Error(t, err)
 		assert.Nil(t, posts)
 		assert.Contains(t, err.Error(), "no responses remaining")
@@ -283,13 +303,14 @@ func TestGetPostByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 	singleResult := mocks.NewMockAbstractSingleResult(ctrl)
 
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
-		expected := deepCopyPost(expectedPosts[0])
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+		expected := withoutComments(expectedPosts[0])[0]
 		filter := bson.M{"uuid": expected.ID}
 		abstractCollection.EXPECT().FindOne(context.Background(), filter).Return(singleResult)
 		singleResult.EXPECT().Err().Return(nil)
@@ -301,7 +322,7 @@ func TestGetPostByID(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_post_not_found", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 
 		abstractCollection.EXPECT().FindOne(context.Background(), gomock.Any()).Return(singleResult)
 		singleResult.EXPECT().Err().Return(mongo.ErrNoDocuments)
@@ -313,7 +334,7 @@ func TestGetPostByID(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_decode_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 
 		abstractCollection.EXPECT().FindOne(context.Background(), gomock.Any()).Return(singleResult)
 		singleResult.EXPECT().Err().Return(nil)
@@ -333,7 +354,7 @@ func TestCreatePost(t *testing.T) {
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		expected := expectedPosts[1]
 		ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 
@@ -346,7 +367,7 @@ func TestCreatePost(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_bad_payload", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		ctx := context.WithValue(context.Background(), jwt.Payload, "bad payload")
 
 		post, err := postRepo.CreatePost(ctx, postPayload)
@@ -356,7 +377,7 @@ func TestCreatePost(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_insert_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 
 		mt.AddMockResponses(mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
@@ -375,21 +396,33 @@ func TestDeletePost(t *testing.T) {
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 
 		filter := bson.M{"uuid": expectedPosts[0].ID}
 		abstractCollection.EXPECT().DeleteOne(context.Background(), filter).Return(int64(1), nil)
+		commentCollection.EXPECT().DeleteMany(context.Background(), bson.M{"postId": expectedPosts[0].ID}).Return(int64(1), nil)
 
 		err := postRepo.DeletePost(context.Background(), expectedPosts[0].ID)
 		assert.NoError(t, err)
 	})
 
+	mt.Run(t.Name()+"_delete_comments_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+
+		abstractCollection.EXPECT().DeleteOne(context.Background(), gomock.Any()).Return(int64(1), nil)
+		commentCollection.EXPECT().DeleteMany(context.Background(), gomock.Any()).Return(int64(0), errSimulatedErr)
+
+		err := postRepo.DeletePost(context.Background(), expectedPosts[0].ID)
+		assert.ErrorIs(t, err, errSimulatedErr)
+	})
+
 	mt.Run(t.Name()+"_delete_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 
 		abstractCollection.EXPECT().DeleteOne(context.Background(), gomock.Any()).Return(int64(0), errSimulatedErr)
 
@@ -399,7 +432,7 @@ func TestDeletePost(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_post_not_found", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 
 		abstractCollection.EXPECT().DeleteOne(context.Background(), gomock.Any()).Return(int64(0), nil)
 
@@ -414,28 +447,55 @@ func TestAddComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	commentBody := "comment body"
 	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		filter := bson.M{"uuid": expected.ID}
 
-		abstractCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).Return(int64(1), nil)
-		updatedPost.AddComment(*tokenPayloadAdmin, commentBody)
+		commentCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+		commentCollection.EXPECT().CountDocuments(ctx, bson.M{"postId": expected.ID}).Return(int64(2), nil)
+		abstractCollection.EXPECT().UpdateOne(ctx, filter, bson.M{"$set": bson.M{"commentCount": int64(2)}}).Return(int64(1), nil)
+		updatedPost.AddComment(*tokenPayloadAdmin, posts.Comment{Body: commentBody})
 
 		post, err := postRepo.AddComment(ctx, expected, posts.Comment{Body: commentBody})
 		assert.NoError(t, err)
 		assert.Equal(t, updatedPost.Comments[len(updatedPost.Comments)-1].Body, post.Comments[len(post.Comments)-1].Body)
 		assert.Equal(t, updatedPost.Comments[len(updatedPost.Comments)-1].Author, post.Comments[len(post.Comments)-1].Author)
+		assert.Equal(t, expected.ID, post.Comments[len(post.Comments)-1].PostID)
+		// The number is recounted, not incremented
+		assert.Equal(t, 2, post.CommentCount)
+	})
+
+	mt.Run(t.Name()+"_reply", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+		expected := deepCopyPost(expectedPosts[0])
+		parent := expected.Comments[0]
+
+		commentCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+		commentCollection.EXPECT().CountDocuments(ctx, gomock.Any()).Return(int64(3), nil)
+		abstractCollection.EXPECT().UpdateOne(ctx, bson.M{"uuid": expected.ID}, gomock.Any()).Return(int64(1), nil)
+
+		post, err := postRepo.AddComment(ctx, expected, posts.Comment{Body: commentBody, ParentID: parent.ID})
+		assert.NoError(t, err)
+		reply := post.Comments[len(post.Comments)-1]
+		assert.Equal(t, parent.ID, reply.ParentID)
+		assert.Equal(t, parent.Depth+1, reply.Depth)
+
+		// The parent must be on the same post
+		post, err = postRepo.AddComment(ctx, expected, posts.Comment{Body: commentBody, ParentID: expectedPosts[1].ID})
+		assert.ErrorIs(t, err, errs.ErrBadParentComment)
+		assert.Nil(t, post)
 	})
 
 	mt.Run(t.Name()+"_bad_payload", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		badCTX := context.WithValue(context.Background(), jwt.Payload, "bad payload")
 
 		post, err := postRepo.AddComment(badCTX, expectedPosts[0], posts.Comment{Body: commentBody})
@@ -444,16 +504,36 @@ func TestAddComment(t *testing.T) {
 		assert.ErrorIs(t, err, errs.ErrBadPayload)
 	})
 
-	mt.Run(t.Name()+"_update_comments_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+	mt.Run(t.Name()+"_insert_comment_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+
+		commentCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, errSimulatedErr)
+
+		post, err := postRepo.AddComment(ctx, deepCopyPost(expectedPosts[0]), posts.Comment{Body: commentBody})
+		assert.Error(t, err)
+		assert.Nil(t, post)
+		assert.ErrorIs(t, err, errSimulatedErr)
+	})
+
+	mt.Run(t.Name()+"_count_comments_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		filter := bson.M{"uuid": expectedPosts[0].ID}
 
+		commentCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+		commentCollection.EXPECT().CountDocuments(ctx, gomock.Any()).Return(int64(2), nil)
 		abstractCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).Return(int64(0), errSimulatedErr)
 
-		post, err := postRepo.AddComment(ctx, expectedPosts[0], posts.Comment{Body: commentBody})
+		post, err := postRepo.AddComment(ctx, deepCopyPost(expectedPosts[0]), posts.Comment{Body: commentBody})
 		assert.Error(t, err)
 		assert.Nil(t, post)
 		assert.ErrorIs(t, err, errSimulatedErr)
+
+		commentCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+		commentCollection.EXPECT().CountDocuments(ctx, gomock.Any()).Return(int64(0), errSimulatedErr)
+
+		post, err = postRepo.AddComment(ctx, deepCopyPost(expectedPosts[0]), posts.Comment{Body: commentBody})
+		assert.Nil(t, post)
+		assert.ErrorIs(t, err, errSimulatedErr)
 	})
 }
 
@@ -462,18 +542,20 @@ func TestDeleteComment(t *testing.T) {
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
-		filter := bson.M{"uuid": expected.ID}
-		update := bson.M{"$pull": bson.M{"comments": bson.M{"uuid": expected.Comments[0].ID}}}
+		filter := bson.M{"uuid": bson.M{"$in": []users.ID{expected.Comments[0].ID}}}
 
-		abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+		commentCollection.EXPECT().DeleteMany(ctx, filter).Return(int64(1), nil)
+		commentCollection.EXPECT().CountDocuments(ctx, bson.M{"postId": expected.ID}).Return(int64(0), nil)
+		abstractCollection.EXPECT().UpdateOne(ctx, bson.M{"uuid": expected.ID}, bson.M{"$set": bson.M{"commentCount": int64(0)}}).Return(int64(1), nil)
 		updatedPost.DeleteComment(updatedPost.Comments[0].ID) //nolint:errcheck
 
 		post, err := postRepo.DeleteComment(ctx, expected, expected.Comments[0].ID)
@@ -482,8 +564,56 @@ func TestDeleteComment(t *testing.T) {
 		assert.Equal(t, updatedPost.Comments, post.Comments)
 	})
 
+	mt.Run(t.Name()+"_with_replies", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+		expected := deepCopyPost(expectedPosts[0])
+		parentID := expected.Comments[0].ID
+		reply, err := expected.AddComment(*tokenPayloadUser, posts.Comment{Body: "reply", ParentID: parentID})
+		assert.NoError(t, err)
+		filter := bson.M{"uuid": expected.ID}
+
+		// The parent is kept as a placeholder for the reply
+		commentCollection.EXPECT().UpdateOne(ctx, bson.M{"uuid": parentID}, bson.M{
+			"$set": bson.M{
+				"author":  jwt.TokenPayload{},
+				"body":    posts.DeletedPlaceholder,
+				"score":   0,
+				"deleted": true,
+			},
+			"$unset": bson.M{
+				"bodyHtml":  "",
+				"mentions":  "",
+				"edited":    "",
+				"revisions": "",
+				"votes":     "",
+			},
+		}).Return(int64(1), nil)
+
+		post, err := postRepo.DeleteComment(ctx, expected, parentID)
+		assert.NoError(t, err)
+		assert.Len(t, post.Comments, len(expectedPosts[0].Comments)+1)
+		assert.True(t, post.Comments[0].Deleted)
+		assert.Equal(t, posts.DeletedPlaceholder, post.Comments[0].Body)
+		assert.Empty(t, post.Comments[0].Author.Login)
+		assert.Empty(t, post.Comments[0].Votes)
+		assert.Zero(t, post.Comments[0].Score)
+
+		// A placeholder cannot be deleted again
+		_, err = postRepo.DeleteComment(ctx, expected, parentID)
+		assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+		// The placeholder goes along with its last reply
+		commentCollection.EXPECT().DeleteMany(ctx, bson.M{"uuid": bson.M{"$in": []users.ID{reply.ID, parentID}}}).Return(int64(2), nil)
+		commentCollection.EXPECT().CountDocuments(ctx, bson.M{"postId": expected.ID}).Return(int64(0), nil)
+		abstractCollection.EXPECT().UpdateOne(ctx, filter, bson.M{"$set": bson.M{"commentCount": int64(0)}}).Return(int64(1), nil)
+
+		post, err = postRepo.DeleteComment(ctx, expected, reply.ID)
+		assert.NoError(t, err)
+		assert.Len(t, post.Comments, len(expectedPosts[0].Comments)-1)
+	})
+
 	mt.Run(t.Name()+"_comment_not_found", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 
 		post, err := postRepo.DeleteComment(ctx, expected, expected.ID)
@@ -493,12 +623,11 @@ func TestDeleteComment(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_update_comments_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
-		filter := bson.M{"uuid": expected.ID}
-		update := bson.M{"$pull": bson.M{"comments": bson.M{"uuid": expected.Comments[0].ID}}}
+		filter := bson.M{"uuid": bson.M{"$in": []users.ID{expected.Comments[0].ID}}}
 
-		abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), errSimulatedErr)
+		commentCollection.EXPECT().DeleteMany(ctx, filter).Return(int64(0), errSimulatedErr)
 
 		post, err := postRepo.DeleteComment(ctx, expected, expected.Comments[0].ID)
 		assert.Error(t, err)
@@ -507,17 +636,59 @@ func TestDeleteComment(t *testing.T) {
 	})
 }
 
+func TestEditComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	post := deepCopyPost(expectedPosts[0])
+	commentID := post.Comments[0].ID
+	created := post.Comments[0].Created
+	filter := bson.M{"uuid": commentID}
+
+	// The previous body becomes a revision
+	commentCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, update any, _ ...*options.UpdateOptions) (int64, error) {
+			set := update.(bson.M)["$set"].(bson.M)
+			assert.Equal(t, "Edited", set["body"])
+			assert.NotZero(t, set["edited"])
+			assert.Contains(t, set, "mentions")
+			revision := update.(bson.M)["$push"].(bson.M)["revisions"].(*posts.CommentRevision)
+			assert.Equal(t, &posts.CommentRevision{Body: expectedPosts[0].Comments[0].Body, Created: created}, revision)
+			return 1, nil
+		})
+
+	updated, err := postRepo.EditComment(ctx, post, commentID, posts.Comment{Body: "Edited"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited", updated.Comments[0].Body)
+	assert.False(t, updated.Comments[0].Edited.IsZero())
+	assert.Len(t, updated.Comments[0].Revisions, 1)
+
+	// Missing comment
+	_, err = postRepo.EditComment(ctx, post, post.ID, posts.Comment{Body: "Edited"})
+	assert.ErrorIs(t, err, errs.ErrCommentNotFound)
+
+	// Update error
+	commentCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).Return(int64(0), errSimulatedErr)
+	_, err = postRepo.EditComment(ctx, post, commentID, posts.Comment{Body: "Again"})
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
 func TestUpvote(t *testing.T) { //nolint:funlen
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	ctxAdmin := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 	ctxUser := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
 
 	mt.Run(t.Name()+"_success_create", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		newVote, _ := updatedPost.Upvote(tokenPayloadUser.ID)
@@ -540,7 +711,7 @@ func TestUpvote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_create_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		newVote, _ := updatedPost.Upvote(tokenPayloadUser.ID)
@@ -564,7 +735,7 @@ func TestUpvote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_bad_payload", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		ctx := context.WithValue(context.Background(), jwt.Payload, "bad payload")
 
 		post, err := postRepo.Upvote(ctx, expectedPosts[0])
@@ -574,7 +745,7 @@ func TestUpvote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_success_update", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		expected.Votes[tokenPayloadAdmin.ID].Vote = -1
@@ -602,7 +773,7 @@ func TestUpvote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_update_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		expected.Votes[tokenPayloadAdmin.ID].Vote = -1
@@ -636,12 +807,13 @@ func TestDownVote(t *testing.T) { //nolint:funlen
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	ctxAdmin := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 	ctxUser := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
 
 	mt.Run(t.Name()+"_success_create", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		newVote, _ := updatedPost.Downvote(tokenPayloadUser.ID)
@@ -664,7 +836,7 @@ func TestDownVote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_create_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 		newVote, _ := updatedPost.Downvote(tokenPayloadUser.ID)
@@ -688,7 +860,7 @@ func TestDownVote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_bad_payload", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		ctx := context.WithValue(context.Background(), jwt.Payload, "bad payload")
 
 		post, err := postRepo.Downvote(ctx, expectedPosts[0])
@@ -698,7 +870,7 @@ func TestDownVote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_success_update", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 
@@ -723,7 +895,7 @@ func TestDownVote(t *testing.T) { //nolint:funlen
 	})
 
 	mt.Run(t.Name()+"_update_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 
@@ -754,12 +926,13 @@ func TestUnvote(t *testing.T) {
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
 	ctxAdmin := context.WithValue(context.Background(), jwt.Payload, tokenPayloadAdmin)
 	ctxUser := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 
@@ -785,7 +958,7 @@ func TestUnvote(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_bad_payload", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll))
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
 		ctx := context.WithValue(context.Background(), jwt.Payload, "bad payload")
 
 		post, err := postRepo.Unvote(ctx, expectedPosts[0])
@@ -795,7 +968,7 @@ func TestUnvote(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_vote_not_found", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 
 		post, err := postRepo.Unvote(ctxUser, expected)
@@ -805,7 +978,7 @@ func TestUnvote(t *testing.T) {
 	})
 
 	mt.Run(t.Name()+"_update_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
+		postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
 		expected := deepCopyPost(expectedPosts[0])
 		updatedPost := deepCopyPost(expected)
 
@@ -832,33 +1005,710 @@ func TestUnvote(t *testing.T) {
 	})
 }
 
-func TestUpdateViews(t *testing.T) {
+func TestAddViews(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	views := map[users.ID]uint{expectedPosts[0].ID: 3, expectedPosts[1].ID: 1}
+
+	// Success
+	abstractCollection.EXPECT().BulkWrite(ctx, gomock.Len(2), gomock.Any()).Return(int64(2), nil)
+	assert.NoError(t, postRepo.AddViews(ctx, views))
+
+	// Nothing to write
+	assert.NoError(t, postRepo.AddViews(ctx, nil))
+
+	// Write error
+	abstractCollection.EXPECT().BulkWrite(ctx, gomock.Any(), gomock.Any()).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, postRepo.AddViews(ctx, views), errSimulatedErr)
+}
+
+func TestSearchPosts(t *testing.T) { //nolint:funlen
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	query := posts.SearchQuery{
+		Text:     "kartik",
+		Category: posts.Music,
+		Page:     posts.NewPage(0, 0),
+	}
+	withRelevance := func(post *posts.Post, relevance float64) bson.D {
+		return append(toBSON(post), bson.E{Key: "relevance", Value: relevance})
+	}
+	commentHit := func(postID users.ID, relevance float64) bson.D {
+		return bson.D{{Key: "_id", Value: postID}, {Key: "relevance", Value: relevance}}
+	}
 
 	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
-		filter := bson.M{"uuid": expectedPosts[0].ID}
-		update := bson.M{"$inc": bson.M{"views": 1}}
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch),
+			mtest.CreateCursorResponse(1, "db.test", mtest.FirstBatch, toBSON(expectedPosts[0])),
+			mtest.CreateCursorResponse(0, "db.test", mtest.NextBatch),
+		)
+
+		postList, err := postRepo.SearchPosts(context.Background(), query)
+		assert.NoError(t, err)
+		assert.Equal(t, withoutComments(expectedPosts[0]), postList)
+	})
 
-		abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(1), nil)
+	mt.Run(t.Name()+"_comment_hits", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		responses := func() []bson.D {
+			return []bson.D{
+				mtest.CreateCursorResponse(0, "db.comments", mtest.FirstBatch,
+					commentHit(expectedPosts[1].ID, 2), commentHit(expectedPosts[0].ID, 1)),
+				mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, withRelevance(expectedPosts[0], 1.5)),
+				mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, toBSON(expectedPosts[0]), toBSON(expectedPosts[1])),
+			}
+		}
+
+		// A post found by its own text and by a comment sums up both relevances
+		mt.AddMockResponses(responses()...)
+		postList, err := postRepo.SearchPosts(context.Background(), query)
+		assert.NoError(t, err)
+		assert.Equal(t, withoutComments(expectedPosts[0], expectedPosts[1]), postList)
 
-		err := postRepo.UpdateViews(context.Background(), expectedPosts[0].ID)
+		// The page is taken from the merged hits
+		mt.AddMockResponses(responses()...)
+		postList, err = postRepo.SearchPosts(context.Background(), posts.SearchQuery{Text: query.Text, Page: posts.NewPage(1, 1)})
 		assert.NoError(t, err)
+		assert.Equal(t, withoutComments(expectedPosts[1]), postList)
 	})
 
-	mt.Run(t.Name()+"_update_error", func(mt *mtest.T) {
-		postRepo := storage.NewPostRepoMongoDB(abstractCollection)
-		filter := bson.M{"uuid": expectedPosts[0].ID}
-		update := bson.M{"$inc": bson.M{"views": 1}}
+	mt.Run(t.Name()+"_aggregate_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Message: findInternalErr}))
+
+		postList, err := postRepo.SearchPosts(context.Background(), query)
+		assert.Error(t, err)
+		assert.Nil(t, postList)
+		assert.Contains(t, err.Error(), findInternalErr)
+	})
 
-		abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(0), errSimulatedErr)
+	mt.Run(t.Name()+"_find_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.comments", mtest.FirstBatch),
+			mtest.CreateWriteConcernErrorResponse(mtest.WriteConcernError{
+				Message: findInternalErr,
+			}),
+		)
 
-		err := postRepo.UpdateViews(context.Background(), expectedPosts[0].ID)
+		postList, err := postRepo.SearchPosts(context.Background(), query)
 		assert.Error(t, err)
-		assert.ErrorIs(t, err, errSimulatedErr)
+		assert.Nil(t, postList)
+		assert.Contains(t, err.Error(), findInternalErr)
+	})
+}
+
+func TestGetPostsByURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	canonicalURL := "https://84.23.52.45:3000/createpost"
+	filter := bson.M{"canonicalUrl": canonicalURL}
+
+	// Success
+	abstractCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.Post{expectedPosts[1]}).Return(nil)
+
+	postList, err := postRepo.GetPostsByURL(ctx, canonicalURL)
+	assert.NoError(t, err)
+	assert.Equal(t, []*posts.Post{expectedPosts[1]}, postList)
+
+	// Find error
+	abstractCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(nil, errSimulatedErr)
+
+	_, err = postRepo.GetPostsByURL(ctx, canonicalURL)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Cursor error
+	abstractCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(errSimulatedErr)
+
+	_, err = postRepo.GetPostsByURL(ctx, canonicalURL)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestCreateIndexes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+
+	// Success
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("posts_text", nil)
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("posts_hidden_by", nil)
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("posts_canonical_url", nil)
+	commentCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("comments_uuid", nil)
+	commentCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("comments_post", nil)
+	commentCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("comments_parent", nil)
+	commentCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("comments_author", nil)
+	commentCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("comments_text", nil)
+
+	assert.NoError(t, postRepo.CreateIndexes(context.Background()))
+
+	// The text index of an older definition is replaced
+	conflict := mongo.CommandError{Code: 85, Message: "An equivalent index already exists with a different name and options"}
+	gomock.InOrder(
+		abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("", conflict),
+		abstractCollection.EXPECT().DropIndex(context.Background(), "posts_text").Return(nil),
+		abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("posts_text", nil),
+	)
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("", errSimulatedErr)
+
+	assert.ErrorIs(t, postRepo.CreateIndexes(context.Background()), errSimulatedErr)
+
+	// Drop error
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("", conflict)
+	abstractCollection.EXPECT().DropIndex(context.Background(), "posts_text").Return(errSimulatedErr)
+
+	assert.ErrorIs(t, postRepo.CreateIndexes(context.Background()), errSimulatedErr)
+
+	// Index error
+	abstractCollection.EXPECT().CreateIndex(context.Background(), gomock.Any()).Return("", errSimulatedErr)
+
+	assert.ErrorIs(t, postRepo.CreateIndexes(context.Background()), errSimulatedErr)
+}
+
+func TestSetLinkPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	preview := &posts.LinkPreview{
+		Title:        "Example Domain",
+		ThumbnailURL: "https://example.com/preview.png",
+	}
+	filter := bson.M{"uuid": expectedPosts[0].ID}
+	update := bson.M{"$set": bson.M{"preview": preview}}
+
+	// Success
+	abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(1), nil)
+	err := postRepo.SetLinkPreview(context.Background(), expectedPosts[0].ID, preview)
+	assert.NoError(t, err)
+
+	// Post not found
+	abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(0), nil)
+	err = postRepo.SetLinkPreview(context.Background(), expectedPosts[0].ID, preview)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// Update error
+	abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(0), errSimulatedErr)
+	err = postRepo.SetLinkPreview(context.Background(), expectedPosts[0].ID, preview)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestCastBallot(t *testing.T) { //nolint:funlen
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	newPoll := func() *posts.Post {
+		return &posts.Post{
+			ID:   expectedPosts[0].ID,
+			Type: posts.WithPoll,
+			Poll: posts.NewPoll(&posts.PollPayload{
+				Options:        []string{"Rock", "Jazz", "Blues"},
+				MultipleChoice: true,
+			}),
+		}
+	}
+
+	// First ballot
+	post := newPoll()
+	filter := bson.M{"uuid": post.ID, "poll.ballots.user": bson.M{"$ne": tokenPayloadUser.ID}}
+	update := bson.M{
+		"$push": bson.M{"poll.ballots": &posts.Ballot{UserID: tokenPayloadUser.ID, Options: []int{0, 2}}},
+		"$inc":  bson.M{"poll.options.$[added].votes": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{
+		bson.M{"added.id": bson.M{"$in": []int{0, 2}}},
+	}})
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update, opts).Return(int64(1), nil)
+
+	post, err := postRepo.CastBallot(ctx, post, []int{2, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, post.Poll.Options[0].Votes)
+	assert.Equal(t, 1, post.Poll.Options[2].Votes)
+
+	// Changed ballot
+	filter = bson.M{"uuid": post.ID, "poll.ballots": bson.M{"$elemMatch": bson.M{"user": tokenPayloadUser.ID, "options": []int{0, 2}}}}
+	update = bson.M{
+		"$set": bson.M{"poll.ballots.$.options": []int{1, 2}},
+		"$inc": bson.M{"poll.options.$[added].votes": 1, "poll.options.$[removed].votes": -1},
+	}
+	opts = options.Update().SetArrayFilters(options.ArrayFilters{Filters: []any{
+		bson.M{"added.id": bson.M{"$in": []int{1}}},
+		bson.M{"removed.id": bson.M{"$in": []int{0}}},
+	}})
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update, opts).Return(int64(0), nil)
+
+	_, err = postRepo.CastBallot(ctx, post, []int{1, 2})
+	assert.ErrorIs(t, err, errs.ErrBallotConflict)
+
+	// Same ballot again does not touch the database
+	post = newPoll()
+	abstractCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	post, err = postRepo.CastBallot(ctx, post, []int{1})
+	assert.NoError(t, err)
+	_, err = postRepo.CastBallot(ctx, post, []int{1})
+	assert.NoError(t, err)
+
+	// Update error
+	post = newPoll()
+	abstractCollection.EXPECT().UpdateOne(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), errSimulatedErr)
+	_, err = postRepo.CastBallot(ctx, post, []int{1})
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Bad ballot
+	_, err = postRepo.CastBallot(ctx, newPoll(), []int{5})
+	assert.ErrorIs(t, err, errs.ErrBadBallot)
+
+	// Not a poll
+	_, err = postRepo.CastBallot(ctx, &posts.Post{ID: expectedPosts[0].ID}, []int{1})
+	assert.ErrorIs(t, err, errs.ErrNotAPoll)
+
+	// Bad payload
+	_, err = postRepo.CastBallot(context.Background(), newPoll(), []int{1})
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+}
+
+func TestCreateCrosspost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	payload := posts.CrosspostPayload{Category: posts.Funny}
+
+	// Success
+	parent := *expectedPosts[0]
+	abstractCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+	abstractCollection.EXPECT().UpdateOne(ctx, bson.M{"uuid": parent.ID}, gomock.Any()).Return(int64(1), nil)
+
+	crosspost, err := postRepo.CreateCrosspost(ctx, &parent, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, posts.Funny, crosspost.Category)
+	assert.Equal(t, parent.ID, crosspost.CrosspostParent.ID)
+	assert.Equal(t, 1, parent.CrosspostCount)
+	assert.Equal(t, crosspost.ID, parent.Crossposts[0].ID)
+
+	// Insert error
+	parent = *expectedPosts[0]
+	abstractCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, errSimulatedErr)
+
+	_, err = postRepo.CreateCrosspost(ctx, &parent, payload)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Update error
+	abstractCollection.EXPECT().InsertOne(ctx, gomock.Any()).Return(nil, nil)
+	abstractCollection.EXPECT().UpdateOne(ctx, bson.M{"uuid": parent.ID}, gomock.Any()).Return(int64(0), errSimulatedErr)
+
+	_, err = postRepo.CreateCrosspost(ctx, &parent, payload)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Bad payload
+	_, err = postRepo.CreateCrosspost(context.Background(), &parent, payload)
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+}
+
+func TestRemoveCrosspostSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	parentID := expectedPosts[0].ID
+	filter := bson.M{"crosspostParent.uuid": parentID}
+	update := bson.M{
+		"$set":   bson.M{"crosspostParent": posts.RemovedCrosspostParent(parentID)},
+		"$unset": bson.M{"text": "", "image": ""},
+	}
+
+	// Success
+	abstractCollection.EXPECT().UpdateMany(context.Background(), filter, update).Return(int64(2), nil)
+	assert.NoError(t, postRepo.RemoveCrosspostSource(context.Background(), parentID))
+
+	// Update error
+	abstractCollection.EXPECT().UpdateMany(context.Background(), filter, update).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, postRepo.RemoveCrosspostSource(context.Background(), parentID), errSimulatedErr)
+}
+
+func TestRemoveCrosspostRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	parentID, crosspostID := expectedPosts[0].ID, expectedPosts[1].ID
+	filter := bson.M{"uuid": parentID, "crossposts.uuid": crosspostID}
+	update := bson.M{
+		"$pull": bson.M{"crossposts": bson.M{"uuid": crosspostID}},
+		"$inc":  bson.M{"crosspostCount": -1},
+	}
+
+	// Success
+	abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(1), nil)
+	assert.NoError(t, postRepo.RemoveCrosspostRef(context.Background(), parentID, crosspostID))
+
+	// Update error
+	abstractCollection.EXPECT().UpdateOne(context.Background(), filter, update).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, postRepo.RemoveCrosspostRef(context.Background(), parentID, crosspostID), errSimulatedErr)
+}
+
+func TestHidePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	filter := bson.M{"uuid": expectedPosts[0].ID}
+
+	// Hide
+	post := &posts.Post{ID: expectedPosts[0].ID}
+	update := bson.M{"$addToSet": bson.M{"hiddenBy": tokenPayloadUser.ID}}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+
+	post, err := postRepo.HidePost(ctx, post)
+	assert.NoError(t, err)
+	assert.True(t, post.IsHiddenBy(tokenPayloadUser.ID))
+
+	// Unhide
+	update = bson.M{"$pull": bson.M{"hiddenBy": tokenPayloadUser.ID}}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+
+	post, err = postRepo.UnhidePost(ctx, post)
+	assert.NoError(t, err)
+	assert.False(t, post.IsHiddenBy(tokenPayloadUser.ID))
+
+	// Post not found
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), nil)
+
+	_, err = postRepo.UnhidePost(ctx, post)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// Bad payload
+	_, err = postRepo.HidePost(context.Background(), post)
+	assert.ErrorIs(t, err, errs.ErrBadPayload)
+}
+
+func TestFeedsExcludeHiddenPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	notHidden := bson.M{"$ne": tokenPayloadUser.ID}
+
+	for _, filter := range []bson.M{
+		{"hiddenBy": notHidden},
+		{"category": posts.Music, "hiddenBy": notHidden},
+		{"author.username": tokenPayloadAdmin.Login, "hiddenBy": notHidden},
+	} {
+		abstractCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(cursor, nil)
+		cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+	}
+
+	_, err := postRepo.GetAllPosts(ctx, posts.FeedQuery{NSFW: true})
+	assert.NoError(t, err)
+	_, err = postRepo.GetPostsByCategory(ctx, posts.Music, posts.FeedQuery{NSFW: true})
+	assert.NoError(t, err)
+	_, err = postRepo.GetPostsByUser(ctx, tokenPayloadAdmin.Login, posts.FeedQuery{NSFW: true})
+	assert.NoError(t, err)
+
+	// Hidden posts listing
+	abstractCollection.EXPECT().Find(ctx, bson.M{"hiddenBy": tokenPayloadUser.ID}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+
+	_, err = postRepo.GetHiddenPosts(ctx, posts.NewPage(0, 0))
+	assert.NoError(t, err)
+
+	// Anonymous feeds only skip NSFW posts
+	abstractCollection.EXPECT().Find(context.Background(), bson.M{"nsfw": bson.M{"$ne": true}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(context.Background(), gomock.Any()).Return(nil)
+
+	_, err = postRepo.GetAllPosts(context.Background(), posts.FeedQuery{})
+	assert.NoError(t, err)
+}
+
+func TestFeedTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	for _, filter := range []bson.M{
+		{"created": bson.M{"$gte": from, "$lte": to}},
+		{"created": bson.M{"$gte": from}},
+		{"created": bson.M{"$lte": to}},
+	} {
+		abstractCollection.EXPECT().Find(ctx, filter, gomock.Any()).Return(cursor, nil)
+		cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+	}
+
+	for _, query := range []posts.FeedQuery{
+		{NSFW: true, From: from, To: to},
+		{NSFW: true, From: from},
+		{NSFW: true, To: to},
+	} {
+		_, err := postRepo.GetAllPosts(ctx, query)
+		assert.NoError(t, err)
+	}
+}
+
+func TestLegacyTimeDecoding(t *testing.T) {
+	created := time.Date(2024, 2, 20, 10, 21, 54, 716e6, time.UTC)
+	data, err := bson.Marshal(bson.M{"uuid": expectedPosts[0].ID, "created": "2024-02-20T10:21:54.716Z"})
+	assert.NoError(t, err)
+
+	// Strings are parsed
+	post := &posts.Post{}
+	assert.NoError(t, decodeWithRegistry(data, post))
+	assert.Equal(t, created, post.Created)
+	data, err = bson.Marshal(bson.M{"uuid": expectedPosts[0].Comments[0].ID, "created": "2024-02-20T10:21:54.716Z"})
+	assert.NoError(t, err)
+	comment := &posts.PostComment{}
+	assert.NoError(t, decodeWithRegistry(data, comment))
+	assert.Equal(t, created, comment.Created)
+
+	// Dates are decoded as usual
+	data, err = bson.Marshal(bson.M{"created": created})
+	assert.NoError(t, err)
+	post = &posts.Post{}
+	assert.NoError(t, decodeWithRegistry(data, post))
+	assert.Equal(t, created, post.Created)
+
+	// Garbage is rejected
+	data, err = bson.Marshal(bson.M{"created": "yesterday"})
+	assert.NoError(t, err)
+	assert.Error(t, decodeWithRegistry(data, &posts.Post{}))
+}
+
+func decodeWithRegistry(data []byte, v any) error {
+	dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return err
+	}
+	dec.SetRegistry(storage.NewRegistry())
+
+	return dec.Decode(v)
+}
+
+func TestMigrateCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"created": bson.M{"$type": "string"}},
+		bson.M{"comments.created": bson.M{"$type": "string"}},
+		bson.M{"crosspostParent.created": bson.M{"$type": "string"}},
+	}}
+
+	// Success, the strings that are not dates are left behind
+	abstractCollection.EXPECT().UpdateMany(ctx, filter, gomock.AssignableToTypeOf(mongo.Pipeline{})).Return(int64(3), nil)
+	abstractCollection.EXPECT().CountDocuments(ctx, filter).Return(int64(1), nil)
+	migrated, unparsed, err := postRepo.MigrateCreated(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), migrated)
+	assert.Equal(t, int64(1), unparsed)
+
+	// Update error
+	abstractCollection.EXPECT().UpdateMany(ctx, filter, gomock.Any()).Return(int64(0), errSimulatedErr)
+	_, _, err = postRepo.MigrateCreated(ctx)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Count error
+	abstractCollection.EXPECT().UpdateMany(ctx, filter, gomock.Any()).Return(int64(3), nil)
+	abstractCollection.EXPECT().CountDocuments(ctx, filter).Return(int64(0), errSimulatedErr)
+	_, _, err = postRepo.MigrateCreated(ctx)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestMigrateComments(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	embedded := bson.D{
+		{Key: "uuid", Value: expectedPosts[0].ID},
+		{Key: "comments", Value: bson.A{toBSON(expectedPosts[0].Comments[0])}},
+	}
+
+	mt.Run(t.Name()+"_success", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, embedded),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+
+		migrated, err := postRepo.MigrateComments(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), migrated)
 	})
+
+	mt.Run(t.Name()+"_nothing_to_migrate", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch))
+
+		migrated, err := postRepo.MigrateComments(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, migrated)
+	})
+
+	mt.Run(t.Name()+"_write_error", func(mt *mtest.T) {
+		postRepo := storage.NewPostRepoMongoDB(storage.NewMongoCollection(mt.Coll), storage.NewMongoCollection(mt.Coll))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.test", mtest.FirstBatch, embedded),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "write failed"}),
+		)
+
+		migrated, err := postRepo.MigrateComments(ctx)
+		assert.Error(t, err)
+		assert.Zero(t, migrated)
+	})
+}
+
+func TestSetFlair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	filter := bson.M{"uuid": expectedPosts[0].ID}
+	flair := &posts.Flair{ID: "00000000-0000-0000-0000-00000000f1a1", Text: "Discussion", Color: "#ff4500"}
+
+	// Set
+	post := &posts.Post{ID: expectedPosts[0].ID}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, bson.M{"$set": bson.M{"flair": flair}}).Return(int64(1), nil)
+
+	post, err := postRepo.SetFlair(ctx, post, flair)
+	assert.NoError(t, err)
+	assert.Equal(t, flair, post.Flair)
+
+	// Remove
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"flair": ""}}).Return(int64(1), nil)
+
+	post, err = postRepo.SetFlair(ctx, post, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, post.Flair)
+
+	// Post not found
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, gomock.Any()).Return(int64(0), nil)
+
+	_, err = postRepo.SetFlair(ctx, post, flair)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// Feeds filter by the text of the flair
+	abstractCollection.EXPECT().Find(ctx, bson.M{"category": posts.Music, "flair.text": flair.Text, "nsfw": bson.M{"$ne": true}}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+
+	_, err = postRepo.GetPostsByCategory(ctx, posts.Music, posts.FeedQuery{Flair: flair.Text})
+	assert.NoError(t, err)
+}
+
+func TestSetFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	filter := bson.M{"uuid": expectedPosts[0].ID}
+	flags := posts.FlagsPayload{NSFW: true, Spoiler: true}
+	update := bson.M{"$set": bson.M{"nsfw": true, "spoiler": true}}
+
+	// Set
+	post := &posts.Post{ID: expectedPosts[0].ID}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+
+	post, err := postRepo.SetFlags(ctx, post, flags)
+	assert.NoError(t, err)
+	assert.True(t, post.NSFW)
+	assert.True(t, post.Spoiler)
+
+	// Post not found
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), nil)
+
+	_, err = postRepo.SetFlags(ctx, post, flags)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// DB error
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), errSimulatedErr)
+
+	_, err = postRepo.SetFlags(ctx, post, flags)
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
+
+func TestSetLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	commentCollection := mocks.NewMockAbstractCollection(ctrl)
+	postRepo := storage.NewPostRepoMongoDB(abstractCollection, commentCollection)
+	ctx := context.Background()
+	filter := bson.M{"uuid": expectedPosts[0].ID}
+	update := bson.M{"$set": bson.M{"locked": true, "votesFrozen": true}}
+
+	// Lock
+	post := &posts.Post{ID: expectedPosts[0].ID}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+
+	post, err := postRepo.SetLock(ctx, post, true, true)
+	assert.NoError(t, err)
+	assert.True(t, post.Locked)
+	assert.True(t, post.VotesFrozen)
+
+	// Post not found
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), nil)
+
+	_, err = postRepo.SetLock(ctx, post, true, true)
+	assert.ErrorIs(t, err, errs.ErrPostNotFound)
+
+	// DB error
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), errSimulatedErr)
+
+	_, err = postRepo.SetLock(ctx, post, true, true)
+	assert.ErrorIs(t, err, errSimulatedErr)
 }
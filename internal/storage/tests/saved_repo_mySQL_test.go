@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+)
+
+var savedComment = posts.SavedItem{
+	UserID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+	Type:   posts.ItemComment,
+	ItemID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+	PostID: "12345678-9abc-def1-2345-6789abcdef12",
+	Saved:  time.UnixMilli(1704164645123).UTC(),
+}
+
+func TestSaveItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	savedRepo := storage.NewSavedRepoMySQL(db)
+	insertQuery := "INSERT INTO saved_items (user_uuid, item_uuid, item_type, post_uuid, saved_at) VALUES (?, ?, ?, ?, ?) " +
+		"ON DUPLICATE KEY UPDATE saved_at = saved_at"
+
+	// Success
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(savedComment.UserID, savedComment.ItemID, savedComment.Type, savedComment.PostID, savedComment.Saved.UnixMilli()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = savedRepo.SaveItem(context.Background(), savedComment)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// DB error
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(savedComment.UserID, savedComment.ItemID, savedComment.Type, savedComment.PostID, savedComment.Saved.UnixMilli()).
+		WillReturnError(errors.New("db_error"))
+
+	err = savedRepo.SaveItem(context.Background(), savedComment)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSavedItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	savedRepo := storage.NewSavedRepoMySQL(db)
+
+	// Filtered by type
+	rows := sqlmock.NewRows([]string{"item_uuid", "item_type", "post_uuid", "saved_at"}).
+		AddRow(savedComment.ItemID, savedComment.Type, savedComment.PostID, savedComment.Saved.UnixMilli())
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT item_uuid, item_type, post_uuid, saved_at FROM saved_items WHERE user_uuid = ? AND item_type = ? ORDER BY saved_at DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs(savedComment.UserID, posts.ItemComment, 25, 0).
+		WillReturnRows(rows)
+
+	items, err := savedRepo.GetSavedItems(context.Background(), savedComment.UserID, posts.SavedQuery{
+		Type: posts.ItemComment,
+		Page: posts.NewPage(0, 0),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []posts.SavedItem{savedComment}, items)
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT item_uuid, item_type, post_uuid, saved_at FROM saved_items WHERE user_uuid = ? ORDER BY saved_at DESC LIMIT ? OFFSET ?",
+	)).
+		WithArgs(savedComment.UserID, 10, 20).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = savedRepo.GetSavedItems(context.Background(), savedComment.UserID, posts.SavedQuery{
+		Page: posts.NewPage(10, 20),
+	})
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSavedIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	savedRepo := storage.NewSavedRepoMySQL(db)
+	itemIDs := []users.ID{savedComment.PostID, savedComment.ItemID}
+
+	rows := sqlmock.NewRows([]string{"item_uuid"}).AddRow(savedComment.ItemID)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT item_uuid FROM saved_items WHERE user_uuid = ? AND item_uuid IN (?, ?)")).
+		WithArgs(savedComment.UserID, savedComment.PostID, savedComment.ItemID).
+		WillReturnRows(rows)
+
+	saved, err := savedRepo.GetSavedIDs(context.Background(), savedComment.UserID, itemIDs)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, posts.SavedSet{savedComment.ItemID: {}}, saved)
+
+	// Nothing to look up
+	saved, err = savedRepo.GetSavedIDs(context.Background(), savedComment.UserID, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, saved)
+}
+
+func TestDeleteSavedItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	savedRepo := storage.NewSavedRepoMySQL(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM saved_items WHERE item_uuid = ? OR post_uuid = ?")).
+		WithArgs(savedComment.PostID, savedComment.PostID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = savedRepo.DeleteSavedItems(context.Background(), savedComment.PostID)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+func TestScheduledRepoMongoDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	singleResult := mocks.NewMockAbstractSingleResult(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	scheduledRepo := storage.NewScheduledRepoMongoDB(abstractCollection)
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	scheduled := posts.NewScheduledPost(*tokenPayloadUser, posts.PostPayload{
+		Type:      posts.WithText,
+		Title:     "Title",
+		Category:  posts.Music,
+		Text:      "Text",
+		PublishAt: &publishAt,
+	})
+	filter := bson.M{"uuid": scheduled.ID}
+
+	// Add
+	abstractCollection.EXPECT().InsertOne(ctx, scheduled).Return(nil, nil)
+	assert.NoError(t, scheduledRepo.AddScheduledPost(ctx, scheduled))
+
+	// Get
+	abstractCollection.EXPECT().FindOne(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(nil)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, *scheduled).Return(nil)
+
+	found, err := scheduledRepo.GetScheduledPost(ctx, scheduled.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, scheduled, found)
+
+	abstractCollection.EXPECT().FindOne(ctx, filter).Return(singleResult)
+	singleResult.EXPECT().Err().Return(mongo.ErrNoDocuments)
+
+	_, err = scheduledRepo.GetScheduledPost(ctx, scheduled.ID)
+	assert.ErrorIs(t, err, errs.ErrScheduledPostNotFound)
+
+	// Lists
+	now := time.Now()
+	abstractCollection.EXPECT().Find(ctx, bson.M{"author.id": tokenPayloadUser.ID}, gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+	claimable := func(filter bson.M) bson.M {
+		filter["attempts"] = bson.M{"$not": bson.M{"$gte": posts.MaxPublishAttempts}}
+		filter["lockedUntil"] = bson.M{"$not": bson.M{"$gt": now}}
+		return filter
+	}
+	// Held posts and posts given up on are not due
+	abstractCollection.EXPECT().Find(ctx, claimable(bson.M{"publishAt": bson.M{"$lte": now}}), gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(nil)
+
+	_, err = scheduledRepo.GetScheduledPosts(ctx, tokenPayloadUser.ID)
+	assert.NoError(t, err)
+	_, err = scheduledRepo.GetDueScheduledPosts(ctx, now, 10)
+	assert.NoError(t, err)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, errSimulatedErr)
+	_, err = scheduledRepo.GetDueScheduledPosts(ctx, now, 10)
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	// Update
+	update := bson.M{"$set": bson.M{"post": scheduled.Post, "publishAt": scheduled.PublishAt, "attempts": 0}}
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(1), nil)
+	assert.NoError(t, scheduledRepo.UpdateScheduledPost(ctx, scheduled))
+
+	abstractCollection.EXPECT().UpdateOne(ctx, filter, update).Return(int64(0), nil)
+	assert.ErrorIs(t, scheduledRepo.UpdateScheduledPost(ctx, scheduled), errs.ErrScheduledPostNotFound)
+
+	// Delete
+	abstractCollection.EXPECT().DeleteOne(ctx, filter).Return(int64(1), nil)
+	assert.NoError(t, scheduledRepo.DeleteScheduledPost(ctx, scheduled.ID))
+
+	abstractCollection.EXPECT().DeleteOne(ctx, filter).Return(int64(0), nil)
+	assert.ErrorIs(t, scheduledRepo.DeleteScheduledPost(ctx, scheduled.ID), errs.ErrScheduledPostNotFound)
+
+	abstractCollection.EXPECT().DeleteOne(ctx, filter).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, scheduledRepo.DeleteScheduledPost(ctx, scheduled.ID), errSimulatedErr)
+
+	// Claim holds the post and counts the attempt, the post stays on the schedule
+	until := now.Add(time.Minute)
+	held := *scheduled
+	held.LockedUntil, held.Attempts = until, 1
+	abstractCollection.EXPECT().FindOneAndUpdate(ctx, claimable(bson.M{"uuid": scheduled.ID}), bson.M{
+		"$set": bson.M{"lockedUntil": until},
+		"$inc": bson.M{"attempts": 1},
+	}, gomock.Any()).Return(singleResult)
+	singleResult.EXPECT().Err().Return(nil)
+	singleResult.EXPECT().Decode(gomock.Any()).SetArg(0, held).Return(nil)
+
+	claimed, err := scheduledRepo.ClaimScheduledPost(ctx, scheduled.ID, now, until)
+	assert.NoError(t, err)
+	assert.Equal(t, &held, claimed)
+
+	abstractCollection.EXPECT().FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(singleResult)
+	singleResult.EXPECT().Err().Return(mongo.ErrNoDocuments)
+
+	_, err = scheduledRepo.ClaimScheduledPost(ctx, scheduled.ID, now, until)
+	assert.ErrorIs(t, err, errs.ErrScheduledPostNotFound)
+}
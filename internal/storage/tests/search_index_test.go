@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/inmem"
+)
+
+func searchIDs(t *testing.T, repo *inmem.PostRepo, query posts.SearchQuery) []users.ID {
+	t.Helper()
+	if query.Limit == 0 {
+		query.Page = posts.NewPage(0, 0)
+	}
+	postList, err := repo.SearchPosts(context.Background(), query)
+	require.NoError(t, err)
+	postIDs := make([]users.ID, 0, len(postList))
+	for _, post := range postList {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	return postIDs
+}
+
+func TestInmemSearchTokenizing(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	post, err := repo.CreatePost(ctx, posts.PostPayload{
+		Type:     posts.WithText,
+		Title:    "Gophers, UNITE!",
+		Category: posts.Programming,
+		Text:     "Go1.23 is out: range-over-func & iterators",
+	})
+	require.NoError(t, err)
+
+	// Words are matched case-insensitively and are split on anything but letters and digits
+	for _, text := range []string{"gophers", "unite", "GO1", "23", "func", "Iterators", "range over"} {
+		assert.Equal(t, []users.ID{post.ID}, searchIDs(t, repo, posts.SearchQuery{Text: text}), text)
+	}
+	for _, text := range []string{"gopher", "go123", "", "!&:"} {
+		assert.Empty(t, searchIDs(t, repo, posts.SearchQuery{Text: text}), text)
+	}
+
+	// Deleted posts are unindexed
+	require.NoError(t, repo.DeletePost(context.Background(), post.ID))
+	assert.Empty(t, searchIDs(t, repo, posts.SearchQuery{Text: "gophers"}))
+}
+
+func TestInmemSearchRanking(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	create := func(title, text string) *posts.Post {
+		post, err := repo.CreatePost(ctx, posts.PostPayload{Type: posts.WithText, Title: title, Category: posts.Music, Text: text})
+		require.NoError(t, err)
+		return post
+	}
+
+	inComment := create("First", "Nothing to see")
+	_, err := repo.AddComment(ctx, inComment, posts.Comment{Body: "jazz jazz"})
+	require.NoError(t, err)
+	inText := create("Second", "Some jazz")
+	inTitle := create("Jazz", "Nothing to see")
+	inBoth := create("Jazz", "More jazz")
+
+	// Title matches outweigh text matches, which outweigh comment matches
+	expected := []users.ID{inBoth.ID, inTitle.ID, inText.ID, inComment.ID}
+	assert.Equal(t, expected, searchIDs(t, repo, posts.SearchQuery{Text: "jazz"}))
+
+	// Every word of the query adds to the relevance
+	assert.Equal(t, []users.ID{inText.ID, inBoth.ID, inTitle.ID, inComment.ID},
+		searchIDs(t, repo, posts.SearchQuery{Text: "jazz second some"}))
+
+	// Pages are cut out of the ranked list
+	assert.Equal(t, expected[1:3], searchIDs(t, repo, posts.SearchQuery{Text: "jazz", Page: posts.NewPage(2, 1)}))
+
+	// Edited comments are reindexed
+	comment := inComment.Comments[0]
+	_, err = repo.EditComment(ctx, inComment, comment.ID, posts.Comment{Body: "blues"})
+	require.NoError(t, err)
+	assert.Equal(t, expected[:3], searchIDs(t, repo, posts.SearchQuery{Text: "jazz"}))
+	assert.Equal(t, []users.ID{inComment.ID}, searchIDs(t, repo, posts.SearchQuery{Text: "blues"}))
+}
+
+func TestInmemSearchDateRange(t *testing.T) {
+	repo := inmem.NewPostRepo()
+	ctx := context.WithValue(context.Background(), jwt.Payload, tokenPayloadUser)
+	fresh, err := repo.CreatePost(ctx, posts.PostPayload{Type: posts.WithText, Title: "Fresh news", Category: posts.News, Text: "Text"})
+	require.NoError(t, err)
+	old, err := repo.CreatePost(ctx, posts.PostPayload{Type: posts.WithText, Title: "Old news", Category: posts.News, Text: "Text"})
+	require.NoError(t, err)
+	stored, err := repo.GetPostByID(context.Background(), old.ID)
+	require.NoError(t, err)
+	stored.Created = time.Now().Add(-72 * time.Hour)
+
+	dayAgo := time.Now().Add(-24 * time.Hour)
+	for _, tc := range []struct {
+		from, to time.Time
+		expected []users.ID
+	}{
+		{time.Time{}, time.Time{}, []users.ID{fresh.ID, old.ID}},
+		{dayAgo, time.Time{}, []users.ID{fresh.ID}},
+		{time.Time{}, dayAgo, []users.ID{old.ID}},
+		{dayAgo.Add(-72 * time.Hour), dayAgo, []users.ID{old.ID}},
+		{dayAgo, dayAgo.Add(time.Hour), []users.ID{}},
+	} {
+		assert.ElementsMatch(t, tc.expected, searchIDs(t, repo, posts.SearchQuery{Text: "news", From: tc.from, To: tc.to}))
+	}
+}
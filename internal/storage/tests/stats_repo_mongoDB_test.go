@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+)
+
+func TestStatsRepoMongoDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	abstractCollection := mocks.NewMockAbstractCollection(ctrl)
+	cursor := mocks.NewMockAbstractCursor(ctrl)
+	statsRepo := storage.NewStatsRepoMongoDB(abstractCollection, 24*time.Hour)
+	ctx := context.Background()
+	postID := expectedPosts[0].ID
+	bucket := &posts.StatsBucket{PostID: postID, Start: time.Now().UTC().Truncate(time.Hour), StatsCounts: posts.StatsCounts{Views: 1}}
+
+	// Indexes, the retention one included
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("stats_post_start", nil)
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("stats_retention", nil)
+	assert.NoError(t, statsRepo.CreateIndexes(ctx))
+
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("", errSimulatedErr)
+	assert.ErrorIs(t, statsRepo.CreateIndexes(ctx), errSimulatedErr)
+
+	// Without retention there is no TTL index
+	abstractCollection.EXPECT().CreateIndex(ctx, gomock.Any()).Return("stats_post_start", nil)
+	assert.NoError(t, storage.NewStatsRepoMongoDB(abstractCollection, 0).CreateIndexes(ctx))
+
+	// Add
+	abstractCollection.EXPECT().BulkWrite(ctx, gomock.Len(1), gomock.Any()).Return(int64(0), nil)
+	assert.NoError(t, statsRepo.AddStats(ctx, []*posts.StatsBucket{bucket}))
+	assert.NoError(t, statsRepo.AddStats(ctx, nil))
+
+	abstractCollection.EXPECT().BulkWrite(ctx, gomock.Any(), gomock.Any()).Return(int64(0), errSimulatedErr)
+	assert.ErrorIs(t, statsRepo.AddStats(ctx, []*posts.StatsBucket{bucket}), errSimulatedErr)
+
+	// Get
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).SetArg(1, []*posts.StatsBucket{bucket}).Return(nil)
+
+	buckets, err := statsRepo.GetStats(ctx, postID, time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []*posts.StatsBucket{bucket}, buckets)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(nil, errSimulatedErr)
+	_, err = statsRepo.GetStats(ctx, postID, time.Time{}, time.Now())
+	assert.ErrorIs(t, err, errSimulatedErr)
+
+	abstractCollection.EXPECT().Find(ctx, gomock.Any(), gomock.Any()).Return(cursor, nil)
+	cursor.EXPECT().All(ctx, gomock.Any()).Return(errSimulatedErr)
+	_, err = statsRepo.GetStats(ctx, postID, time.Time{}, time.Now())
+	assert.ErrorIs(t, err, errSimulatedErr)
+}
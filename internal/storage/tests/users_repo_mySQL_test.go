@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 
@@ -153,6 +154,20 @@ func TestRegisterUser(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 	assert.EqualError(t, err, "db_error")
 
+	// Registered concurrently after the check
+	response = sqlmock.NewRows([]string{"exists"}).AddRow(false)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM users WHERE login = ?)")).
+		WithArgs(authData.Login).
+		WillReturnRows(response)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users (`uuid`, `login`, `password`) VALUES (?, ?, ?)")).
+		WithArgs(sqlmock.AnyArg(), authData.Login, authData.Password).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	_, err = userRepoMySQLMock.RegisterUser(context.Background(), authData)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.ErrorIs(t, err, errs.ErrUserExists)
+
 	rows = sqlmock.NewRows([]string{"uuid", "login", "password"})
 	response = sqlmock.NewRows([]string{"exists"}).AddRow(false)
 
@@ -172,6 +187,98 @@ func TestRegisterUser(t *testing.T) {
 	assert.Equal(t, authData.Password, user.Password)
 }
 
+func TestPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	userRepoMySQLMock := storage.NewUserRepoMySQL(db)
+	userID := expectedUsers[0].ID
+
+	// Defaults
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT show_nsfw FROM user_preferences WHERE user_uuid = ?")).
+		WithArgs(userID).
+		WillReturnError(sql.ErrNoRows)
+
+	prefs, err := userRepoMySQLMock.GetPreferences(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, users.Preferences{}, prefs)
+
+	// Stored
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT show_nsfw FROM user_preferences WHERE user_uuid = ?")).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"show_nsfw"}).AddRow(true))
+
+	prefs, err = userRepoMySQLMock.GetPreferences(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, prefs.ShowNSFW)
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT show_nsfw FROM user_preferences WHERE user_uuid = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = userRepoMySQLMock.GetPreferences(context.Background(), userID)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Set
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_preferences")).
+		WithArgs(userID, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = userRepoMySQLMock.SetPreferences(context.Background(), userID, users.Preferences{ShowNSFW: true})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUsersByLogins(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("cant create mock: %s", err)
+	}
+	defer db.Close()
+
+	userRepoMySQLMock := storage.NewUserRepoMySQL(db)
+	logins := []users.Username{"admin", "ghost"}
+	query := "SELECT uuid, login FROM users WHERE login IN (?, ?)"
+
+	// Success, unknown logins are skipped
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(logins[0], logins[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "login"}).AddRow(expectedUsers[0].ID, expectedUsers[0].Username))
+
+	userList, err := userRepoMySQLMock.GetUsersByLogins(context.Background(), logins)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []*users.User{{ID: expectedUsers[0].ID, Username: expectedUsers[0].Username}}, userList)
+
+	// No logins
+	userList, err = userRepoMySQLMock.GetUsersByLogins(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, userList)
+
+	// DB error
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(logins[0], logins[1]).
+		WillReturnError(errors.New("db_error"))
+
+	_, err = userRepoMySQLMock.GetUsersByLogins(context.Background(), logins)
+
+	assert.EqualError(t, err, "db_error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 //func TestNewUserRepoMySQL(t *testing.T) {
 //	db, _, err := sqlmock.New()
 //	if err != nil {
@@ -3,13 +3,18 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"strings"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 
 	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
 	"github.com/Benzogang-Tape/Reddit/internal/models/users"
 )
 
+// errDuplicateEntry is the code MySQL rejects a row with when it breaks a unique key
+const errDuplicateEntry uint16 = 1062
+
 type UserRepoMySQL struct {
 	db *sql.DB
 }
@@ -58,8 +63,12 @@ func (repo *UserRepoMySQL) RegisterUser(ctx context.Context, authData users.Auth
 		return nil, errors.Wrap(errs.ErrUserExists, source)
 	}
 
+	// The login may be taken by a concurrent registration in between, the unique key settles it
 	newUser, err := repo.createUser(authData)
-	if err != nil {
+	switch {
+	case isDuplicateEntry(err):
+		return nil, errors.Wrap(errs.ErrUserExists, source)
+	case err != nil:
 		return nil, err
 	}
 
@@ -79,3 +88,68 @@ func (repo *UserRepoMySQL) createUser(credentials users.AuthUserInfo) (*users.Us
 
 	return newUser, nil
 }
+
+// isDuplicateEntry reports whether the statement failed because of a unique key
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
+
+// GetUsersByLogins returns the users with the logins, the passwords left out. Unknown logins are skipped
+func (repo *UserRepoMySQL) GetUsersByLogins(ctx context.Context, logins []users.Username) ([]*users.User, error) {
+	userList := make([]*users.User, 0, len(logins))
+	if len(logins) == 0 {
+		return userList, nil
+	}
+
+	args := make([]any, 0, len(logins))
+	for _, login := range logins {
+		args = append(args, login)
+	}
+	rows, err := repo.db.QueryContext(
+		ctx,
+		"SELECT uuid, login FROM users WHERE login IN (?"+strings.Repeat(", ?", len(logins)-1)+")",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &users.User{}
+		if err = rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		userList = append(userList, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userList, nil
+}
+
+// GetPreferences returns the preferences of the user, the defaults if he/she has never changed them
+func (repo *UserRepoMySQL) GetPreferences(ctx context.Context, userID users.ID) (users.Preferences, error) { //nolint:unparam
+	prefs := users.Preferences{}
+	err := repo.db.QueryRow(
+		"SELECT show_nsfw FROM user_preferences WHERE user_uuid = ?",
+		userID,
+	).Scan(&prefs.ShowNSFW)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return prefs, err
+	}
+
+	return prefs, nil
+}
+
+func (repo *UserRepoMySQL) SetPreferences(ctx context.Context, userID users.ID, prefs users.Preferences) error { //nolint:unparam
+	_, err := repo.db.Exec(
+		"INSERT INTO user_preferences (`user_uuid`, `show_nsfw`) VALUES (?, ?) ON DUPLICATE KEY UPDATE show_nsfw = VALUES(show_nsfw)",
+		userID,
+		prefs.ShowNSFW,
+	)
+
+	return err
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// ViewCounterRedis keeps the viewers in HyperLogLogs, one per post and window, so a set takes at most 12 KB whatever
+// the audience. The count is approximate: a new viewer is rarely taken for a known one. A set lives no longer than its window
+type ViewCounterRedis struct {
+	rdb *redis.Client
+}
+
+func NewViewCounterRedis(client *redis.Client) *ViewCounterRedis {
+	return &ViewCounterRedis{
+		rdb: client,
+	}
+}
+
+func (c *ViewCounterRedis) AddViewer(ctx context.Context, key, viewer string, ttl time.Duration) (bool, error) { //nolint:unparam
+	pipe := c.rdb.TxPipeline()
+	added := pipe.PFAdd(key, viewer)
+	pipe.Expire(key, ttl)
+	if _, err := pipe.Exec(); err != nil {
+		return false, err
+	}
+
+	return added.Val() == 1, nil
+}
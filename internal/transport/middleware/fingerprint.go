@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// Fingerprint lets the views of anonymous users be told apart
+func Fingerprint(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fingerprint := posts.NewFingerprint(r.RemoteAddr, r.UserAgent())
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), posts.Fingerprint, fingerprint)))
+	})
+}
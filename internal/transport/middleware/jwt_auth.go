@@ -18,13 +18,40 @@ type Endpoints map[*regexp.Regexp]HTTPMethods
 
 var (
 	authUrls = Endpoints{
-		regexp.MustCompile(`^/api/posts$`):                            {http.MethodPost},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+$`):               {http.MethodPost},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+$`): {http.MethodDelete},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/upvote$`):        {http.MethodGet},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/downvote$`):      {http.MethodGet},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/unvote$`):        {http.MethodGet},
-		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+$`):               {http.MethodDelete},
+		regexp.MustCompile(`^/api/posts$`):                                         {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+$`):                            {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+$`):              {http.MethodDelete, http.MethodPatch},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/upvote$`):                     {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/downvote$`):                   {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/unvote$`):                     {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+/upvote$`):       {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+/downvote$`):     {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+/unvote$`):       {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+/revisions$`):    {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+$`):                            {http.MethodDelete},
+		regexp.MustCompile(`^/api/communities$`):                                   {http.MethodPost},
+		regexp.MustCompile(`^/api/posts/image$`):                                   {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/poll$`):                       {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/crosspost$`):                  {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/(un)?save$`):                  {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/[0-9a-fA-F-]+/(un)?save$`):    {http.MethodPost},
+		regexp.MustCompile(`^/api/me/saved$`):                                      {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/(un)?hide$`):                  {http.MethodPost},
+		regexp.MustCompile(`^/api/me/hidden$`):                                     {http.MethodGet},
+		regexp.MustCompile(`^/api/me/mentions$`):                                   {http.MethodGet},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/flags$`):                      {http.MethodPut},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/pin$`):                        {http.MethodPut, http.MethodDelete},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/lock$`):                       {http.MethodPut, http.MethodDelete},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/stats$`):                      {http.MethodGet},
+		regexp.MustCompile(`^/api/me/preferences$`):                                {http.MethodGet, http.MethodPut},
+		regexp.MustCompile(`^/api/me/scheduled$`):                                  {http.MethodGet},
+		regexp.MustCompile(`^/api/me/scheduled/[0-9a-fA-F-]+$`):                    {http.MethodPut, http.MethodDelete},
+		regexp.MustCompile(`^/api/me/drafts$`):                                     {http.MethodGet, http.MethodPost},
+		regexp.MustCompile(`^/api/me/drafts/[0-9a-fA-F-]+$`):                       {http.MethodGet, http.MethodPut, http.MethodDelete},
+		regexp.MustCompile(`^/api/me/drafts/[0-9a-fA-F-]+/publish$`):               {http.MethodPost},
+		regexp.MustCompile(`^/api/post/[0-9a-fA-F-]+/flair$`):                      {http.MethodPut},
+		regexp.MustCompile(`^/api/community/[0-9a-zA-Z_-]+/flairs$`):               {http.MethodPost},
+		regexp.MustCompile(`^/api/community/[0-9a-zA-Z_-]+/flairs/[0-9a-fA-F-]+$`): {http.MethodDelete},
 	}
 )
 
@@ -38,6 +65,13 @@ func Auth(next http.Handler, sessMngr service.SessionAPI, logger *zap.SugaredLog
 			}
 		}
 		if canBeWithoutAuth {
+			// Public endpoints still personalize the response for a signed-in user,
+			// a missing or bad token only makes the request anonymous
+			if token, ok := bearerToken(r); ok {
+				if payload, err := sessMngr.Verify(r.Context(), &jwt.Session{Token: token}); err == nil {
+					r = r.WithContext(context.WithValue(r.Context(), jwt.Payload, payload))
+				}
+			}
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -60,3 +94,12 @@ func Auth(next http.Handler, sessMngr service.SessionAPI, logger *zap.SugaredLog
 		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), jwt.Payload, payload)))
 	})
 }
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
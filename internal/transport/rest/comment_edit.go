@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// EditComment godoc
+//
+//	@Summary		Edit comment
+//	@Description	Replace the body of your comment on a certain post. The previous body is kept for the moderators
+//	@Security		ApiKeyAuth
+//	@Tags			commenting-posts
+//	@ID				edit-comment
+//	@Accept			json
+//	@Produce		json
+//	@Param			comment_payload	body		posts.Comment		true	"Comment data"	validate(required)
+//	@Param			POST_ID			path		string				true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID		path		string				true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200				{object}	posts.Post			"Comment successfully edited"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload or uuid"
+//	@Failure		403				{object}	errs.SimpleErr		"The user is not the author of the comment or the post is locked or archived"
+//	@Failure		404				{object}	errs.SimpleErr		"No posts or comment with the provided id were found"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID} [patch]
+func (p *PostHandler) EditComment(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	comment := posts.Comment{}
+	if err = json.Unmarshal(body, &comment); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	params := mux.Vars(r)
+	postID, err := validateID("POST_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	commentID, err := validateID("COMMENT_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCommentID.Error()))
+		return
+	}
+
+	post, err := p.service.EditComment(r.Context(), postID, commentID, comment)
+	switch {
+	case errors.Is(err, errs.ErrBadCommentBody):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "comment",
+			Msg:      "is required",
+		}))
+		return
+	case errors.Is(err, errs.ErrTooManyMentions):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "comment",
+			Msg:      "must mention at most 10 users",
+		}))
+		return
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrCommentNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommentNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrNotCommentAuthor):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotCommentAuthor.Error()))
+		return
+	case errors.Is(err, errs.ErrPostLocked):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostLocked.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+// GetCommentRevisions godoc
+//
+//	@Summary		Get comment revisions
+//	@Description	Get the bodies a certain comment had before it was edited, oldest first. Available to the moderators only
+//	@Security		ApiKeyAuth
+//	@Tags			moderation
+//	@ID				get-comment-revisions
+//	@Produce		json
+//	@Param			POST_ID		path		string					true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string					true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{array}		posts.CommentRevision	"Revisions successfully received"
+//	@Failure		400			{object}	errs.SimpleErr			"Bad uuid"
+//	@Failure		403			{object}	errs.SimpleErr			"The user is not a moderator"
+//	@Failure		404			{object}	errs.SimpleErr			"No posts or comment with the provided id were found"
+//	@Failure		500			{object}	errs.SimpleErr			"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/revisions [get]
+func (p *PostHandler) GetCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	postID, err := validateID("POST_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	commentID, err := validateID("COMMENT_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCommentID.Error()))
+		return
+	}
+
+	revisions, err := p.service.GetCommentRevisions(r.Context(), postID, commentID)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrCommentNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommentNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrNotModerator):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotModerator.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(revisions, w)
+}
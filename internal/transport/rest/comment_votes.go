@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// UpvoteComment godoc
+//
+//	@Summary		Vote up on a comment
+//	@Description	Increase comment rating by 1 vote
+//	@Security		ApiKeyAuth
+//	@Tags			voting-posts
+//	@ID				upvote-comment
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string			true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Post		"Successfully upvoted"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		403			{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts or comment with the provided id were found"
+//	@Failure		409			{object}	errs.SimpleErr	"Vote has been changed concurrently"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/upvote [get]
+func (p *PostHandler) UpvoteComment(w http.ResponseWriter, r *http.Request) {
+	p.voteComment(w, r, p.service.UpvoteComment)
+}
+
+// DownvoteComment godoc
+//
+//	@Summary		Vote down on a comment
+//	@Description	Decrease comment rating by 1 vote
+//	@Security		ApiKeyAuth
+//	@Tags			voting-posts
+//	@ID				downvote-comment
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string			true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Post		"Successfully downvoted"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		403			{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts or comment with the provided id were found"
+//	@Failure		409			{object}	errs.SimpleErr	"Vote has been changed concurrently"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/downvote [get]
+func (p *PostHandler) DownvoteComment(w http.ResponseWriter, r *http.Request) {
+	p.voteComment(w, r, p.service.DownvoteComment)
+}
+
+// UnvoteComment godoc
+//
+//	@Summary		Cancel your vote on a comment
+//	@Description	Withdraw your vote from the comment
+//	@Security		ApiKeyAuth
+//	@Tags			voting-posts
+//	@ID				unvote-comment
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string			true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Post		"Successfully unvoted"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		403			{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts, comment or vote were found"
+//	@Failure		409			{object}	errs.SimpleErr	"Vote has been changed concurrently"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/unvote [get]
+func (p *PostHandler) UnvoteComment(w http.ResponseWriter, r *http.Request) {
+	p.voteComment(w, r, p.service.UnvoteComment)
+}
+
+func (p *PostHandler) voteComment(
+	w http.ResponseWriter,
+	r *http.Request,
+	vote func(ctx context.Context, postID, commentID users.ID) (*posts.Post, error),
+) {
+	params := mux.Vars(r)
+	postID, err := validateID("POST_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	commentID, err := validateID("COMMENT_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCommentID.Error()))
+		return
+	}
+
+	post, err := vote(r.Context(), postID, commentID)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrCommentNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommentNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrVoteNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrVoteNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrVotingFrozen):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrVotingFrozen.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
+	case errors.Is(err, errs.ErrVoteConflict):
+		sendErrorResponse(w, http.StatusConflict, errs.NewSimpleErr(errs.ErrVoteConflict.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+// GetKarma godoc
+//
+//	@Summary		Get user karma
+//	@Description	Get the balance of the votes other users have put on the posts and the comments of a certain user
+//	@Tags			getting-posts
+//	@ID				get-karma
+//	@Produce		json
+//	@Param			USER_LOGIN	path		string			true	"Username of user"
+//	@Success		200			{object}	users.Karma		"Karma successfully received"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/user/{USER_LOGIN}/karma [get]
+func (p *PostHandler) GetKarma(w http.ResponseWriter, r *http.Request) {
+	userLogin := users.Username(mux.Vars(r)["USER_LOGIN"])
+	karma, err := p.service.GetKarma(r.Context(), userLogin)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(karma, w)
+}
@@ -0,0 +1,262 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/httpresp"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+//go:generate mockgen -source=community.go -destination=../../storage/mocks/communities_repo_mySQL_mock.go -package=mocks CommunityAPI
+type CommunityAPI interface {
+	GetAllCommunities(ctx context.Context) ([]*communities.Community, error)
+	GetCommunityByName(ctx context.Context, name string) (*communities.Community, error)
+	CreateCommunity(ctx context.Context, payload communities.CommunityPayload) (*communities.Community, error)
+	CreateFlair(ctx context.Context, name string, payload communities.FlairPayload) (*communities.Community, error)
+	DeleteFlair(ctx context.Context, name string, flairID users.ID) (*communities.Community, error)
+}
+
+type CommunityHandler struct {
+	logger  *zap.SugaredLogger
+	service CommunityAPI
+}
+
+func NewCommunityHandler(c CommunityAPI, logger *zap.SugaredLogger) *CommunityHandler {
+	return &CommunityHandler{
+		logger:  logger,
+		service: c,
+	}
+}
+
+// GetAllCommunities godoc
+//
+//	@Summary		Get all communities
+//	@Description	Get a list of all communities created by users
+//	@Tags			communities
+//	@ID				get-all-communities
+//	@Produce		json
+//	@Success		200	{array}		communities.Community	"Communities successfully received"
+//	@Failure		500	{object}	errs.SimpleErr			"Internal server error"
+//	@Router			/communities [get]
+func (c *CommunityHandler) GetAllCommunities(w http.ResponseWriter, r *http.Request) {
+	communityList, err := c.service.GetAllCommunities(r.Context())
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(communityList, w)
+}
+
+// GetCommunity godoc
+//
+//	@Summary		Get a certain community
+//	@Description	Get information on a specific community by its name
+//	@Tags			communities
+//	@ID				get-community
+//	@Produce		json
+//	@Param			COMMUNITY_NAME	path		string					true	"Community name"
+//	@Success		200				{object}	communities.Community	"Community successfully received"
+//	@Failure		404				{object}	errs.SimpleErr			"No communities with the provided name were found"
+//	@Failure		500				{object}	errs.SimpleErr			"Internal server error"
+//	@Router			/community/{COMMUNITY_NAME} [get]
+func (c *CommunityHandler) GetCommunity(w http.ResponseWriter, r *http.Request) {
+	community, err := c.service.GetCommunityByName(r.Context(), mux.Vars(r)["COMMUNITY_NAME"])
+	switch {
+	case errors.Is(err, errs.ErrCommunityNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommunityNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(community, w)
+}
+
+// CreateCommunity godoc
+//
+//	@Summary		Create a community
+//	@Description	Create a community with a name, description and rules. The creator becomes its moderator
+//	@Security		ApiKeyAuth
+//	@Tags			communities
+//	@ID				create-community
+//	@Accept			json
+//	@Produce		json
+//	@Param			community_payload	body		communities.CommunityPayload	true	"Community data"	validate(required)
+//	@Success		201					{object}	communities.Community			"Community successfully created"
+//	@Failure		400					"Bad payload"
+//	@Failure		422					{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500					{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/communities [post]
+func (c *CommunityHandler) CreateCommunity(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := communities.CommunityPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	community, err := c.service.CreateCommunity(r.Context(), payload)
+	switch {
+	case errors.Is(err, errs.ErrInvalidCommunity):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "name",
+			Value:    payload.Name,
+			Msg:      "is invalid",
+		}))
+		return
+	case errors.Is(err, errs.ErrCommunityExists):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "name",
+			Value:    payload.Name,
+			Msg:      "already exists",
+		}))
+		return
+	case errors.Is(err, errs.ErrBadDescription):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "description",
+			Msg:      "is too long",
+		}))
+		return
+	case errors.Is(err, errs.ErrBadRules):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "rules",
+			Msg:      "are invalid",
+		}))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	c.logger.Infow("New community has been created",
+		"name", community.Name,
+		"creator", community.Creator.Login,
+		"remote_addr", r.RemoteAddr,
+	)
+	sendResponse(community, w, httpresp.WithStatusCode(http.StatusCreated))
+}
+
+// CreateFlair godoc
+//
+//	@Summary		Create a flair
+//	@Description	Add a flair template to the community. Only the moderators of the community can do it
+//	@Security		ApiKeyAuth
+//	@Tags			communities
+//	@ID				create-flair
+//	@Accept			json
+//	@Produce		json
+//	@Param			COMMUNITY_NAME	path		string						true	"Community name"
+//	@Param			flair_payload	body		communities.FlairPayload	true	"Flair data"	validate(required)
+//	@Success		201				{object}	communities.Community		"Flair successfully created"
+//	@Failure		400				"Bad payload"
+//	@Failure		403				{object}	errs.SimpleErr		"User is not a moderator of the community"
+//	@Failure		404				{object}	errs.SimpleErr		"No communities with the provided name were found"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/community/{COMMUNITY_NAME}/flairs [post]
+func (c *CommunityHandler) CreateFlair(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := communities.FlairPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	community, err := c.service.CreateFlair(r.Context(), mux.Vars(r)["COMMUNITY_NAME"], payload)
+	switch {
+	case errors.Is(err, errs.ErrBadFlair):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "flair",
+			Value:    payload,
+			Msg:      errs.ErrBadFlair.Error(),
+		}))
+		return
+	case errors.Is(err, errs.ErrTooManyFlairs):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "path",
+			Param:    "COMMUNITY_NAME",
+			Value:    mux.Vars(r)["COMMUNITY_NAME"],
+			Msg:      "has too many flairs",
+		}))
+		return
+	case errors.Is(err, errs.ErrNotModerator):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotModerator.Error()))
+		return
+	case errors.Is(err, errs.ErrCommunityNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommunityNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(community, w, httpresp.WithStatusCode(http.StatusCreated))
+}
+
+// DeleteFlair godoc
+//
+//	@Summary		Delete a flair
+//	@Description	Remove a flair template from the community. Posts keep the flairs they already have
+//	@Security		ApiKeyAuth
+//	@Tags			communities
+//	@ID				delete-flair
+//	@Produce		json
+//	@Param			COMMUNITY_NAME	path		string					true	"Community name"
+//	@Param			FLAIR_ID		path		string					true	"Flair uuid"	minlength(36)	maxlength(36)
+//	@Success		200				{object}	communities.Community	"Flair successfully deleted"
+//	@Failure		400				{object}	errs.SimpleErr			"Bad uuid"
+//	@Failure		403				{object}	errs.SimpleErr			"User is not a moderator of the community"
+//	@Failure		404				{object}	errs.SimpleErr			"No communities or flairs with the provided name and id were found"
+//	@Failure		500				{object}	errs.SimpleErr			"Internal server error"
+//	@Router			/community/{COMMUNITY_NAME}/flairs/{FLAIR_ID} [delete]
+func (c *CommunityHandler) DeleteFlair(w http.ResponseWriter, r *http.Request) {
+	flairID, err := validateID("FLAIR_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	community, err := c.service.DeleteFlair(r.Context(), mux.Vars(r)["COMMUNITY_NAME"], flairID)
+	switch {
+	case errors.Is(err, errs.ErrNotModerator):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotModerator.Error()))
+		return
+	case errors.Is(err, errs.ErrCommunityNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommunityNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrInvalidFlair):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrInvalidFlair.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(community, w)
+}
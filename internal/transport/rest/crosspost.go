@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/httpresp"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// Crosspost godoc
+//
+//	@Summary		Crosspost a post
+//	@Description	Create a post in another community that refers to the original one. Crossposts of crossposts refer to the original
+//	@Security		ApiKeyAuth
+//	@Tags			managing-posts
+//	@ID				crosspost
+//	@Accept			json
+//	@Produce		json
+//	@Param			crosspost_payload	body		posts.CrosspostPayload	true	"Target community"	validate(required)
+//	@Param			POST_ID				path		string					true	"Post uuid"			minlength(36)	maxlength(36)
+//	@Success		201					{object}	posts.Post				"Crosspost successfully created"
+//	@Failure		400					{object}	errs.SimpleErr			"Bad post id or payload"
+//	@Failure		404					{object}	errs.SimpleErr			"No posts with the provided id were found"
+//	@Failure		410					{object}	errs.SimpleErr			"Original post has been removed"
+//	@Failure		422					{object}	errs.ComplexErrArr		"Bad community"
+//	@Failure		500					{object}	errs.SimpleErr			"Internal server error"
+//	@Router			/post/{POST_ID}/crosspost [post]
+func (p *PostHandler) Crosspost(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := posts.CrosspostPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	crosspost, err := p.service.Crosspost(r.Context(), postID, payload)
+	switch {
+	case errors.Is(err, errs.ErrBadCrosspost):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "category",
+			Value:    payload.Category,
+			Msg:      "must differ from the community of the original post",
+		}))
+		return
+	case errors.Is(err, errs.ErrInvalidCategory):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "category",
+			Value:    payload.Category,
+			Msg:      "is invalid",
+		}))
+		return
+	case errors.Is(err, errs.ErrCrosspostSourceRemoved):
+		sendErrorResponse(w, http.StatusGone, errs.NewSimpleErr(errs.ErrCrosspostSourceRemoved.Error()))
+		return
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(crosspost, w, httpresp.WithStatusCode(http.StatusCreated))
+}
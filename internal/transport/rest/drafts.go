@@ -0,0 +1,238 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/httpresp"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// CreateDraft godoc
+//
+//	@Summary		Create draft
+//	@Description	Save an unfinished post. The content is not validated until the draft is published
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				create-draft
+//	@Accept			json
+//	@Produce		json
+//	@Param			post_payload	body		posts.PostPayload	true	"Post data, any field may be missing"
+//	@Success		201				{object}	posts.Draft			"Draft successfully saved"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		422				{object}	errs.SimpleErr		"Too many drafts"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501				{object}	errs.SimpleErr		"Drafts are disabled"
+//	@Router			/me/drafts [post]
+func (p *PostHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	postPayload, ok := readDraftPayload(w, r)
+	if !ok {
+		return
+	}
+
+	draft, err := p.service.CreateDraft(r.Context(), postPayload)
+	if err != nil {
+		sendDraftError(w, err)
+		return
+	}
+
+	sendResponse(draft, w, httpresp.WithStatusCode(http.StatusCreated))
+}
+
+// GetDrafts godoc
+//
+//	@Summary		Get drafts
+//	@Description	Get the drafts of the user, the most recently saved first
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				get-drafts
+//	@Produce		json
+//	@Success		200	{array}		posts.Draft		"Drafts successfully received"
+//	@Failure		500	{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501	{object}	errs.SimpleErr	"Drafts are disabled"
+//	@Router			/me/drafts [get]
+func (p *PostHandler) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	draftList, err := p.service.GetDrafts(r.Context())
+	if err != nil {
+		sendDraftError(w, err)
+		return
+	}
+
+	sendResponse(draftList, w)
+}
+
+// GetDraft godoc
+//
+//	@Summary		Get draft
+//	@Description	Get a draft of the user
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				get-draft
+//	@Produce		json
+//	@Param			DRAFT_ID	path		string			true	"Draft uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Draft		"Draft successfully received"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr	"The user has no drafts with the provided id"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr	"Drafts are disabled"
+//	@Router			/me/drafts/{DRAFT_ID} [get]
+func (p *PostHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := validateID("DRAFT_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	draft, err := p.service.GetDraft(r.Context(), draftID)
+	if err != nil {
+		sendDraftError(w, err)
+		return
+	}
+
+	sendResponse(draft, w)
+}
+
+// UpdateDraft godoc
+//
+//	@Summary		Autosave draft
+//	@Description	Replace the content of a draft. The content is not validated until the draft is published
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				update-draft
+//	@Accept			json
+//	@Produce		json
+//	@Param			DRAFT_ID		path		string				true	"Draft uuid"	minlength(36)	maxlength(36)
+//	@Param			post_payload	body		posts.PostPayload	true	"Post data, any field may be missing"
+//	@Success		200				{object}	posts.Draft			"Draft successfully saved"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		404				{object}	errs.SimpleErr		"The user has no drafts with the provided id"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501				{object}	errs.SimpleErr		"Drafts are disabled"
+//	@Router			/me/drafts/{DRAFT_ID} [put]
+func (p *PostHandler) UpdateDraft(w http.ResponseWriter, r *http.Request) {
+	postPayload, ok := readDraftPayload(w, r)
+	if !ok {
+		return
+	}
+
+	draftID, err := validateID("DRAFT_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	draft, err := p.service.UpdateDraft(r.Context(), draftID, postPayload)
+	if err != nil {
+		sendDraftError(w, err)
+		return
+	}
+
+	sendResponse(draft, w)
+}
+
+// DeleteDraft godoc
+//
+//	@Summary		Delete draft
+//	@Description	Delete a draft of the user
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				delete-draft
+//	@Produce		json
+//	@Param			DRAFT_ID	path		string			true	"Draft uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	errs.SimpleErr	"Draft successfully deleted"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr	"The user has no drafts with the provided id"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr	"Drafts are disabled"
+//	@Router			/me/drafts/{DRAFT_ID} [delete]
+func (p *PostHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := validateID("DRAFT_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	if err = p.service.DeleteDraft(r.Context(), draftID); err != nil {
+		sendDraftError(w, err)
+		return
+	}
+
+	sendResponse(errs.NewSimpleErr("success"), w)
+}
+
+// PublishDraft godoc
+//
+//	@Summary		Publish draft
+//	@Description	Create a post from a draft. The draft is validated as a new post and removed once published
+//	@Security		ApiKeyAuth
+//	@Tags			drafts
+//	@ID				publish-draft
+//	@Produce		json
+//	@Param			DRAFT_ID	path		string				true	"Draft uuid"	minlength(36)	maxlength(36)
+//	@Success		201			{object}	posts.Post			"Post successfully created"
+//	@Failure		400			{object}	errs.SimpleErr		"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr		"The user has no drafts with the provided id"
+//	@Failure		409			{object}	errs.SimpleErr		"The link has already been posted to the category recently"
+//	@Failure		422			{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500			{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr		"Drafts are disabled"
+//	@Router			/me/drafts/{DRAFT_ID}/publish [post]
+func (p *PostHandler) PublishDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := validateID("DRAFT_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	post, err := p.service.PublishDraft(r.Context(), draftID)
+	switch {
+	case errors.Is(err, errs.ErrDraftNotFound), errors.Is(err, errs.ErrFeatureDisabled):
+		sendDraftError(w, err)
+		return
+	case err != nil:
+		// The content of the draft is not at hand here, so only the invalid field is named
+		sendPayloadError(w, posts.PostPayload{}, err)
+		return
+	}
+
+	p.logger.Infow("Draft has been published",
+		"draft", draftID,
+		"post", post.ID,
+		"remote_addr", r.RemoteAddr,
+	)
+	sendResponse(post, w, httpresp.WithStatusCode(http.StatusCreated))
+}
+
+func readDraftPayload(w http.ResponseWriter, r *http.Request) (posts.PostPayload, bool) {
+	defer r.Body.Close()
+	postPayload := posts.PostPayload{}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return postPayload, false
+	}
+	if err = json.Unmarshal(body, &postPayload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return postPayload, false
+	}
+
+	return postPayload, true
+}
+
+func sendDraftError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrDraftNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrDraftNotFound.Error()))
+	case errors.Is(err, errs.ErrTooManyDrafts):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewSimpleErr(errs.ErrTooManyDrafts.Error()))
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+	}
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// SetPostFlags godoc
+//
+//	@Summary		Set post flags
+//	@Description	Mark the post as NSFW and/or spoiler. Both the author and the moderators can do it
+//	@Security		ApiKeyAuth
+//	@Tags			flags
+//	@ID				set-post-flags
+//	@Accept			json
+//	@Produce		json
+//	@Param			POST_ID			path		string				true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Param			flags_payload	body		posts.FlagsPayload	true	"New flags"	validate(required)
+//	@Success		200				{object}	posts.Post			"Flags successfully set"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		403				{object}	errs.SimpleErr		"User is neither the author nor a moderator"
+//	@Failure		404				{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/flags [put]
+func (p *PostHandler) SetPostFlags(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := posts.FlagsPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.SetPostFlags(r.Context(), postID, payload)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrNotAuthor):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotAuthor.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// SetPostFlair godoc
+//
+//	@Summary		Set post flair
+//	@Description	Pick one of the flairs of the community for the post or remove the flair with an empty id. Both the author and the moderators can do it
+//	@Security		ApiKeyAuth
+//	@Tags			flairs
+//	@ID				set-post-flair
+//	@Accept			json
+//	@Produce		json
+//	@Param			POST_ID			path		string				true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Param			flair_payload	body		posts.FlairPayload	true	"Flair id"	validate(required)
+//	@Success		200				{object}	posts.Post			"Flair successfully set"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		403				{object}	errs.SimpleErr		"User is neither the author nor a moderator"
+//	@Failure		404				{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/flair [put]
+func (p *PostHandler) SetPostFlair(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := posts.FlairPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.SetPostFlair(r.Context(), postID, payload)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrNotAuthor):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotAuthor.Error()))
+		return
+	case errors.Is(err, errs.ErrInvalidFlair):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "flairId",
+			Value:    payload.FlairID,
+			Msg:      "is not a flair of the community",
+		}))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
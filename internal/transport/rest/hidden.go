@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// HidePost godoc
+//
+//	@Summary		Hide post
+//	@Description	Remove a post from the feeds of the user. The post can still be opened by its id
+//	@Security		ApiKeyAuth
+//	@Tags			hiding
+//	@ID				hide-post
+//	@Produce		json
+//	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Success		200		{object}	posts.Post		"Post successfully hidden"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/hide [post]
+func (p *PostHandler) HidePost(w http.ResponseWriter, r *http.Request) {
+	p.handleHide(w, r, p.service.HidePost)
+}
+
+// UnhidePost godoc
+//
+//	@Summary		Unhide post
+//	@Description	Bring a hidden post back to the feeds of the user
+//	@Security		ApiKeyAuth
+//	@Tags			hiding
+//	@ID				unhide-post
+//	@Produce		json
+//	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Success		200		{object}	posts.Post		"Post successfully unhidden"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/unhide [post]
+func (p *PostHandler) UnhidePost(w http.ResponseWriter, r *http.Request) {
+	p.handleHide(w, r, p.service.UnhidePost)
+}
+
+// GetHiddenPosts godoc
+//
+//	@Summary		Get hidden posts
+//	@Description	Get the posts hidden by the user, the newest first
+//	@Security		ApiKeyAuth
+//	@Tags			hiding
+//	@ID				get-hidden-posts
+//	@Produce		json
+//	@Param			limit	query		int				false	"Page size"					minimum(1)	maximum(100)	default(25)
+//	@Param			offset	query		int				false	"Number of posts to skip"	minimum(0)	default(0)
+//	@Success		200		{array}		posts.Post		"Posts successfully received"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad page"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/me/hidden [get]
+func (p *PostHandler) GetHiddenPosts(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+
+	postList, err := p.service.GetHiddenPosts(r.Context(), page)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(postList, w)
+}
+
+func (p *PostHandler) handleHide(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, postID users.ID) (*posts.Post, error)) {
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := action(r.Context(), postID)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
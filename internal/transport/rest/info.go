@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+)
+
+// GetPostsByURL godoc
+//
+//	@Summary		Other discussions
+//	@Description	Get all the posts of a link. Links differing only in tracking parameters, www, trailing slashes or known shorteners are the same
+//	@Tags			getting-posts
+//	@ID				get-posts-by-url
+//	@Produce		json
+//	@Param			url	query		string			true	"Link to look up"
+//	@Success		200	{array}		posts.Post		"Posts successfully received"
+//	@Failure		400	{object}	errs.SimpleErr	"Bad url"
+//	@Failure		500	{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/info [get]
+func (p *PostHandler) GetPostsByURL(w http.ResponseWriter, r *http.Request) {
+	rawURL := r.URL.Query().Get("url")
+	if rawURL == "" {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidURL.Error()))
+		return
+	}
+
+	postList, err := p.service.GetPostsByURL(r.Context(), rawURL)
+	switch {
+	case errors.Is(err, errs.ErrInvalidURL):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidURL.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(postList, w)
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// LockPost godoc
+//
+//	@Summary		Lock post
+//	@Description	Stop the post from getting new comments and, optionally, votes. Only the moderators of the community can do it
+//	@Security		ApiKeyAuth
+//	@Tags			moderation
+//	@ID				lock-post
+//	@Accept			json
+//	@Produce		json
+//	@Param			POST_ID			path		string				true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			lock_payload	body		posts.LockPayload	true	"Lock settings"	validate(required)
+//	@Success		200				{object}	posts.Post			"Post successfully locked"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		403				{object}	errs.SimpleErr		"User is not a moderator of the community"
+//	@Failure		404				{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/lock [put]
+func (p *PostHandler) LockPost(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := posts.LockPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.LockPost(r.Context(), postID, payload)
+	if err != nil {
+		sendLockError(w, err)
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+// UnlockPost godoc
+//
+//	@Summary		Unlock post
+//	@Description	Let the post get comments and votes again. Only the moderators of the community can do it
+//	@Security		ApiKeyAuth
+//	@Tags			moderation
+//	@ID				unlock-post
+//	@Produce		json
+//	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Success		200		{object}	posts.Post		"Post successfully unlocked"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		403		{object}	errs.SimpleErr	"User is not a moderator of the community"
+//	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/lock [delete]
+func (p *PostHandler) UnlockPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.UnlockPost(r.Context(), postID)
+	if err != nil {
+		sendLockError(w, err)
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+func sendLockError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+	case errors.Is(err, errs.ErrNotModerator):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotModerator.Error()))
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+	}
+}
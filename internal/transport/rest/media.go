@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/httpresp"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+//go:generate mockgen -source=media.go -destination=../../storage/mocks/blob_store_mock.go -package=mocks MediaAPI
+type MediaAPI interface {
+	GetBlob(ctx context.Context, key string) (io.ReadCloser, *media.BlobInfo, error)
+}
+
+type MediaHandler struct {
+	logger  *zap.SugaredLogger
+	service MediaAPI
+}
+
+func NewMediaHandler(m MediaAPI, logger *zap.SugaredLogger) *MediaHandler {
+	return &MediaHandler{
+		logger:  logger,
+		service: m,
+	}
+}
+
+// multipartOverhead leaves room for the form fields and boundaries around the image itself
+const multipartOverhead int64 = 1 << 20
+
+// CreateImagePost godoc
+//
+//	@Summary		Create an image post
+//	@Description	Upload an image and create a post with it. The image is stripped of its metadata and gets a thumbnail
+//	@Security		ApiKeyAuth
+//	@Tags			managing-posts
+//	@ID				create-image-post
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			title		formData	string				true	"Post title"
+//	@Param			category	formData	string				true	"Community name"
+//	@Param			image		formData	file				true	"JPEG, PNG or GIF image up to 10 MiB"
+//	@Success		201			{object}	posts.Post			"Post successfully created"
+//	@Failure		400			{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		413			{object}	errs.SimpleErr		"Image is too large"
+//	@Failure		415			{object}	errs.SimpleErr		"Unsupported image type"
+//	@Failure		422			{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500			{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/posts/image [post]
+func (p *PostHandler) CreateImagePost(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, media.MaxImageSize+multipartOverhead)
+	defer r.Body.Close()
+
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			sendErrorResponse(w, http.StatusRequestEntityTooLarge, errs.NewSimpleErr(errs.ErrImageTooLarge.Error()))
+			return
+		}
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+	defer r.MultipartForm.RemoveAll() //nolint:errcheck
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, media.MaxImageSize+1))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+
+	postPayload := posts.PostPayload{
+		Type:     posts.WithImage,
+		Title:    r.FormValue("title"),
+		Category: posts.PostCategory(r.FormValue("category")),
+		FlairID:  users.ID(r.FormValue("flairId")),
+	}
+	newPost, err := p.service.CreateImagePost(r.Context(), postPayload, image)
+	switch {
+	case errors.Is(err, errs.ErrImageTooLarge):
+		sendErrorResponse(w, http.StatusRequestEntityTooLarge, errs.NewSimpleErr(errs.ErrImageTooLarge.Error()))
+		return
+	case errors.Is(err, errs.ErrUnsupportedMedia):
+		sendErrorResponse(w, http.StatusUnsupportedMediaType, errs.NewSimpleErr(errs.ErrUnsupportedMedia.Error()))
+		return
+	case errors.Is(err, errs.ErrBadImage):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "image",
+			Msg:      "is corrupted",
+		}))
+		return
+	case errors.Is(err, errs.ErrInvalidCategory):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "category",
+			Value:    postPayload.Category,
+			Msg:      "is invalid",
+		}))
+		return
+	case errors.Is(err, errs.ErrInvalidFlair):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "flairId",
+			Value:    postPayload.FlairID,
+			Msg:      "is not a flair of the community",
+		}))
+		return
+	case errors.Is(err, errs.ErrTooManyMentions):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "text",
+			Msg:      "must mention at most 10 users",
+		}))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(newPost, w, httpresp.WithStatusCode(http.StatusCreated))
+}
+
+// GetMedia godoc
+//
+//	@Summary		Get uploaded media
+//	@Description	Get an uploaded image or its thumbnail. Media never changes, so it is cached for a year
+//	@Tags			media
+//	@ID				get-media
+//	@Produce		image/jpeg,image/png,image/gif
+//	@Param			MEDIA_KEY	path	string	true	"Media key"
+//	@Success		200			{file}	binary	"Media content"
+//	@Success		304			"Not modified"
+//	@Failure		404			{object}	errs.SimpleErr	"No media with the provided key was found"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/media/{MEDIA_KEY} [get]
+func (m *MediaHandler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["MEDIA_KEY"]
+	etag := strconv.Quote(key)
+	if r.Header.Get("If-None-Match") == etag {
+		w.Header().Set("Cache-Control", media.CacheControl)
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	blob, info, err := m.service.GetBlob(r.Context(), key)
+	switch {
+	case errors.Is(err, errs.ErrBlobNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrBlobNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", info.ContentType)
+	if info.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	}
+	if !info.ModTime.IsZero() {
+		w.Header().Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", media.CacheControl)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, blob); err != nil {
+		m.logger.Warnw("Media streaming failed",
+			"key", key,
+			"reason", err.Error(),
+		)
+	}
+}
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+)
+
+// GetMentions godoc
+//
+//	@Summary		Get mentions
+//	@Description	Get the posts and comments the user has been mentioned in as @username or /u/username, the most recent first
+//	@Security		ApiKeyAuth
+//	@Tags			mentions
+//	@ID				get-mentions
+//	@Produce		json
+//	@Param			limit	query		int					false	"Page size"						minimum(1)	maximum(100)	default(25)
+//	@Param			offset	query		int					false	"Number of mentions to skip"	minimum(0)	default(0)
+//	@Success		200		{array}		posts.MentionEvent	"Mentions successfully received"
+//	@Failure		400		{object}	errs.SimpleErr		"Bad page"
+//	@Failure		500		{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501		{object}	errs.SimpleErr		"Mentions are disabled"
+//	@Router			/me/mentions [get]
+func (p *PostHandler) GetMentions(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r.URL.Query())
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+
+	events, err := p.service.GetMentions(r.Context(), page)
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(events, w)
+}
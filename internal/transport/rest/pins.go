@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// PinPost godoc
+//
+//	@Summary		Pin post
+//	@Description	Pin the post on top of its category (moderators) or of the front page (admins). Pinning an already pinned post moves it to the new position
+//	@Security		ApiKeyAuth
+//	@Tags			pins
+//	@ID				pin-post
+//	@Accept			json
+//	@Produce		json
+//	@Param			POST_ID		path		string				true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			pin_payload	body		posts.PinPayload	true	"Pin settings"	validate(required)
+//	@Success		200			{object}	posts.Post			"Post successfully pinned"
+//	@Failure		400			{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		403			{object}	errs.SimpleErr		"User is neither a moderator of the community nor an admin"
+//	@Failure		404			{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		422			{object}	errs.SimpleErr		"Bad pin or too many pinned posts"
+//	@Failure		500			{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr		"Pins are disabled"
+//	@Router			/post/{POST_ID}/pin [put]
+func (p *PostHandler) PinPost(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := posts.PinPayload{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.PinPost(r.Context(), postID, payload)
+	if err != nil {
+		sendPinError(w, err)
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+// UnpinPost godoc
+//
+//	@Summary		Unpin post
+//	@Description	Remove the post from the pins of its category (moderators) or of the front page (admins)
+//	@Security		ApiKeyAuth
+//	@Tags			pins
+//	@ID				unpin-post
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Param			frontPage	query		bool			false	"Unpin from the front page"
+//	@Success		200			{object}	posts.Post		"Post successfully unpinned"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid or query"
+//	@Failure		403			{object}	errs.SimpleErr	"User is neither a moderator of the community nor an admin"
+//	@Failure		404			{object}	errs.SimpleErr	"The post is not found or not pinned"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr	"Pins are disabled"
+//	@Router			/post/{POST_ID}/pin [delete]
+func (p *PostHandler) UnpinPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	frontPage, err := parseBool(r.URL.Query().Get("frontPage"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+
+	post, err := p.service.UnpinPost(r.Context(), postID, frontPage)
+	if err != nil {
+		sendPinError(w, err)
+		return
+	}
+
+	sendResponse(post, w)
+}
+
+func sendPinError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+	case errors.Is(err, errs.ErrPinNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPinNotFound.Error()))
+	case errors.Is(err, errs.ErrNotModerator):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotModerator.Error()))
+	case errors.Is(err, errs.ErrNotAdmin):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotAdmin.Error()))
+	case errors.Is(err, errs.ErrBadPin):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewSimpleErr(errs.ErrBadPin.Error()))
+	case errors.Is(err, errs.ErrTooManyPins):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewSimpleErr(errs.ErrTooManyPins.Error()))
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+	}
+}
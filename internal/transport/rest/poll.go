@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// CastBallot godoc
+//
+//	@Summary		Vote in a poll
+//	@Description	Cast a ballot in a poll post or replace the previous one while the poll is open
+//	@Security		ApiKeyAuth
+//	@Tags			voting-posts
+//	@ID				cast-ballot
+//	@Accept			json
+//	@Produce		json
+//	@Param			ballot_payload	body		posts.BallotPayload	true	"Ids of the picked options"	validate(required)
+//	@Param			POST_ID			path		string				true	"Post uuid"					minlength(36)	maxlength(36)
+//	@Success		200				{object}	posts.Post			"Ballot successfully cast"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad post id or the post is not a poll"
+//	@Failure		403				{object}	errs.SimpleErr		"Poll is closed"
+//	@Failure		404				{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		409				{object}	errs.SimpleErr		"Ballot has been changed concurrently"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad choice"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/poll [post]
+func (p *PostHandler) CastBallot(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ballot := posts.BallotPayload{}
+	if err = json.Unmarshal(body, &ballot); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	post, err := p.service.CastBallot(r.Context(), postID, ballot)
+	switch {
+	case errors.Is(err, errs.ErrBadBallot):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "choice",
+			Value:    ballot.Choice,
+			Msg:      "is invalid",
+		}))
+		return
+	case errors.Is(err, errs.ErrNotAPoll):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrNotAPoll.Error()))
+		return
+	case errors.Is(err, errs.ErrPollClosed):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPollClosed.Error()))
+		return
+	case errors.Is(err, errs.ErrBallotConflict):
+		sendErrorResponse(w, http.StatusConflict, errs.NewSimpleErr(errs.ErrBallotConflict.Error()))
+		return
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
@@ -19,17 +19,58 @@ import (
 
 //go:generate mockgen -source=post.go -destination=../../storage/mocks/posts_repo_mongoDB_mock.go -package=mocks PostAPI
 type PostAPI interface {
-	GetAllPosts(ctx context.Context) ([]*posts.Post, error)
-	GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory) ([]*posts.Post, error)
-	GetPostsByUser(ctx context.Context, userLogin users.Username) ([]*posts.Post, error)
+	GetAllPosts(ctx context.Context, query posts.FeedQuery) ([]*posts.Post, error)
+	GetPostsByCategory(ctx context.Context, postCategory posts.PostCategory, query posts.FeedQuery) ([]*posts.Post, error)
+	GetPostsByUser(ctx context.Context, userLogin users.Username, query posts.FeedQuery) ([]*posts.Post, error)
 	GetPostByID(ctx context.Context, postID users.ID) (*posts.Post, error)
+	GetPostsByURL(ctx context.Context, rawURL string) ([]*posts.Post, error)
 	CreatePost(ctx context.Context, postPayload posts.PostPayload) (*posts.Post, error)
+	CreateImagePost(ctx context.Context, postPayload posts.PostPayload, image []byte) (*posts.Post, error)
 	DeletePost(ctx context.Context, postID users.ID) error
 	AddComment(ctx context.Context, postID users.ID, comment posts.Comment) (*posts.Post, error)
 	DeleteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	GetCommentThread(ctx context.Context, postID, commentID users.ID) (*posts.PostComment, error)
+	GetComments(ctx context.Context, postID users.ID, query posts.CommentQuery) (*posts.CommentPage, error)
+	EditComment(ctx context.Context, postID, commentID users.ID, comment posts.Comment) (*posts.Post, error)
+	GetCommentRevisions(ctx context.Context, postID, commentID users.ID) ([]*posts.CommentRevision, error)
+	UpvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	DownvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	UnvoteComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	GetKarma(ctx context.Context, userLogin users.Username) (users.Karma, error)
 	Upvote(ctx context.Context, postID users.ID) (*posts.Post, error)
 	Downvote(ctx context.Context, postID users.ID) (*posts.Post, error)
 	Unvote(ctx context.Context, postID users.ID) (*posts.Post, error)
+	SearchPosts(ctx context.Context, query posts.SearchQuery) ([]*posts.Post, error)
+	CastBallot(ctx context.Context, postID users.ID, ballot posts.BallotPayload) (*posts.Post, error)
+	Crosspost(ctx context.Context, postID users.ID, payload posts.CrosspostPayload) (*posts.Post, error)
+	SavePost(ctx context.Context, postID users.ID) (*posts.Post, error)
+	UnsavePost(ctx context.Context, postID users.ID) (*posts.Post, error)
+	SaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	UnsaveComment(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+	GetSaved(ctx context.Context, query posts.SavedQuery) ([]*posts.SavedEntry, error)
+	HidePost(ctx context.Context, postID users.ID) (*posts.Post, error)
+	UnhidePost(ctx context.Context, postID users.ID) (*posts.Post, error)
+	GetHiddenPosts(ctx context.Context, page posts.Page) ([]*posts.Post, error)
+	GetMentions(ctx context.Context, page posts.Page) ([]*posts.MentionEvent, error)
+	SetPostFlair(ctx context.Context, postID users.ID, payload posts.FlairPayload) (*posts.Post, error)
+	SetPostFlags(ctx context.Context, postID users.ID, flags posts.FlagsPayload) (*posts.Post, error)
+	GetPreferences(ctx context.Context) (users.Preferences, error)
+	UpdatePreferences(ctx context.Context, prefs users.Preferences) (users.Preferences, error)
+	SchedulePost(ctx context.Context, postPayload posts.PostPayload) (*posts.ScheduledPost, error)
+	GetScheduledPosts(ctx context.Context) ([]*posts.ScheduledPost, error)
+	UpdateScheduledPost(ctx context.Context, scheduledID users.ID, postPayload posts.PostPayload) (*posts.ScheduledPost, error)
+	CancelScheduledPost(ctx context.Context, scheduledID users.ID) error
+	CreateDraft(ctx context.Context, postPayload posts.PostPayload) (*posts.Draft, error)
+	GetDrafts(ctx context.Context) ([]*posts.Draft, error)
+	GetDraft(ctx context.Context, draftID users.ID) (*posts.Draft, error)
+	UpdateDraft(ctx context.Context, draftID users.ID, postPayload posts.PostPayload) (*posts.Draft, error)
+	DeleteDraft(ctx context.Context, draftID users.ID) error
+	PublishDraft(ctx context.Context, draftID users.ID) (*posts.Post, error)
+	PinPost(ctx context.Context, postID users.ID, payload posts.PinPayload) (*posts.Post, error)
+	UnpinPost(ctx context.Context, postID users.ID, frontPage bool) (*posts.Post, error)
+	LockPost(ctx context.Context, postID users.ID, payload posts.LockPayload) (*posts.Post, error)
+	UnlockPost(ctx context.Context, postID users.ID) (*posts.Post, error)
+	GetPostStats(ctx context.Context, postID users.ID, query posts.StatsQuery) (*posts.PostStats, error)
 }
 
 type PostHandler struct {
@@ -60,11 +101,20 @@ func validateID(alias string, params map[string]string) (id users.ID, err error)
 //	@Tags			getting-posts
 //	@ID				get-all-posts
 //	@Produce		json
-//	@Success		200	{array}		posts.Post		"Posts successfully received"
-//	@Failure		500	{object}	errs.SimpleErr	"Internal server error"
+//	@Param			flair	query		string			false	"Text of the flair"
+//	@Param			from	query		string			false	"Created not earlier than (2006-01-02 or RFC 3339)"
+//	@Param			to		query		string			false	"Created not later than (2006-01-02 or RFC 3339)"
+//	@Success		200		{array}		posts.Post		"Posts successfully received"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad time range"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/posts/ [get]
 func (p *PostHandler) GetAllPosts(w http.ResponseWriter, r *http.Request) {
-	postList, err := p.service.GetAllPosts(r.Context())
+	query, err := parseFeedQuery(r.URL.Query())
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadFeedQuery.Error()))
+		return
+	}
+	postList, err := p.service.GetAllPosts(r.Context(), query)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -76,7 +126,8 @@ func (p *PostHandler) GetAllPosts(w http.ResponseWriter, r *http.Request) {
 // CreatePost godoc
 //
 //	@Summary		Create a post
-//	@Description	Create a post of a specific type, category, and content
+//	@Description	Create a post of a specific type, category, and content. A post with a future publishAt is scheduled instead and a posts.ScheduledPost is returned.
+//	@Description	Recent posts of the same link in the category are listed in reposts, or the post is refused if reposts are rejected
 //	@Security		ApiKeyAuth
 //	@Tags			managing-posts
 //	@ID				create-post
@@ -85,6 +136,7 @@ func (p *PostHandler) GetAllPosts(w http.ResponseWriter, r *http.Request) {
 //	@Param			post_payload	body		posts.PostPayload	true	"Post data"	validate(required)
 //	@Success		201				{object}	posts.Post			"Post successfully created"
 //	@Failure		400				"Bad payload"
+//	@Failure		409				{object}	errs.SimpleErr		"The link has already been posted to the category recently"
 //	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
 //	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
 //	@Router			/posts [post]
@@ -101,18 +153,14 @@ func (p *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newPost, err := p.service.CreatePost(r.Context(), postPayload)
-	switch {
-	case errors.Is(err, errs.ErrInvalidURL):
-		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
-			Location: "body",
-			Param:    "url",
-			Value:    postPayload.URL,
-			Msg:      "is invalid",
-		}))
+	if postPayload.PublishAt != nil {
+		p.schedulePost(w, r, postPayload)
 		return
-	case err != nil:
-		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+	}
+
+	newPost, err := p.service.CreatePost(r.Context(), postPayload)
+	if err != nil {
+		sendPayloadError(w, postPayload, err)
 		return
 	}
 
@@ -155,24 +203,31 @@ func (p *PostHandler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 // GetPostsByCategory godoc
 //
 //	@Summary		Get posts by category
-//	@Description	Get all posts belonging to a certain category
+//	@Description	Get all posts belonging to a certain community
 //	@Tags			getting-posts
 //	@ID				get-posts-by-category
 //	@Produce		json
-//	@Param			CATEGORY_NAME	path		string			true	"Category name"
+//	@Param			CATEGORY_NAME	path		string			true	"Community name"
+//	@Param			flair			query		string			false	"Text of the flair"
+//	@Param			from			query		string			false	"Created not earlier than (2006-01-02 or RFC 3339)"
+//	@Param			to				query		string			false	"Created not later than (2006-01-02 or RFC 3339)"
 //	@Success		200				{array}		posts.Post		"Posts successfully received"
-//	@Failure		400				{object}	errs.SimpleErr	"Bad category(doesn't exist)"
+//	@Failure		400				{object}	errs.SimpleErr	"Bad category(doesn't exist) or time range"
 //	@Failure		500				{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/posts/{CATEGORY_NAME} [get]
 func (p *PostHandler) GetPostsByCategory(w http.ResponseWriter, r *http.Request) {
-	postCategory, err := posts.StringToPostCategory(mux.Vars(r)["CATEGORY_NAME"])
+	postCategory := posts.PostCategory(mux.Vars(r)["CATEGORY_NAME"])
+	query, err := parseFeedQuery(r.URL.Query())
 	if err != nil {
-		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCategory.Error()))
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadFeedQuery.Error()))
 		return
 	}
-
-	postList, err := p.service.GetPostsByCategory(r.Context(), postCategory)
-	if err != nil {
+	postList, err := p.service.GetPostsByCategory(r.Context(), postCategory, query)
+	switch {
+	case errors.Is(err, errs.ErrInvalidCategory):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCategory.Error()))
+		return
+	case err != nil:
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
 	}
@@ -188,13 +243,21 @@ func (p *PostHandler) GetPostsByCategory(w http.ResponseWriter, r *http.Request)
 //	@ID				get-posts-by-user
 //	@Produce		json
 //	@Param			USER_LOGIN	path		string			true	"Username of user"
+//	@Param			flair		query		string			false	"Text of the flair"
+//	@Param			from		query		string			false	"Created not earlier than (2006-01-02 or RFC 3339)"
+//	@Param			to			query		string			false	"Created not later than (2006-01-02 or RFC 3339)"
 //	@Success		200			{array}		posts.Post		"Posts successfully received"
-//	@Failure		400			{object}	errs.SimpleErr	"Bad username(doesn't exist)"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad username(doesn't exist) or time range"
 //	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/user/{USER_LOGIN} [get]
 func (p *PostHandler) GetPostsByUser(w http.ResponseWriter, r *http.Request) {
 	userLogin := users.Username(mux.Vars(r)["USER_LOGIN"])
-	postList, err := p.service.GetPostsByUser(r.Context(), userLogin)
+	query, err := parseFeedQuery(r.URL.Query())
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadFeedQuery.Error()))
+		return
+	}
+	postList, err := p.service.GetPostsByUser(r.Context(), userLogin, query)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -247,6 +310,7 @@ func (p *PostHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
 //	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
 //	@Success		200		{object}	posts.Post		"Successfully upvoted"
 //	@Failure		400		{object}	errs.SimpleErr	"Bad post id"
+//	@Failure		403		{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
 //	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
 //	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/post/{POST_ID}/upvote [get]
@@ -262,6 +326,12 @@ func (p *PostHandler) Upvote(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, errs.ErrPostNotFound):
 		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
 		return
+	case errors.Is(err, errs.ErrVotingFrozen):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrVotingFrozen.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
 	case err != nil:
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -281,6 +351,7 @@ func (p *PostHandler) Upvote(w http.ResponseWriter, r *http.Request) {
 //	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
 //	@Success		200		{object}	posts.Post		"Successfully downvoted"
 //	@Failure		400		{object}	errs.SimpleErr	"Bad post id"
+//	@Failure		403		{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
 //	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
 //	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/post/{POST_ID}/downvote [get]
@@ -296,6 +367,12 @@ func (p *PostHandler) Downvote(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, errs.ErrPostNotFound):
 		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
 		return
+	case errors.Is(err, errs.ErrVotingFrozen):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrVotingFrozen.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
 	case err != nil:
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -315,6 +392,7 @@ func (p *PostHandler) Downvote(w http.ResponseWriter, r *http.Request) {
 //	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
 //	@Success		200		{object}	posts.Post		"Successfully unvoted"
 //	@Failure		400		{object}	errs.SimpleErr	"Bad post id"
+//	@Failure		403		{object}	errs.SimpleErr	"Voting on the post is frozen or the post is archived"
 //	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
 //	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
 //	@Router			/post/{POST_ID}/unvote [get]
@@ -330,6 +408,12 @@ func (p *PostHandler) Unvote(w http.ResponseWriter, r *http.Request) {
 	case errors.Is(err, errs.ErrPostNotFound):
 		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
 		return
+	case errors.Is(err, errs.ErrVotingFrozen):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrVotingFrozen.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
 	case err != nil:
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -351,8 +435,9 @@ func (p *PostHandler) Unvote(w http.ResponseWriter, r *http.Request) {
 //	@Param			POST_ID			path		string				true	"Post uuid"		minlength(36)	maxlength(36)
 //	@Success		201				{object}	posts.Post			"Comment successfully left"
 //	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		403				{object}	errs.SimpleErr		"The post is locked or archived"
 //	@Failure		404				{object}	errs.SimpleErr		"No posts with the provided id were found"
-//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content or parent comment"
 //	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
 //	@Router			/posts/{POST_ID} [post]
 func (p *PostHandler) AddComment(w http.ResponseWriter, r *http.Request) {
@@ -383,9 +468,30 @@ func (p *PostHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 			Msg:      "is required",
 		}))
 		return
+	case errors.Is(err, errs.ErrTooManyMentions):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "comment",
+			Msg:      "must mention at most 10 users",
+		}))
+		return
+	case errors.Is(err, errs.ErrBadParentComment):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "parentId",
+			Value:    comment.ParentID,
+			Msg:      "must be a comment on the same post",
+		}))
+		return
 	case errors.Is(err, errs.ErrPostNotFound):
 		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
 		return
+	case errors.Is(err, errs.ErrPostLocked):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostLocked.Error()))
+		return
+	case errors.Is(err, errs.ErrPostArchived):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrPostArchived.Error()))
+		return
 	case err != nil:
 		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
 		return
@@ -397,7 +503,7 @@ func (p *PostHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 // DeleteComment godoc
 //
 //	@Summary		Delete comment
-//	@Description	Delete a certain comment on a certain post
+//	@Description	Delete a certain comment on a certain post. A comment with replies is kept as a "[deleted]" placeholder
 //	@Security		ApiKeyAuth
 //	@Tags			commenting-posts
 //	@ID				delete-comment
@@ -439,3 +545,61 @@ func (p *PostHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	sendResponse(post, w)
 
 }
+
+// sendPayloadError responds with the reason the post payload has been rejected for
+func sendPayloadError(w http.ResponseWriter, postPayload posts.PostPayload, err error) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidURL):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "url",
+			Value:    postPayload.URL,
+			Msg:      "is invalid",
+		}))
+	case errors.Is(err, errs.ErrInvalidPostType):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "type",
+			Value:    postPayload.Type,
+			Msg:      "must be a text, a link or a poll, images are uploaded to /posts/image",
+		}))
+	case errors.Is(err, errs.ErrInvalidCategory):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "category",
+			Value:    postPayload.Category,
+			Msg:      "is invalid",
+		}))
+	case errors.Is(err, errs.ErrInvalidFlair):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "flairId",
+			Value:    postPayload.FlairID,
+			Msg:      "is not a flair of the community",
+		}))
+	case errors.Is(err, errs.ErrBadPoll):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "poll",
+			Value:    postPayload.Poll,
+			Msg:      "must have from 2 to 10 distinct options and close at least 5 minutes and at most a year from now",
+		}))
+	case errors.Is(err, errs.ErrTooManyMentions):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "text",
+			Msg:      "must mention at most 10 users",
+		}))
+	case errors.Is(err, errs.ErrBadPublishTime):
+		sendErrorResponse(w, http.StatusUnprocessableEntity, errs.NewComplexErrArr(errs.ComplexErr{
+			Location: "body",
+			Param:    "publishAt",
+			Value:    postPayload.PublishAt,
+			Msg:      "must be in the future and at most 90 days from now",
+		}))
+	case errors.Is(err, errs.ErrRepost):
+		sendErrorResponse(w, http.StatusConflict, errs.NewSimpleErr(errs.ErrRepost.Error()))
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+	}
+}
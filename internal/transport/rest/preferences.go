@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// GetPreferences godoc
+//
+//	@Summary		Get preferences
+//	@Description	Get the settings of the user that change what the app shows to him/her
+//	@Security		ApiKeyAuth
+//	@Tags			preferences
+//	@ID				get-preferences
+//	@Produce		json
+//	@Success		200	{object}	users.Preferences	"Preferences successfully received"
+//	@Failure		500	{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501	{object}	errs.SimpleErr		"Preferences are disabled"
+//	@Router			/me/preferences [get]
+func (p *PostHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := p.service.GetPreferences(r.Context())
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(prefs, w)
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		Update preferences
+//	@Description	Replace the settings of the user, e.g. allow NSFW posts in the feeds
+//	@Security		ApiKeyAuth
+//	@Tags			preferences
+//	@ID				update-preferences
+//	@Accept			json
+//	@Produce		json
+//	@Param			preferences	body		users.Preferences	true	"New preferences"	validate(required)
+//	@Success		200			{object}	users.Preferences	"Preferences successfully updated"
+//	@Failure		400			"Bad payload"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr	"Preferences are disabled"
+//	@Router			/me/preferences [put]
+func (p *PostHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	prefs := users.Preferences{}
+	if err = json.Unmarshal(body, &prefs); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	prefs, err = p.service.UpdatePreferences(r.Context(), prefs)
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(prefs, w)
+}
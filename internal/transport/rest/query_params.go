@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+const dateFormat = "2006-01-02"
+
+// parsePage extracts the limit and offset query parameters, omitted ones are set to zero
+func parsePage(query url.Values) (posts.Page, error) {
+	page := posts.Page{}
+	var err error
+	if limit := query.Get("limit"); limit != "" {
+		if page.Limit, err = strconv.Atoi(limit); err != nil {
+			return page, err
+		}
+	}
+	if offset := query.Get("offset"); offset != "" {
+		if page.Offset, err = strconv.Atoi(offset); err != nil {
+			return page, err
+		}
+	}
+
+	return page, nil
+}
+
+// parseFeedQuery extracts the filters of a feed
+func parseFeedQuery(query url.Values) (posts.FeedQuery, error) {
+	from, err := parseTime(query.Get("from"))
+	if err != nil {
+		return posts.FeedQuery{}, errs.ErrBadFeedQuery
+	}
+	to, err := parseEndTime(query.Get("to"))
+	if err != nil || (!from.IsZero() && !to.IsZero() && to.Before(from)) {
+		return posts.FeedQuery{}, errs.ErrBadFeedQuery
+	}
+
+	return posts.FeedQuery{
+		Flair: query.Get("flair"),
+		From:  from,
+		To:    to,
+	}, nil
+}
+
+// parseCommentQuery extracts the order of the comments and the page to list
+func parseCommentQuery(query url.Values) (posts.CommentQuery, error) {
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			return posts.CommentQuery{}, errs.ErrBadCommentQuery
+		}
+	}
+	after := users.ID(query.Get("after"))
+	if after != "" && utf8.RuneCountInString(string(after)) != posts.UUIDLength {
+		return posts.CommentQuery{}, errs.ErrBadCommentQuery
+	}
+
+	return posts.NewCommentQuery(posts.CommentSort(query.Get("sort")), limit, after)
+}
+
+// parseTime accepts both a date and a full RFC 3339 timestamp, an empty value gives the zero time
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(dateFormat, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseEndTime is parseTime that treats a bare date as the end of that day
+func parseEndTime(value string) (time.Time, error) {
+	t, err := parseTime(value)
+	if err != nil || len(value) != len(dateFormat) {
+		return t, err
+	}
+
+	return t.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// parseBool treats an omitted flag as false
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
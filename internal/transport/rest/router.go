@@ -14,14 +14,18 @@ import (
 )
 
 type AppRouter struct {
-	userHandler *UserHandler
-	postHandler *PostHandler
+	userHandler      *UserHandler
+	postHandler      *PostHandler
+	communityHandler *CommunityHandler
+	mediaHandler     *MediaHandler
 }
 
-func NewAppRouter(u *UserHandler, p *PostHandler) *AppRouter {
+func NewAppRouter(u *UserHandler, p *PostHandler, c *CommunityHandler, m *MediaHandler) *AppRouter {
 	return &AppRouter{
-		userHandler: u,
-		postHandler: p,
+		userHandler:      u,
+		postHandler:      p,
+		communityHandler: c,
+		mediaHandler:     m,
 	}
 }
 
@@ -44,17 +48,64 @@ func (rtr *AppRouter) InitRouter(logger *zap.SugaredLogger) http.Handler {
 	r.HandleFunc("/api/login", rtr.userHandler.LoginUser).Methods(http.MethodPost)
 	r.HandleFunc("/api/posts/", rtr.postHandler.GetAllPosts).Methods(http.MethodGet)
 	r.HandleFunc("/api/posts", rtr.postHandler.CreatePost).Methods(http.MethodPost)
+	r.HandleFunc("/api/posts/image", rtr.postHandler.CreateImagePost).Methods(http.MethodPost)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+$}", rtr.postHandler.GetPostByID).Methods(http.MethodGet)
 	r.HandleFunc("/api/posts/{CATEGORY_NAME:[0-9a-zA-Z_-]+$}", rtr.postHandler.GetPostsByCategory).Methods(http.MethodGet)
 	r.HandleFunc("/api/user/{USER_LOGIN:[0-9a-zA-Z_-]+$}", rtr.postHandler.GetPostsByUser).Methods(http.MethodGet)
+	r.HandleFunc("/api/user/{USER_LOGIN:[0-9a-zA-Z_-]+}/karma", rtr.postHandler.GetKarma).Methods(http.MethodGet)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+$}", rtr.postHandler.DeletePost).Methods(http.MethodDelete)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/upvote", rtr.postHandler.Upvote).Methods(http.MethodGet)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/downvote", rtr.postHandler.Downvote).Methods(http.MethodGet)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/unvote", rtr.postHandler.Unvote).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/poll", rtr.postHandler.CastBallot).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/crosspost", rtr.postHandler.Crosspost).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/save", rtr.postHandler.SavePost).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/unsave", rtr.postHandler.UnsavePost).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/hide", rtr.postHandler.HidePost).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/unhide", rtr.postHandler.UnhidePost).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/flair", rtr.postHandler.SetPostFlair).Methods(http.MethodPut)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/flags", rtr.postHandler.SetPostFlags).Methods(http.MethodPut)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/pin", rtr.postHandler.PinPost).Methods(http.MethodPut)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/pin", rtr.postHandler.UnpinPost).Methods(http.MethodDelete)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/lock", rtr.postHandler.LockPost).Methods(http.MethodPut)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/lock", rtr.postHandler.UnlockPost).Methods(http.MethodDelete)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/stats", rtr.postHandler.GetPostStats).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/comments", rtr.postHandler.GetComments).Methods(http.MethodGet)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+$}", rtr.postHandler.AddComment).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/save", rtr.postHandler.SaveComment).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/unsave", rtr.postHandler.UnsaveComment).Methods(http.MethodPost)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/upvote", rtr.postHandler.UpvoteComment).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/downvote", rtr.postHandler.DownvoteComment).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/unvote", rtr.postHandler.UnvoteComment).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+}/revisions", rtr.postHandler.GetCommentRevisions).Methods(http.MethodGet)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+$}", rtr.postHandler.GetCommentThread).Methods(http.MethodGet)
 	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+$}", rtr.postHandler.DeleteComment).Methods(http.MethodDelete)
+	r.HandleFunc("/api/post/{POST_ID:[0-9a-fA-F-]+}/{COMMENT_ID:[0-9a-fA-F-]+$}", rtr.postHandler.EditComment).Methods(http.MethodPatch)
+	r.HandleFunc("/api/media/{MEDIA_KEY:[0-9a-zA-Z_-]+\\.[a-z]+$}", rtr.mediaHandler.GetMedia).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/saved", rtr.postHandler.GetSaved).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/hidden", rtr.postHandler.GetHiddenPosts).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/mentions", rtr.postHandler.GetMentions).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/preferences", rtr.postHandler.GetPreferences).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/preferences", rtr.postHandler.UpdatePreferences).Methods(http.MethodPut)
+	r.HandleFunc("/api/me/scheduled", rtr.postHandler.GetScheduledPosts).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/scheduled/{SCHEDULED_ID}", rtr.postHandler.UpdateScheduledPost).Methods(http.MethodPut)
+	r.HandleFunc("/api/me/scheduled/{SCHEDULED_ID}", rtr.postHandler.CancelScheduledPost).Methods(http.MethodDelete)
+	r.HandleFunc("/api/me/drafts", rtr.postHandler.GetDrafts).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/drafts", rtr.postHandler.CreateDraft).Methods(http.MethodPost)
+	r.HandleFunc("/api/me/drafts/{DRAFT_ID}", rtr.postHandler.GetDraft).Methods(http.MethodGet)
+	r.HandleFunc("/api/me/drafts/{DRAFT_ID}", rtr.postHandler.UpdateDraft).Methods(http.MethodPut)
+	r.HandleFunc("/api/me/drafts/{DRAFT_ID}", rtr.postHandler.DeleteDraft).Methods(http.MethodDelete)
+	r.HandleFunc("/api/me/drafts/{DRAFT_ID}/publish", rtr.postHandler.PublishDraft).Methods(http.MethodPost)
+	r.HandleFunc("/api/search", rtr.postHandler.SearchPosts).Methods(http.MethodGet)
+	r.HandleFunc("/api/info", rtr.postHandler.GetPostsByURL).Methods(http.MethodGet)
+	r.HandleFunc("/api/communities", rtr.communityHandler.GetAllCommunities).Methods(http.MethodGet)
+	r.HandleFunc("/api/communities", rtr.communityHandler.CreateCommunity).Methods(http.MethodPost)
+	r.HandleFunc("/api/community/{COMMUNITY_NAME:[0-9a-zA-Z_-]+$}", rtr.communityHandler.GetCommunity).Methods(http.MethodGet)
+	r.HandleFunc("/api/community/{COMMUNITY_NAME:[0-9a-zA-Z_-]+}/flairs", rtr.communityHandler.CreateFlair).Methods(http.MethodPost)
+	r.HandleFunc("/api/community/{COMMUNITY_NAME:[0-9a-zA-Z_-]+}/flairs/{FLAIR_ID:[0-9a-fA-F-]+$}", rtr.communityHandler.DeleteFlair).Methods(http.MethodDelete)
 
 	router := middleware.Auth(r, rtr.userHandler.sessMngr, logger)
+	router = middleware.Fingerprint(router)
 	router = mdwr.AccessLog(logger, router)
 	router = middleware.Panic(router, logger)
 
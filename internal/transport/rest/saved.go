@@ -0,0 +1,168 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+type saveAction func(ctx context.Context, postID, commentID users.ID) (*posts.Post, error)
+
+// SavePost godoc
+//
+//	@Summary		Save post
+//	@Description	Save a post to read it later. Saving an already saved post does nothing
+//	@Security		ApiKeyAuth
+//	@Tags			saving
+//	@ID				save-post
+//	@Produce		json
+//	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Success		200		{object}	posts.Post		"Post successfully saved"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/save [post]
+func (p *PostHandler) SavePost(w http.ResponseWriter, r *http.Request) {
+	p.handleSave(w, r, false, func(ctx context.Context, postID, _ users.ID) (*posts.Post, error) {
+		return p.service.SavePost(ctx, postID)
+	})
+}
+
+// UnsavePost godoc
+//
+//	@Summary		Unsave post
+//	@Description	Remove a post from the saved items
+//	@Security		ApiKeyAuth
+//	@Tags			saving
+//	@ID				unsave-post
+//	@Produce		json
+//	@Param			POST_ID	path		string			true	"Post uuid"	minlength(36)	maxlength(36)
+//	@Success		200		{object}	posts.Post		"Post successfully unsaved"
+//	@Failure		400		{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404		{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/unsave [post]
+func (p *PostHandler) UnsavePost(w http.ResponseWriter, r *http.Request) {
+	p.handleSave(w, r, false, func(ctx context.Context, postID, _ users.ID) (*posts.Post, error) {
+		return p.service.UnsavePost(ctx, postID)
+	})
+}
+
+// SaveComment godoc
+//
+//	@Summary		Save comment
+//	@Description	Save a comment to read it later. Saving an already saved comment does nothing
+//	@Security		ApiKeyAuth
+//	@Tags			saving
+//	@ID				save-comment
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string			true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Post		"Comment successfully saved"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts or comment with the provided id were found"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/save [post]
+func (p *PostHandler) SaveComment(w http.ResponseWriter, r *http.Request) {
+	p.handleSave(w, r, true, p.service.SaveComment)
+}
+
+// UnsaveComment godoc
+//
+//	@Summary		Unsave comment
+//	@Description	Remove a comment from the saved items
+//	@Security		ApiKeyAuth
+//	@Tags			saving
+//	@ID				unsave-comment
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string			true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.Post		"Comment successfully unsaved"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts or comment with the provided id were found"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID}/unsave [post]
+func (p *PostHandler) UnsaveComment(w http.ResponseWriter, r *http.Request) {
+	p.handleSave(w, r, true, p.service.UnsaveComment)
+}
+
+// GetSaved godoc
+//
+//	@Summary		Get saved items
+//	@Description	Get the posts and comments saved by the user, the most recently saved first
+//	@Security		ApiKeyAuth
+//	@Tags			saving
+//	@ID				get-saved
+//	@Produce		json
+//	@Param			type	query		string				false	"Type of the items"			Enums(post, comment)
+//	@Param			limit	query		int					false	"Page size"					minimum(1)	maximum(100)	default(25)
+//	@Param			offset	query		int					false	"Number of items to skip"	minimum(0)	default(0)
+//	@Success		200		{array}		posts.SavedEntry	"Saved items successfully received"
+//	@Failure		400		{object}	errs.SimpleErr		"Bad query"
+//	@Failure		500		{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/me/saved [get]
+func (p *PostHandler) GetSaved(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	page, err := parsePage(params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadPayload.Error()))
+		return
+	}
+	itemType, err := posts.ParseItemType(params.Get("type"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadItemType.Error()))
+		return
+	}
+
+	entries, err := p.service.GetSaved(r.Context(), posts.SavedQuery{Type: itemType, Page: page})
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(entries, w)
+}
+
+func (p *PostHandler) handleSave(w http.ResponseWriter, r *http.Request, withComment bool, action saveAction) {
+	params := mux.Vars(r)
+	postID, err := validateID("POST_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	var commentID users.ID
+	if withComment {
+		if commentID, err = validateID("COMMENT_ID", params); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCommentID.Error()))
+			return
+		}
+	}
+
+	post, err := action(r.Context(), postID, commentID)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrCommentNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommentNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(post, w)
+}
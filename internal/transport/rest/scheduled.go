@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/httpresp"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// GetScheduledPosts godoc
+//
+//	@Summary		Get scheduled posts
+//	@Description	Get the posts the user has scheduled, the earliest to be published first
+//	@Security		ApiKeyAuth
+//	@Tags			scheduling
+//	@ID				get-scheduled-posts
+//	@Produce		json
+//	@Success		200	{array}		posts.ScheduledPost	"Scheduled posts successfully received"
+//	@Failure		500	{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501	{object}	errs.SimpleErr		"Scheduling is disabled"
+//	@Router			/me/scheduled [get]
+func (p *PostHandler) GetScheduledPosts(w http.ResponseWriter, r *http.Request) {
+	scheduledList, err := p.service.GetScheduledPosts(r.Context())
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(scheduledList, w)
+}
+
+// UpdateScheduledPost godoc
+//
+//	@Summary		Edit scheduled post
+//	@Description	Replace the content and the publish time of a post that has not been published yet
+//	@Security		ApiKeyAuth
+//	@Tags			scheduling
+//	@ID				update-scheduled-post
+//	@Accept			json
+//	@Produce		json
+//	@Param			SCHEDULED_ID	path		string				true	"Scheduled post uuid"	minlength(36)	maxlength(36)
+//	@Param			post_payload	body		posts.PostPayload	true	"Post data"				validate(required)
+//	@Success		200				{object}	posts.ScheduledPost	"Scheduled post successfully updated"
+//	@Failure		400				{object}	errs.SimpleErr		"Bad payload"
+//	@Failure		404				{object}	errs.SimpleErr		"The user has no scheduled posts with the provided id"
+//	@Failure		422				{object}	errs.ComplexErrArr	"Bad content"
+//	@Failure		500				{object}	errs.SimpleErr		"Internal server error"
+//	@Failure		501				{object}	errs.SimpleErr		"Scheduling is disabled"
+//	@Router			/me/scheduled/{SCHEDULED_ID} [put]
+func (p *PostHandler) UpdateScheduledPost(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	postPayload := posts.PostPayload{}
+	if err = json.Unmarshal(body, &postPayload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	scheduledID, err := validateID("SCHEDULED_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	scheduled, err := p.service.UpdateScheduledPost(r.Context(), scheduledID, postPayload)
+	switch {
+	case errors.Is(err, errs.ErrScheduledPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrScheduledPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendPayloadError(w, postPayload, err)
+		return
+	}
+
+	sendResponse(scheduled, w)
+}
+
+// CancelScheduledPost godoc
+//
+//	@Summary		Cancel scheduled post
+//	@Description	Remove a post from the schedule so that it is never published
+//	@Security		ApiKeyAuth
+//	@Tags			scheduling
+//	@ID				cancel-scheduled-post
+//	@Produce		json
+//	@Param			SCHEDULED_ID	path		string			true	"Scheduled post uuid"	minlength(36)	maxlength(36)
+//	@Success		200				{object}	errs.SimpleErr	"Scheduled post successfully canceled"
+//	@Failure		400				{object}	errs.SimpleErr	"Bad uuid"
+//	@Failure		404				{object}	errs.SimpleErr	"The user has no scheduled posts with the provided id"
+//	@Failure		500				{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501				{object}	errs.SimpleErr	"Scheduling is disabled"
+//	@Router			/me/scheduled/{SCHEDULED_ID} [delete]
+func (p *PostHandler) CancelScheduledPost(w http.ResponseWriter, r *http.Request) {
+	scheduledID, err := validateID("SCHEDULED_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadID.Error()))
+		return
+	}
+
+	err = p.service.CancelScheduledPost(r.Context(), scheduledID)
+	switch {
+	case errors.Is(err, errs.ErrScheduledPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrScheduledPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(errs.NewSimpleErr("success"), w)
+}
+
+func (p *PostHandler) schedulePost(w http.ResponseWriter, r *http.Request, postPayload posts.PostPayload) {
+	scheduled, err := p.service.SchedulePost(r.Context(), postPayload)
+	switch {
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendPayloadError(w, postPayload, err)
+		return
+	}
+
+	p.logger.Infow("New post has been scheduled",
+		"scheduled", scheduled.ID,
+		"publish_at", scheduled.PublishAt,
+		"remote_addr", r.RemoteAddr,
+	)
+	sendResponse(scheduled, w, httpresp.WithStatusCode(http.StatusCreated))
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+)
+
+// SearchPosts godoc
+//
+//	@Summary		Search posts
+//	@Description	Full-text search over post titles, post texts and comments, ordered by relevance
+//	@Tags			getting-posts
+//	@ID				search-posts
+//	@Produce		json
+//	@Param			q			query		string			true	"Search text"	maxlength(256)
+//	@Param			category	query		string			false	"Community name"
+//	@Param			author		query		string			false	"Username of the author"
+//	@Param			from		query		string			false	"Created not earlier than (2006-01-02 or RFC 3339)"
+//	@Param			to			query		string			false	"Created not later than (2006-01-02 or RFC 3339)"
+//	@Param			limit		query		int				false	"Page size"					minimum(1)	maximum(100)	default(25)
+//	@Param			offset		query		int				false	"Number of posts to skip"	minimum(0)	default(0)
+//	@Success		200			{array}		posts.Post		"Posts successfully found"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad search query"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Router			/search [get]
+func (p *PostHandler) SearchPosts(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	page, err := parsePage(params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadSearchQuery.Error()))
+		return
+	}
+	from, err := parseTime(params.Get("from"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadSearchQuery.Error()))
+		return
+	}
+	to, err := parseEndTime(params.Get("to"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadSearchQuery.Error()))
+		return
+	}
+
+	query := posts.SearchQuery{
+		Text:     params.Get("q"),
+		Category: posts.PostCategory(params.Get("category")),
+		Author:   users.Username(params.Get("author")),
+		From:     from,
+		To:       to,
+		Page:     page,
+	}
+	postList, err := p.service.SearchPosts(r.Context(), query)
+	switch {
+	case errors.Is(err, errs.ErrBadSearchQuery):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadSearchQuery.Error()))
+		return
+	case errors.Is(err, errs.ErrInvalidCategory):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCategory.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(postList, w)
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+)
+
+// GetPostStats godoc
+//
+//	@Summary		Get post stats
+//	@Description	Get the views, upvotes, downvotes and comments of the post over time. Only the author can see them
+//	@Security		ApiKeyAuth
+//	@Tags			stats
+//	@ID				get-post-stats
+//	@Produce		json
+//	@Param			POST_ID		path		string			true	"Post uuid"		minlength(36)		maxlength(36)
+//	@Param			interval	query		string			false	"Bucket width"	Enums(hour, day)	default(hour)
+//	@Param			from		query		string			false	"Buckets starting not earlier than (2006-01-02 or RFC 3339)"
+//	@Param			to			query		string			false	"Buckets starting not later than (2006-01-02 or RFC 3339)"
+//	@Success		200			{object}	posts.PostStats	"Stats successfully received"
+//	@Failure		400			{object}	errs.SimpleErr	"Bad uuid or query"
+//	@Failure		403			{object}	errs.SimpleErr	"User is not the author of the post"
+//	@Failure		404			{object}	errs.SimpleErr	"No posts with the provided id were found"
+//	@Failure		500			{object}	errs.SimpleErr	"Internal server error"
+//	@Failure		501			{object}	errs.SimpleErr	"Stats are disabled"
+//	@Router			/post/{POST_ID}/stats [get]
+func (p *PostHandler) GetPostStats(w http.ResponseWriter, r *http.Request) {
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+
+	params := r.URL.Query()
+	from, err := parseTime(params.Get("from"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadStatsQuery.Error()))
+		return
+	}
+	to, err := parseEndTime(params.Get("to"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadStatsQuery.Error()))
+		return
+	}
+
+	query := posts.StatsQuery{
+		Interval: posts.StatsInterval(params.Get("interval")),
+		From:     from,
+		To:       to,
+	}
+	stats, err := p.service.GetPostStats(r.Context(), postID, query)
+	switch {
+	case errors.Is(err, errs.ErrBadStatsQuery):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadStatsQuery.Error()))
+		return
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrNotAuthor):
+		sendErrorResponse(w, http.StatusForbidden, errs.NewSimpleErr(errs.ErrNotAuthor.Error()))
+		return
+	case errors.Is(err, errs.ErrFeatureDisabled):
+		sendErrorResponse(w, http.StatusNotImplemented, errs.NewSimpleErr(errs.ErrFeatureDisabled.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(stats, w)
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestEditComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, commentID, body string) *http.Request {
+		r := httptest.NewRequest("PATCH", "/api/post/"+postID+"/"+commentID, strings.NewReader(body))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID":    postID,
+			"COMMENT_ID": commentID,
+		})
+	}
+	comment := posts.Comment{Body: "Edited"}
+
+	// Success
+	r := newRequest(string(fakeID), string(fakeID), `{"comment": "Edited"}`)
+	w := httptest.NewRecorder()
+	st.EXPECT().EditComment(r.Context(), fakeID, fakeID, comment).Return(postList[0], nil)
+
+	handler.EditComment(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad payload and ids
+	for _, r = range []*http.Request{
+		newRequest(string(fakeID), string(fakeID), `{"comment": `),
+		newRequest("1", string(fakeID), `{"comment": "Edited"}`),
+		newRequest(string(fakeID), "1", `{"comment": "Edited"}`),
+	} {
+		w = httptest.NewRecorder()
+
+		handler.EditComment(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+	}
+
+	for err, status := range map[error]int{
+		errs.ErrBadCommentBody:   http.StatusUnprocessableEntity,
+		errs.ErrTooManyMentions:  http.StatusUnprocessableEntity,
+		errs.ErrPostNotFound:     http.StatusNotFound,
+		errs.ErrCommentNotFound:  http.StatusNotFound,
+		errs.ErrNotCommentAuthor: http.StatusForbidden,
+		errs.ErrPostLocked:       http.StatusForbidden,
+		errs.ErrPostArchived:     http.StatusForbidden,
+		errs.ErrUnknownError:     http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), string(fakeID), `{"comment": "Edited"}`)
+		w = httptest.NewRecorder()
+		st.EXPECT().EditComment(r.Context(), fakeID, fakeID, comment).Return(nil, err)
+
+		handler.EditComment(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestGetCommentRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, commentID string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/post/"+postID+"/"+commentID+"/revisions", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID":    postID,
+			"COMMENT_ID": commentID,
+		})
+	}
+	revisions := []*posts.CommentRevision{{Body: "First", Created: time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)}}
+
+	// Success
+	r := newRequest(string(fakeID), string(fakeID))
+	w := httptest.NewRecorder()
+	st.EXPECT().GetCommentRevisions(r.Context(), fakeID, fakeID).Return(revisions, nil)
+
+	handler.GetCommentRevisions(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(revisions) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad ids
+	for _, r = range []*http.Request{
+		newRequest("1", string(fakeID)),
+		newRequest(string(fakeID), "1"),
+	} {
+		w = httptest.NewRecorder()
+
+		handler.GetCommentRevisions(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+	}
+
+	for err, status := range map[error]int{
+		errs.ErrPostNotFound:    http.StatusNotFound,
+		errs.ErrCommentNotFound: http.StatusNotFound,
+		errs.ErrNotModerator:    http.StatusForbidden,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), string(fakeID))
+		w = httptest.NewRecorder()
+		st.EXPECT().GetCommentRevisions(r.Context(), fakeID, fakeID).Return(nil, err)
+
+		handler.GetCommentRevisions(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestVoteComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, commentID string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/post/"+postID+"/"+commentID+"/upvote", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID":    postID,
+			"COMMENT_ID": commentID,
+		})
+	}
+
+	for _, tc := range []struct {
+		handle func(http.ResponseWriter, *http.Request)
+		expect func(ctx context.Context) *gomock.Call
+	}{
+		{handler.UpvoteComment, func(ctx context.Context) *gomock.Call { return st.EXPECT().UpvoteComment(ctx, fakeID, fakeID) }},
+		{handler.DownvoteComment, func(ctx context.Context) *gomock.Call { return st.EXPECT().DownvoteComment(ctx, fakeID, fakeID) }},
+		{handler.UnvoteComment, func(ctx context.Context) *gomock.Call { return st.EXPECT().UnvoteComment(ctx, fakeID, fakeID) }},
+	} {
+		// Success
+		r := newRequest(string(fakeID), string(fakeID))
+		w := httptest.NewRecorder()
+		tc.expect(r.Context()).Return(postList[0], nil)
+
+		tc.handle(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, expectedData, body)
+
+		// Bad ids
+		for _, r = range []*http.Request{
+			newRequest("1", string(fakeID)),
+			newRequest(string(fakeID), "1"),
+		} {
+			w = httptest.NewRecorder()
+
+			tc.handle(w, r)
+			resp = w.Result() //nolint:bodyclose
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+		}
+
+		for err, status := range map[error]int{
+			errs.ErrPostNotFound:    http.StatusNotFound,
+			errs.ErrCommentNotFound: http.StatusNotFound,
+			errs.ErrVoteNotFound:    http.StatusNotFound,
+			errs.ErrVotingFrozen:    http.StatusForbidden,
+			errs.ErrPostArchived:    http.StatusForbidden,
+			errs.ErrVoteConflict:    http.StatusConflict,
+			errs.ErrUnknownError:    http.StatusInternalServerError,
+		} {
+			r = newRequest(string(fakeID), string(fakeID))
+			w = httptest.NewRecorder()
+			tc.expect(r.Context()).Return(nil, err)
+
+			tc.handle(w, r)
+			resp = w.Result() //nolint:bodyclose
+
+			assert.Equal(t, status, resp.StatusCode, err.Error())
+		}
+	}
+}
+
+func TestGetKarma(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("GET", "/api/user/admin/karma", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"USER_LOGIN": "admin",
+		})
+	}
+	karma := users.Karma{Post: 3, Comment: 2, Total: 5}
+
+	// Success
+	r := newRequest()
+	w := httptest.NewRecorder()
+	st.EXPECT().GetKarma(r.Context(), users.Username("admin")).Return(karma, nil)
+
+	handler.GetKarma(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(karma) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unknown error
+	r = newRequest()
+	w = httptest.NewRecorder()
+	st.EXPECT().GetKarma(r.Context(), users.Username("admin")).Return(users.Karma{}, errs.ErrUnknownError)
+
+	handler.GetKarma(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,309 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/communities"
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/jwt"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+var (
+	communityPayload = communities.CommunityPayload{
+		Name:        "golang",
+		Description: "Gophers only",
+		Rules: []communities.Rule{
+			{Title: "Be nice"},
+		},
+	}
+	communityList = []*communities.Community{
+		{
+			ID:          "44444444-4444-4444-4444-444444444444",
+			Name:        "golang",
+			Description: "Gophers only",
+			Creator:     *payload,
+			Moderators:  []jwt.TokenPayload{*payload},
+			Rules: []communities.Rule{
+				{Title: "Be nice"},
+			},
+		},
+	}
+)
+
+func TestGetAllCommunities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockCommunityAPI(ctrl)
+	handler := rest.NewCommunityHandler(st, zap.NewNop().Sugar())
+
+	// Success
+	st.EXPECT().GetAllCommunities(context.Background()).Return(communityList, nil)
+
+	r := httptest.NewRequest("GET", "/api/communities", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllCommunities(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(communityList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unknown error
+	st.EXPECT().GetAllCommunities(context.Background()).Return(nil, errs.ErrUnknownError)
+
+	r = httptest.NewRequest("GET", "/api/communities", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllCommunities(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrUnknownError.Error())
+}
+
+func TestGetCommunity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockCommunityAPI(ctrl)
+	handler := rest.NewCommunityHandler(st, zap.NewNop().Sugar())
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/community/golang", nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"COMMUNITY_NAME": "golang",
+	})
+	w := httptest.NewRecorder()
+	st.EXPECT().GetCommunityByName(r.Context(), "golang").Return(communityList[0], nil)
+
+	handler.GetCommunity(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(communityList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Not found
+	r = httptest.NewRequest("GET", "/api/community/ski", nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"COMMUNITY_NAME": "ski",
+	})
+	w = httptest.NewRecorder()
+	st.EXPECT().GetCommunityByName(r.Context(), "ski").Return(nil, errs.ErrCommunityNotFound)
+
+	handler.GetCommunity(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrCommunityNotFound.Error())
+}
+
+func TestCreateCommunity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockCommunityAPI(ctrl)
+	handler := rest.NewCommunityHandler(st, zap.NewNop().Sugar())
+	rawCommunityPayload, _ := json.Marshal(communityPayload) //nolint:errcheck
+
+	// Success
+	st.EXPECT().CreateCommunity(context.Background(), communityPayload).Return(communityList[0], nil)
+	r := httptest.NewRequest("POST", "/api/communities", bytes.NewReader(rawCommunityPayload))
+	w := httptest.NewRecorder()
+
+	handler.CreateCommunity(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(communityList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = httptest.NewRequest("POST", "/api/communities", bytes.NewReader(nil))
+	w = httptest.NewRecorder()
+	handler.CreateCommunity(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Already exists
+	st.EXPECT().CreateCommunity(context.Background(), communityPayload).Return(nil, errs.ErrCommunityExists)
+	r = httptest.NewRequest("POST", "/api/communities", bytes.NewReader(rawCommunityPayload))
+	w = httptest.NewRecorder()
+
+	handler.CreateCommunity(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, string(body), `"msg":"already exists"`)
+
+	// Invalid name
+	st.EXPECT().CreateCommunity(context.Background(), communityPayload).Return(nil, errs.ErrInvalidCommunity)
+	r = httptest.NewRequest("POST", "/api/communities", bytes.NewReader(rawCommunityPayload))
+	w = httptest.NewRecorder()
+
+	handler.CreateCommunity(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, string(body), `"msg":"is invalid"`)
+
+	// Unknown error
+	st.EXPECT().CreateCommunity(context.Background(), communityPayload).Return(nil, errs.ErrUnknownError)
+	r = httptest.NewRequest("POST", "/api/communities", bytes.NewReader(rawCommunityPayload))
+	w = httptest.NewRecorder()
+
+	handler.CreateCommunity(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrUnknownError.Error())
+}
+
+func TestCreateFlair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockCommunityAPI(ctrl)
+	handler := rest.NewCommunityHandler(st, zap.NewNop().Sugar())
+	flairPayload := communities.FlairPayload{Text: "News", Color: "#0079d3"}
+	rawFlairPayload, _ := json.Marshal(flairPayload) //nolint:errcheck
+	newRequest := func(rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("POST", "/api/community/golang/flairs", bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"COMMUNITY_NAME": "golang",
+		})
+	}
+
+	// Success
+	r := newRequest(rawFlairPayload)
+	w := httptest.NewRecorder()
+	st.EXPECT().CreateFlair(r.Context(), "golang", flairPayload).Return(communityList[0], nil)
+
+	handler.CreateFlair(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(communityList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = newRequest(nil)
+	w = httptest.NewRecorder()
+
+	handler.CreateFlair(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrBadFlair:          http.StatusUnprocessableEntity,
+		errs.ErrTooManyFlairs:     http.StatusUnprocessableEntity,
+		errs.ErrNotModerator:      http.StatusForbidden,
+		errs.ErrCommunityNotFound: http.StatusNotFound,
+		errs.ErrUnknownError:      http.StatusInternalServerError,
+	} {
+		r = newRequest(rawFlairPayload)
+		w = httptest.NewRecorder()
+		st.EXPECT().CreateFlair(r.Context(), "golang", flairPayload).Return(nil, err)
+
+		handler.CreateFlair(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestDeleteFlair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockCommunityAPI(ctrl)
+	handler := rest.NewCommunityHandler(st, zap.NewNop().Sugar())
+	flairID := "55555555-5555-5555-5555-555555555555"
+	newRequest := func(flairID string) *http.Request {
+		r := httptest.NewRequest("DELETE", "/api/community/golang/flairs/"+flairID, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"COMMUNITY_NAME": "golang",
+			"FLAIR_ID":       flairID,
+		})
+	}
+
+	// Success
+	r := newRequest(flairID)
+	w := httptest.NewRecorder()
+	st.EXPECT().DeleteFlair(r.Context(), "golang", users.ID(flairID)).Return(communityList[0], nil)
+
+	handler.DeleteFlair(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Invalid flair id
+	r = newRequest("1")
+	w = httptest.NewRecorder()
+
+	handler.DeleteFlair(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrInvalidFlair:      http.StatusNotFound,
+		errs.ErrCommunityNotFound: http.StatusNotFound,
+		errs.ErrNotModerator:      http.StatusForbidden,
+		errs.ErrUnknownError:      http.StatusInternalServerError,
+	} {
+		r = newRequest(flairID)
+		w = httptest.NewRecorder()
+		st.EXPECT().DeleteFlair(r.Context(), "golang", users.ID(flairID)).Return(nil, err)
+
+		handler.DeleteFlair(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestCrosspost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	payload := posts.CrosspostPayload{Category: posts.Funny}
+	newRequest := func(postID, body string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/post/"+postID+"/crosspost", strings.NewReader(body))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), `{"category":"funny"}`)
+	w := httptest.NewRecorder()
+	st.EXPECT().Crosspost(r.Context(), postList[0].ID, payload).Return(postList[0], nil)
+
+	handler.Crosspost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad payload
+	r = newRequest(string(postList[0].ID), `{"category":`)
+	w = httptest.NewRecorder()
+
+	handler.Crosspost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid post id
+	r = newRequest("1", `{"category":"funny"}`)
+	w = httptest.NewRecorder()
+
+	handler.Crosspost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for _, tc := range []struct {
+		err     error
+		status  int
+		message string
+	}{
+		{errs.ErrBadCrosspost, http.StatusUnprocessableEntity, "must differ"},
+		{errs.ErrInvalidCategory, http.StatusUnprocessableEntity, "is invalid"},
+		{errs.ErrCrosspostSourceRemoved, http.StatusGone, errs.ErrCrosspostSourceRemoved.Error()},
+		{errs.ErrPostNotFound, http.StatusNotFound, errs.ErrPostNotFound.Error()},
+		{errs.ErrUnknownError, http.StatusInternalServerError, errs.ErrUnknownError.Error()},
+	} {
+		r = newRequest(string(fakeID), `{"category":"funny"}`)
+		w = httptest.NewRecorder()
+		st.EXPECT().Crosspost(r.Context(), fakeID, payload).Return(nil, tc.err)
+
+		handler.Crosspost(w, r)
+		resp = w.Result()
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body) //nolint:errcheck
+
+		assert.Equal(t, tc.status, resp.StatusCode, tc.err.Error())
+		assert.Contains(t, string(body), tc.message)
+	}
+}
@@ -0,0 +1,261 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestCreateDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	postPayload := posts.PostPayload{Title: "Work in progress"}
+	rawPostPayload, _ := json.Marshal(postPayload) //nolint:errcheck
+	draft := &posts.Draft{ID: fakeID, Post: postPayload}
+
+	// Success
+	st.EXPECT().CreateDraft(context.Background(), postPayload).Return(draft, nil)
+	r := httptest.NewRequest("POST", "/api/me/drafts", bytes.NewReader(rawPostPayload))
+	w := httptest.NewRecorder()
+
+	handler.CreateDraft(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(draft) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = httptest.NewRequest("POST", "/api/me/drafts", bytes.NewReader(nil))
+	w = httptest.NewRecorder()
+
+	handler.CreateDraft(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrTooManyDrafts:   http.StatusUnprocessableEntity,
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		st.EXPECT().CreateDraft(context.Background(), postPayload).Return(nil, err)
+		r = httptest.NewRequest("POST", "/api/me/drafts", bytes.NewReader(rawPostPayload))
+		w = httptest.NewRecorder()
+
+		handler.CreateDraft(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestGetDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	draftList := []*posts.Draft{{ID: fakeID}}
+
+	// List
+	st.EXPECT().GetDrafts(context.Background()).Return(draftList, nil)
+	r := httptest.NewRequest("GET", "/api/me/drafts", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetDrafts(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(draftList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Single draft
+	r = mux.SetURLVars(httptest.NewRequest("GET", "/api/me/drafts/"+string(fakeID), nil), map[string]string{
+		"DRAFT_ID": string(fakeID),
+	})
+	w = httptest.NewRecorder()
+	st.EXPECT().GetDraft(r.Context(), fakeID).Return(nil, errs.ErrDraftNotFound)
+
+	handler.GetDraft(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Feature disabled
+	st.EXPECT().GetDrafts(context.Background()).Return(nil, errs.ErrFeatureDisabled)
+	r = httptest.NewRequest("GET", "/api/me/drafts", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetDrafts(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+}
+
+func TestUpdateDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	postPayload := posts.PostPayload{Title: "Work in progress"}
+	rawPostPayload, _ := json.Marshal(postPayload) //nolint:errcheck
+	newRequest := func(draftID string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("PUT", "/api/me/drafts/"+draftID, bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"DRAFT_ID": draftID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(fakeID), rawPostPayload)
+	w := httptest.NewRecorder()
+	st.EXPECT().UpdateDraft(r.Context(), fakeID, postPayload).Return(&posts.Draft{ID: fakeID}, nil)
+
+	handler.UpdateDraft(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Invalid id
+	r = newRequest("1", rawPostPayload)
+	w = httptest.NewRecorder()
+
+	handler.UpdateDraft(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrDraftNotFound: http.StatusNotFound,
+		errs.ErrUnknownError:  http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), rawPostPayload)
+		w = httptest.NewRecorder()
+		st.EXPECT().UpdateDraft(r.Context(), fakeID, postPayload).Return(nil, err)
+
+		handler.UpdateDraft(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestDeleteDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(draftID string) *http.Request {
+		r := httptest.NewRequest("DELETE", "/api/me/drafts/"+draftID, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"DRAFT_ID": draftID,
+		})
+	}
+
+	for err, status := range map[error]int{
+		nil:                   http.StatusOK,
+		errs.ErrDraftNotFound: http.StatusNotFound,
+		errs.ErrUnknownError:  http.StatusInternalServerError,
+	} {
+		r := newRequest(string(fakeID))
+		w := httptest.NewRecorder()
+		st.EXPECT().DeleteDraft(r.Context(), fakeID).Return(err)
+
+		handler.DeleteDraft(w, r)
+		resp := w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode)
+	}
+
+	// Invalid id
+	r := newRequest("1")
+	w := httptest.NewRecorder()
+
+	handler.DeleteDraft(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestPublishDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(draftID string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/me/drafts/"+draftID+"/publish", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"DRAFT_ID": draftID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(fakeID))
+	w := httptest.NewRecorder()
+	st.EXPECT().PublishDraft(r.Context(), fakeID).Return(postList[0], nil)
+
+	handler.PublishDraft(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Invalid id
+	r = newRequest("1")
+	w = httptest.NewRecorder()
+
+	handler.PublishDraft(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrDraftNotFound:   http.StatusNotFound,
+		errs.ErrInvalidURL:      http.StatusUnprocessableEntity,
+		errs.ErrInvalidPostType: http.StatusUnprocessableEntity,
+		errs.ErrBadPoll:         http.StatusUnprocessableEntity,
+		errs.ErrTooManyMentions: http.StatusUnprocessableEntity,
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID))
+		w = httptest.NewRecorder()
+		st.EXPECT().PublishDraft(r.Context(), fakeID).Return(nil, err)
+
+		handler.PublishDraft(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestSetPostFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	flags := posts.FlagsPayload{NSFW: true, Spoiler: true}
+	rawFlags, _ := json.Marshal(flags) //nolint:errcheck
+	newRequest := func(postID string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("PUT", "/api/post/"+postID+"/flags", bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), rawFlags)
+	w := httptest.NewRecorder()
+	st.EXPECT().SetPostFlags(r.Context(), postList[0].ID, flags).Return(postList[0], nil)
+
+	handler.SetPostFlags(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = newRequest(string(postList[0].ID), nil)
+	w = httptest.NewRecorder()
+
+	handler.SetPostFlags(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid post id
+	r = newRequest("1", rawFlags)
+	w = httptest.NewRecorder()
+
+	handler.SetPostFlags(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrPostNotFound: http.StatusNotFound,
+		errs.ErrNotAuthor:    http.StatusForbidden,
+		errs.ErrUnknownError: http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), rawFlags)
+		w = httptest.NewRecorder()
+		st.EXPECT().SetPostFlags(r.Context(), fakeID, flags).Return(nil, err)
+
+		handler.SetPostFlags(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestHidePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/post/"+postID+"/hide", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID))
+	w := httptest.NewRecorder()
+	st.EXPECT().HidePost(r.Context(), postList[0].ID).Return(postList[0], nil)
+
+	handler.HidePost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Invalid post id
+	r = newRequest("1")
+	w = httptest.NewRecorder()
+
+	handler.HidePost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Post not found
+	r = newRequest(string(fakeID))
+	w = httptest.NewRecorder()
+	st.EXPECT().UnhidePost(r.Context(), fakeID).Return(nil, errs.ErrPostNotFound)
+
+	handler.UnhidePost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Internal error
+	r = newRequest(string(fakeID))
+	w = httptest.NewRecorder()
+	st.EXPECT().HidePost(r.Context(), fakeID).Return(nil, errs.ErrUnknownError)
+
+	handler.HidePost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestGetHiddenPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/me/hidden?limit=5", nil)
+	w := httptest.NewRecorder()
+	st.EXPECT().GetHiddenPosts(r.Context(), posts.Page{Limit: 5}).Return(postList, nil)
+
+	handler.GetHiddenPosts(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad page
+	r = httptest.NewRequest("GET", "/api/me/hidden?offset=x", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetHiddenPosts(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
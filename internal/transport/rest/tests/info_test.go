@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestGetPostsByURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	link := "https://youtu.be/dQw4w9WgXcQ?utm_source=share"
+	target := "/api/info?url=" + url.QueryEscape(link)
+
+	// Success
+	st.EXPECT().GetPostsByURL(context.Background(), link).Return(postList, nil)
+	r := httptest.NewRequest("GET", target, nil)
+	w := httptest.NewRecorder()
+
+	handler.GetPostsByURL(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// No url
+	r = httptest.NewRequest("GET", "/api/info", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetPostsByURL(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrInvalidURL:   http.StatusBadRequest,
+		errs.ErrUnknownError: http.StatusInternalServerError,
+	} {
+		st.EXPECT().GetPostsByURL(context.Background(), link).Return(nil, err)
+		r = httptest.NewRequest("GET", target, nil)
+		w = httptest.NewRecorder()
+
+		handler.GetPostsByURL(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestLockPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	lockPayload := posts.LockPayload{FreezeVotes: true}
+	rawLockPayload, _ := json.Marshal(lockPayload) //nolint:errcheck
+	newRequest := func(method, postID string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest(method, "/api/post/"+postID+"/lock", bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Lock
+	r := newRequest("PUT", string(postList[0].ID), rawLockPayload)
+	w := httptest.NewRecorder()
+	st.EXPECT().LockPost(r.Context(), postList[0].ID, lockPayload).Return(postList[0], nil)
+
+	handler.LockPost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = newRequest("PUT", string(postList[0].ID), nil)
+	w = httptest.NewRecorder()
+
+	handler.LockPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid post id
+	r = newRequest("DELETE", "1", nil)
+	w = httptest.NewRecorder()
+
+	handler.UnlockPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		nil:                  http.StatusOK,
+		errs.ErrPostNotFound: http.StatusNotFound,
+		errs.ErrNotModerator: http.StatusForbidden,
+		errs.ErrUnknownError: http.StatusInternalServerError,
+	} {
+		r = newRequest("DELETE", string(fakeID), nil)
+		w = httptest.NewRecorder()
+		st.EXPECT().UnlockPost(r.Context(), fakeID).Return(postList[0], err)
+
+		handler.UnlockPost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode)
+	}
+}
+
+func TestReadOnlyPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	comment := posts.Comment{Body: "Comment"}
+	rawComment, _ := json.Marshal(comment) //nolint:errcheck
+	newRequest := func(url string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("POST", url, bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": string(fakeID),
+		})
+	}
+
+	for _, err := range []error{errs.ErrPostLocked, errs.ErrPostArchived} {
+		r := newRequest("/api/post/"+string(fakeID), rawComment)
+		w := httptest.NewRecorder()
+		st.EXPECT().AddComment(r.Context(), fakeID, comment).Return(nil, err)
+
+		handler.AddComment(w, r)
+		resp := w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, err.Error())
+	}
+
+	for _, err := range []error{errs.ErrVotingFrozen, errs.ErrPostArchived} {
+		r := newRequest("/api/post/"+string(fakeID)+"/upvote", nil)
+		w := httptest.NewRecorder()
+		st.EXPECT().Upvote(r.Context(), fakeID).Return(nil, err)
+
+		handler.Upvote(w, r)
+		resp := w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, err.Error())
+
+		r = newRequest("/api/post/"+string(fakeID)+"/downvote", nil)
+		w = httptest.NewRecorder()
+		st.EXPECT().Downvote(r.Context(), fakeID).Return(nil, err)
+
+		handler.Downvote(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, err.Error())
+
+		r = newRequest("/api/post/"+string(fakeID)+"/unvote", nil)
+		w = httptest.NewRecorder()
+		st.EXPECT().Unvote(r.Context(), fakeID).Return(nil, err)
+
+		handler.Unvote(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, err.Error())
+	}
+}
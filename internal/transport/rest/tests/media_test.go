@@ -0,0 +1,210 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+var (
+	mediaKey     = "12345678-9abc-def1-2345-6789abcdef12.png"
+	mediaContent = []byte("png bytes")
+	imagePayload = posts.PostPayload{
+		Type:     posts.WithImage,
+		Title:    "TEST POST",
+		Category: posts.Music,
+	}
+)
+
+func newImageRequest(t *testing.T, withImage bool) *http.Request {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	assert.NoError(t, form.WriteField("title", imagePayload.Title))
+	assert.NoError(t, form.WriteField("category", imagePayload.Category.String()))
+	if withImage {
+		part, err := form.CreateFormFile("image", "cat.png")
+		assert.NoError(t, err)
+		_, err = part.Write(mediaContent)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, form.Close())
+
+	r := httptest.NewRequest("POST", "/api/posts/image", body)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	return r
+}
+
+func TestCreateImagePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+
+	// Success
+	r := newImageRequest(t, true)
+	w := httptest.NewRecorder()
+	st.EXPECT().CreateImagePost(gomock.Any(), imagePayload, mediaContent).Return(postList[0], nil)
+
+	handler.CreateImagePost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// No image
+	r = newImageRequest(t, false)
+	w = httptest.NewRecorder()
+
+	handler.CreateImagePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Not a multipart form
+	r = httptest.NewRequest("POST", "/api/posts/image", bytes.NewReader(mediaContent))
+	w = httptest.NewRecorder()
+
+	handler.CreateImagePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Unsupported type
+	r = newImageRequest(t, true)
+	w = httptest.NewRecorder()
+	st.EXPECT().CreateImagePost(gomock.Any(), imagePayload, mediaContent).Return(nil, errs.ErrUnsupportedMedia)
+
+	handler.CreateImagePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	// Too large
+	r = newImageRequest(t, true)
+	w = httptest.NewRecorder()
+	st.EXPECT().CreateImagePost(gomock.Any(), imagePayload, mediaContent).Return(nil, errs.ErrImageTooLarge)
+
+	handler.CreateImagePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	// Unknown error
+	r = newImageRequest(t, true)
+	w = httptest.NewRecorder()
+	st.EXPECT().CreateImagePost(gomock.Any(), imagePayload, mediaContent).Return(nil, errs.ErrUnknownError)
+
+	handler.CreateImagePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrUnknownError.Error())
+}
+
+func TestGetMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockMediaAPI(ctrl)
+	handler := rest.NewMediaHandler(st, zap.NewNop().Sugar())
+	modTime := time.Date(2024, time.February, 20, 10, 21, 4, 0, time.UTC)
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/media/"+mediaKey, nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"MEDIA_KEY": mediaKey,
+	})
+	w := httptest.NewRecorder()
+	st.EXPECT().GetBlob(r.Context(), mediaKey).Return(
+		io.NopCloser(bytes.NewReader(mediaContent)),
+		&media.BlobInfo{ContentType: "image/png", Size: int64(len(mediaContent)), ModTime: modTime},
+		nil,
+	)
+
+	handler.GetMedia(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, mediaContent, body)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	assert.Equal(t, media.CacheControl, resp.Header.Get("Cache-Control"))
+	assert.Equal(t, modTime.Format(http.TimeFormat), resp.Header.Get("Last-Modified"))
+	etag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// Not modified
+	r = httptest.NewRequest("GET", "/api/media/"+mediaKey, nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"MEDIA_KEY": mediaKey,
+	})
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+
+	handler.GetMedia(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// Not found
+	r = httptest.NewRequest("GET", "/api/media/"+mediaKey, nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"MEDIA_KEY": mediaKey,
+	})
+	w = httptest.NewRecorder()
+	st.EXPECT().GetBlob(r.Context(), mediaKey).Return(nil, nil, errs.ErrBlobNotFound)
+
+	handler.GetMedia(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrBlobNotFound.Error())
+
+	// Unknown error
+	r = httptest.NewRequest("GET", "/api/media/"+mediaKey, nil)
+	r = mux.SetURLVars(r, map[string]string{
+		"MEDIA_KEY": mediaKey,
+	})
+	w = httptest.NewRecorder()
+	st.EXPECT().GetBlob(r.Context(), mediaKey).Return(nil, nil, errs.ErrUnknownError)
+
+	handler.GetMedia(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestGetMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	events := []*posts.MentionEvent{{
+		ID:      fakeID,
+		Author:  postList[0].Author,
+		PostID:  postList[0].ID,
+		Created: postList[0].Created,
+	}}
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/me/mentions?limit=5&offset=5", nil)
+	w := httptest.NewRecorder()
+	st.EXPECT().GetMentions(r.Context(), posts.Page{Limit: 5, Offset: 5}).Return(events, nil)
+
+	handler.GetMentions(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(events) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad page
+	r = httptest.NewRequest("GET", "/api/me/mentions?limit=x", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetMentions(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = httptest.NewRequest("GET", "/api/me/mentions", nil)
+		w = httptest.NewRecorder()
+		st.EXPECT().GetMentions(r.Context(), gomock.Any()).Return(nil, err)
+
+		handler.GetMentions(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestPinPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	pinPayload := posts.PinPayload{FrontPage: true, Position: 1}
+	rawPinPayload, _ := json.Marshal(pinPayload) //nolint:errcheck
+	newRequest := func(postID string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("PUT", "/api/post/"+postID+"/pin", bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), rawPinPayload)
+	w := httptest.NewRecorder()
+	st.EXPECT().PinPost(r.Context(), postList[0].ID, pinPayload).Return(postList[0].AsPinned(), nil)
+
+	handler.PinPost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0].AsPinned()) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Unmarshal body error
+	r = newRequest(string(postList[0].ID), nil)
+	w = httptest.NewRecorder()
+
+	handler.PinPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid post id
+	r = newRequest("1", rawPinPayload)
+	w = httptest.NewRecorder()
+
+	handler.PinPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrPostNotFound:    http.StatusNotFound,
+		errs.ErrNotModerator:    http.StatusForbidden,
+		errs.ErrNotAdmin:        http.StatusForbidden,
+		errs.ErrBadPin:          http.StatusUnprocessableEntity,
+		errs.ErrTooManyPins:     http.StatusUnprocessableEntity,
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), rawPinPayload)
+		w = httptest.NewRecorder()
+		st.EXPECT().PinPost(r.Context(), fakeID, pinPayload).Return(nil, err)
+
+		handler.PinPost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestUnpinPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, query string) *http.Request {
+		r := httptest.NewRequest("DELETE", "/api/post/"+postID+"/pin"+query, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), "?frontPage=true")
+	w := httptest.NewRecorder()
+	st.EXPECT().UnpinPost(r.Context(), postList[0].ID, true).Return(postList[0], nil)
+
+	handler.UnpinPost(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Bad query
+	r = newRequest(string(postList[0].ID), "?frontPage=maybe")
+	w = httptest.NewRecorder()
+
+	handler.UnpinPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrPinNotFound:  http.StatusNotFound,
+		errs.ErrNotAdmin:     http.StatusForbidden,
+		errs.ErrUnknownError: http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), "")
+		w = httptest.NewRecorder()
+		st.EXPECT().UnpinPost(r.Context(), fakeID, false).Return(nil, err)
+
+		handler.UnpinPost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
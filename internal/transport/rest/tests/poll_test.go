@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestCastBallot(t *testing.T) { //nolint:funlen
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	ballot := posts.BallotPayload{Choice: []int{1}}
+	newRequest := func(postID, body string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/post/"+postID+"/poll", strings.NewReader(body))
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), `{"choice":[1]}`)
+	w := httptest.NewRecorder()
+	st.EXPECT().CastBallot(r.Context(), postList[0].ID, ballot).Return(postList[0], nil)
+
+	handler.CastBallot(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad payload
+	r = newRequest(string(postList[0].ID), `{"choice":"1"}`)
+	w = httptest.NewRecorder()
+
+	handler.CastBallot(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid post id
+	r = newRequest("1", `{"choice":[1]}`)
+	w = httptest.NewRecorder()
+
+	handler.CastBallot(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, _ = io.ReadAll(resp.Body) //nolint:errcheck
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrInvalidPostID.Error())
+
+	for _, tc := range []struct {
+		err     error
+		status  int
+		message string
+	}{
+		{errs.ErrBadBallot, http.StatusUnprocessableEntity, `"param":"choice"`},
+		{errs.ErrNotAPoll, http.StatusBadRequest, errs.ErrNotAPoll.Error()},
+		{errs.ErrPollClosed, http.StatusForbidden, errs.ErrPollClosed.Error()},
+		{errs.ErrBallotConflict, http.StatusConflict, errs.ErrBallotConflict.Error()},
+		{errs.ErrPostNotFound, http.StatusNotFound, errs.ErrPostNotFound.Error()},
+		{errs.ErrUnknownError, http.StatusInternalServerError, errs.ErrUnknownError.Error()},
+	} {
+		r = newRequest(string(fakeID), `{"choice":[1]}`)
+		w = httptest.NewRecorder()
+		st.EXPECT().CastBallot(r.Context(), fakeID, ballot).Return(nil, tc.err)
+
+		handler.CastBallot(w, r)
+		resp = w.Result()
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body) //nolint:errcheck
+
+		assert.Equal(t, tc.status, resp.StatusCode, tc.err.Error())
+		assert.Contains(t, string(body), tc.message)
+	}
+}
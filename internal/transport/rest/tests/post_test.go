@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -67,7 +68,7 @@ var (
 			},
 			Comments: []*posts.PostComment{
 				{
-					Created: "2024-02-20T10:21:54.716Z",
+					Created: time.Date(2024, 2, 20, 10, 21, 54, 716e6, time.UTC),
 					Author: jwt.TokenPayload{
 						Login: "admin",
 						ID:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
@@ -76,7 +77,7 @@ var (
 					ID:   "22222222-2222-2222-2222-222222222222",
 				},
 			},
-			Created:          "2024-02-20T10:21:04.716Z",
+			Created:          time.Date(2024, 2, 20, 10, 21, 4, 716e6, time.UTC),
 			UpvotePercentage: 100,
 		},
 	}
@@ -90,7 +91,7 @@ func TestGetAllPosts(t *testing.T) {
 	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
 
 	// Success
-	st.EXPECT().GetAllPosts(context.Background()).Return(postList, nil)
+	st.EXPECT().GetAllPosts(context.Background(), posts.FeedQuery{}).Return(postList, nil)
 
 	r := httptest.NewRequest("GET", "/api/posts/", nil)
 	w := httptest.NewRecorder()
@@ -106,7 +107,7 @@ func TestGetAllPosts(t *testing.T) {
 	assert.Equal(t, expectedData, body)
 
 	// Unknown error
-	st.EXPECT().GetAllPosts(context.Background()).Return(nil, errs.ErrUnknownError)
+	st.EXPECT().GetAllPosts(context.Background(), posts.FeedQuery{}).Return(nil, errs.ErrUnknownError)
 
 	r = httptest.NewRequest("GET", "/api/posts/", nil)
 	w = httptest.NewRecorder()
@@ -120,6 +121,34 @@ func TestGetAllPosts(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 	assert.Contains(t, string(body), errs.ErrUnknownError.Error())
 
+	// Time range
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC)
+	st.EXPECT().GetAllPosts(context.Background(), posts.FeedQuery{From: from, To: to}).Return(postList, nil)
+
+	r = httptest.NewRequest("GET", "/api/posts/?from=2024-02-01&to=2024-02-29", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllPosts(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Bad time range
+	for _, query := range []string{"from=yesterday", "to=2024-13-01", "from=2024-03-01&to=2024-02-01"} {
+		r = httptest.NewRequest("GET", "/api/posts/?"+query, nil)
+		w = httptest.NewRecorder()
+
+		handler.GetAllPosts(w, r)
+		resp = w.Result()
+		defer resp.Body.Close()
+		body, err = io.ReadAll(resp.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(body), errs.ErrBadFeedQuery.Error())
+	}
 }
 
 func TestCreatePost(t *testing.T) {
@@ -191,6 +220,30 @@ func TestCreatePost(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 	assert.Contains(t, string(body), `"msg":"is invalid"`)
+
+	// Invalid category
+	st.EXPECT().CreatePost(context.Background(), validPostPayload).Return(nil, errs.ErrInvalidCategory)
+	r = httptest.NewRequest("POST", "/api/posts", bytes.NewReader(rawValidPostPayload))
+	w = httptest.NewRecorder()
+
+	handler.CreatePost(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, string(body), `"param":"category"`)
+
+	// Repost
+	st.EXPECT().CreatePost(context.Background(), validPostPayload).Return(nil, errs.ErrRepost)
+	r = httptest.NewRequest("POST", "/api/posts", bytes.NewReader(rawValidPostPayload))
+	w = httptest.NewRecorder()
+
+	handler.CreatePost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
 }
 
 func TestGetPostByID(t *testing.T) {
@@ -282,7 +335,7 @@ func TestGetPostsByCategory(t *testing.T) {
 		"CATEGORY_NAME": posts.Music.String(),
 	})
 	w := httptest.NewRecorder()
-	st.EXPECT().GetPostsByCategory(r.Context(), posts.Music).Return(postList, nil)
+	st.EXPECT().GetPostsByCategory(r.Context(), posts.Music, posts.FeedQuery{}).Return(postList, nil)
 
 	handler.GetPostsByCategory(w, r)
 	resp := w.Result()
@@ -300,6 +353,7 @@ func TestGetPostsByCategory(t *testing.T) {
 		"CATEGORY_NAME": "ski",
 	})
 	w = httptest.NewRecorder()
+	st.EXPECT().GetPostsByCategory(r.Context(), posts.PostCategory("ski"), posts.FeedQuery{}).Return(nil, errs.ErrInvalidCategory)
 
 	handler.GetPostsByCategory(w, r)
 	resp = w.Result()
@@ -316,7 +370,7 @@ func TestGetPostsByCategory(t *testing.T) {
 		"CATEGORY_NAME": posts.Music.String(),
 	})
 	w = httptest.NewRecorder()
-	st.EXPECT().GetPostsByCategory(r.Context(), posts.Music).Return(nil, errs.ErrUnknownError)
+	st.EXPECT().GetPostsByCategory(r.Context(), posts.Music, posts.FeedQuery{}).Return(nil, errs.ErrUnknownError)
 
 	handler.GetPostsByCategory(w, r)
 	resp = w.Result()
@@ -341,7 +395,7 @@ func TestGetPostsByUser(t *testing.T) {
 		"USER_LOGIN": string(postList[0].Author.Login),
 	})
 	w := httptest.NewRecorder()
-	st.EXPECT().GetPostsByUser(r.Context(), postList[0].Author.Login).Return(postList, nil)
+	st.EXPECT().GetPostsByUser(r.Context(), postList[0].Author.Login, posts.FeedQuery{}).Return(postList, nil)
 
 	handler.GetPostsByUser(w, r)
 	resp := w.Result()
@@ -359,7 +413,7 @@ func TestGetPostsByUser(t *testing.T) {
 		"USER_LOGIN": string(postList[0].Author.Login),
 	})
 	w = httptest.NewRecorder()
-	st.EXPECT().GetPostsByUser(r.Context(), postList[0].Author.Login).Return(nil, errs.ErrUnknownError)
+	st.EXPECT().GetPostsByUser(r.Context(), postList[0].Author.Login, posts.FeedQuery{}).Return(nil, errs.ErrUnknownError)
 
 	handler.GetPostsByUser(w, r)
 	resp = w.Result()
@@ -749,6 +803,23 @@ func TestAddComment(t *testing.T) { //nolint:funlen
 	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
 	assert.Contains(t, string(body), "is required")
 
+	// Too many mentions
+	r = httptest.NewRequest("POST", "/api/post/", strings.NewReader(rawCommentPayload))
+	r = mux.SetURLVars(r, map[string]string{
+		"POST_ID": string(postList[0].ID),
+	})
+	w = httptest.NewRecorder()
+	st.EXPECT().AddComment(r.Context(), postList[0].ID, commentPayload).Return(nil, errs.ErrTooManyMentions)
+
+	handler.AddComment(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, string(body), "must mention at most 10 users")
+
 	// Post not found
 	r = httptest.NewRequest("POST", "/api/post/", strings.NewReader(rawCommentPayload))
 	r = mux.SetURLVars(r, map[string]string{
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/users"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	prefs := users.Preferences{ShowNSFW: true}
+	rawPrefs, _ := json.Marshal(prefs) //nolint:errcheck
+
+	// Get
+	st.EXPECT().GetPreferences(context.Background()).Return(prefs, nil)
+	r := httptest.NewRequest("GET", "/api/me/preferences", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetPreferences(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, rawPrefs, body)
+
+	// Update
+	st.EXPECT().UpdatePreferences(context.Background(), prefs).Return(prefs, nil)
+	r = httptest.NewRequest("PUT", "/api/me/preferences", bytes.NewReader(rawPrefs))
+	w = httptest.NewRecorder()
+
+	handler.UpdatePreferences(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Unmarshal body error
+	r = httptest.NewRequest("PUT", "/api/me/preferences", bytes.NewReader(nil))
+	w = httptest.NewRecorder()
+
+	handler.UpdatePreferences(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Feature disabled
+	st.EXPECT().GetPreferences(context.Background()).Return(users.Preferences{}, errs.ErrFeatureDisabled)
+	r = httptest.NewRequest("GET", "/api/me/preferences", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetPreferences(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	// Internal error
+	st.EXPECT().UpdatePreferences(context.Background(), prefs).Return(users.Preferences{}, errs.ErrUnknownError)
+	r = httptest.NewRequest("PUT", "/api/me/preferences", bytes.NewReader(rawPrefs))
+	w = httptest.NewRecorder()
+
+	handler.UpdatePreferences(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/media"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+// chdirRoot makes the templates of the app reachable for the router, it loads them relative to the working directory
+func chdirRoot(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir("../../../.."))
+	t.Cleanup(func() {
+		os.Chdir(wd) //nolint:errcheck
+	})
+}
+
+func TestRouterMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	chdirRoot(t)
+
+	logger := zap.NewNop().Sugar()
+	mediaAPI := mocks.NewMockMediaAPI(ctrl)
+	router := rest.NewAppRouter(
+		rest.NewUserHandler(mocks.NewMockUserAPI(ctrl), mocks.NewMockSessionAPI(ctrl), logger),
+		rest.NewPostHandler(mocks.NewMockPostAPI(ctrl), logger),
+		rest.NewCommunityHandler(mocks.NewMockCommunityAPI(ctrl), logger),
+		rest.NewMediaHandler(mediaAPI, logger),
+	).InitRouter(logger)
+
+	// Both the images and their thumbnails are served
+	for _, key := range []string{mediaKey, "12345678-9abc-def1-2345-6789abcdef12_thumb.png"} {
+		mediaAPI.EXPECT().GetBlob(gomock.Any(), key).Return(
+			io.NopCloser(bytes.NewReader(mediaContent)),
+			&media.BlobInfo{ContentType: "image/png", Size: int64(len(mediaContent))},
+			nil,
+		)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/media/"+key, nil))
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, key)
+		assert.Equal(t, mediaContent, body)
+	}
+
+	// Keys without an extension or with several of them are not routed
+	for _, key := range []string{"12345678-9abc-def1-2345-6789abcdef12", "cat.tar.gz"} {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/media/"+key, nil))
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, key)
+	}
+}
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestSaveComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	commentID := postList[0].Comments[0].ID
+	newRequest := func(postID, commentID string) *http.Request {
+		r := httptest.NewRequest("POST", "/api/post/"+postID+"/"+commentID+"/save", nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID":    postID,
+			"COMMENT_ID": commentID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(postList[0].ID), string(commentID))
+	w := httptest.NewRecorder()
+	st.EXPECT().SaveComment(r.Context(), postList[0].ID, commentID).Return(postList[0], nil)
+
+	handler.SaveComment(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList[0]) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Invalid comment id
+	r = newRequest(string(postList[0].ID), "1")
+	w = httptest.NewRecorder()
+
+	handler.SaveComment(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{errs.ErrPostNotFound, http.StatusNotFound},
+		{errs.ErrCommentNotFound, http.StatusNotFound},
+		{errs.ErrFeatureDisabled, http.StatusNotImplemented},
+		{errs.ErrUnknownError, http.StatusInternalServerError},
+	} {
+		r = newRequest(string(fakeID), string(commentID))
+		w = httptest.NewRecorder()
+		st.EXPECT().SaveComment(r.Context(), fakeID, commentID).Return(nil, tc.err)
+
+		handler.SaveComment(w, r)
+		resp = w.Result()
+		defer resp.Body.Close()
+		body, _ = io.ReadAll(resp.Body) //nolint:errcheck
+
+		assert.Equal(t, tc.status, resp.StatusCode, tc.err.Error())
+		assert.Contains(t, string(body), tc.err.Error())
+	}
+}
+
+func TestUnsavePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+
+	r := httptest.NewRequest("POST", "/api/post/"+string(postList[0].ID)+"/unsave", nil)
+	r = mux.SetURLVars(r, map[string]string{"POST_ID": string(postList[0].ID)})
+	w := httptest.NewRecorder()
+	st.EXPECT().UnsavePost(r.Context(), postList[0].ID).Return(postList[0], nil)
+
+	handler.UnsavePost(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGetSaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	entries := []*posts.SavedEntry{{
+		Type:      posts.ItemComment,
+		Saved:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Comment:   postList[0].Comments[0],
+		PostID:    postList[0].ID,
+		PostTitle: postList[0].Title,
+	}}
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/me/saved?type=comment&limit=10&offset=5", nil)
+	w := httptest.NewRecorder()
+	query := posts.SavedQuery{Type: posts.ItemComment, Page: posts.Page{Limit: 10, Offset: 5}}
+	st.EXPECT().GetSaved(r.Context(), query).Return(entries, nil)
+
+	handler.GetSaved(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(entries) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad type and bad page
+	for _, target := range []string{"/api/me/saved?type=user", "/api/me/saved?limit=ten"} {
+		r = httptest.NewRequest("GET", target, nil)
+		w = httptest.NewRecorder()
+
+		handler.GetSaved(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, target)
+	}
+
+	// Internal error
+	r = httptest.NewRequest("GET", "/api/me/saved", nil)
+	w = httptest.NewRecorder()
+	st.EXPECT().GetSaved(r.Context(), posts.SavedQuery{}).Return(nil, errs.ErrUnknownError)
+
+	handler.GetSaved(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
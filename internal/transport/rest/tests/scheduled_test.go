@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestSchedulePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	publishAt := time.Date(2030, time.January, 2, 15, 4, 5, 0, time.UTC)
+	postPayload := posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt}
+	rawPostPayload, _ := json.Marshal(postPayload) //nolint:errcheck
+	scheduled := &posts.ScheduledPost{ID: fakeID, Post: postPayload, PublishAt: publishAt}
+
+	// Success
+	st.EXPECT().SchedulePost(context.Background(), postPayload).Return(scheduled, nil)
+	r := httptest.NewRequest("POST", "/api/posts", bytes.NewReader(rawPostPayload))
+	w := httptest.NewRecorder()
+
+	handler.CreatePost(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(scheduled) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	for err, status := range map[error]int{
+		errs.ErrBadPublishTime:  http.StatusUnprocessableEntity,
+		errs.ErrInvalidCategory: http.StatusUnprocessableEntity,
+		errs.ErrTooManyMentions: http.StatusUnprocessableEntity,
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		st.EXPECT().SchedulePost(context.Background(), postPayload).Return(nil, err)
+		r = httptest.NewRequest("POST", "/api/posts", bytes.NewReader(rawPostPayload))
+		w = httptest.NewRecorder()
+
+		handler.CreatePost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestGetScheduledPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	scheduledList := []*posts.ScheduledPost{{ID: fakeID, Post: posts.PostPayload{Title: "Title"}}}
+
+	// Success
+	st.EXPECT().GetScheduledPosts(context.Background()).Return(scheduledList, nil)
+	r := httptest.NewRequest("GET", "/api/me/scheduled", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetScheduledPosts(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(scheduledList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	for err, status := range map[error]int{
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		st.EXPECT().GetScheduledPosts(context.Background()).Return(nil, err)
+		r = httptest.NewRequest("GET", "/api/me/scheduled", nil)
+		w = httptest.NewRecorder()
+
+		handler.GetScheduledPosts(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestUpdateScheduledPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	publishAt := time.Date(2030, time.January, 2, 15, 4, 5, 0, time.UTC)
+	postPayload := posts.PostPayload{Type: posts.WithText, Title: "Title", Category: posts.Music, Text: "Text", PublishAt: &publishAt}
+	rawPostPayload, _ := json.Marshal(postPayload) //nolint:errcheck
+	newRequest := func(scheduledID string, rawPayload []byte) *http.Request {
+		r := httptest.NewRequest("PUT", "/api/me/scheduled/"+scheduledID, bytes.NewReader(rawPayload))
+		return mux.SetURLVars(r, map[string]string{
+			"SCHEDULED_ID": scheduledID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(fakeID), rawPostPayload)
+	w := httptest.NewRecorder()
+	st.EXPECT().UpdateScheduledPost(r.Context(), fakeID, postPayload).Return(&posts.ScheduledPost{ID: fakeID}, nil)
+
+	handler.UpdateScheduledPost(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Unmarshal body error
+	r = newRequest(string(fakeID), nil)
+	w = httptest.NewRecorder()
+
+	handler.UpdateScheduledPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Invalid id
+	r = newRequest("1", rawPostPayload)
+	w = httptest.NewRecorder()
+
+	handler.UpdateScheduledPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrScheduledPostNotFound: http.StatusNotFound,
+		errs.ErrBadPublishTime:        http.StatusUnprocessableEntity,
+		errs.ErrFeatureDisabled:       http.StatusNotImplemented,
+		errs.ErrUnknownError:          http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), rawPostPayload)
+		w = httptest.NewRecorder()
+		st.EXPECT().UpdateScheduledPost(r.Context(), fakeID, postPayload).Return(nil, err)
+
+		handler.UpdateScheduledPost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestCancelScheduledPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(scheduledID string) *http.Request {
+		r := httptest.NewRequest("DELETE", "/api/me/scheduled/"+scheduledID, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"SCHEDULED_ID": scheduledID,
+		})
+	}
+
+	// Success
+	r := newRequest(string(fakeID))
+	w := httptest.NewRecorder()
+	st.EXPECT().CancelScheduledPost(r.Context(), fakeID).Return(nil)
+
+	handler.CancelScheduledPost(w, r)
+	resp := w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Invalid id
+	r = newRequest("1")
+	w = httptest.NewRecorder()
+
+	handler.CancelScheduledPost(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for err, status := range map[error]int{
+		errs.ErrScheduledPostNotFound: http.StatusNotFound,
+		errs.ErrFeatureDisabled:       http.StatusNotImplemented,
+		errs.ErrUnknownError:          http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID))
+		w = httptest.NewRecorder()
+		st.EXPECT().CancelScheduledPost(r.Context(), fakeID).Return(err)
+
+		handler.CancelScheduledPost(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
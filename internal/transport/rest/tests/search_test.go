@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestSearchPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+
+	// Success
+	r := httptest.NewRequest("GET", "/api/search?q=kartik&category=music&author=admin&from=2024-01-01&limit=10&offset=5", nil)
+	w := httptest.NewRecorder()
+	expectedQuery := posts.SearchQuery{
+		Text:     "kartik",
+		Category: posts.Music,
+		Author:   "admin",
+		From:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Page:     posts.Page{Limit: 10, Offset: 5},
+	}
+	st.EXPECT().SearchPosts(r.Context(), expectedQuery).Return(postList, nil)
+
+	handler.SearchPosts(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(postList) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad pagination
+	r = httptest.NewRequest("GET", "/api/search?q=kartik&limit=ten", nil)
+	w = httptest.NewRecorder()
+
+	handler.SearchPosts(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrBadSearchQuery.Error())
+
+	// Bad date
+	r = httptest.NewRequest("GET", "/api/search?q=kartik&to=yesterday", nil)
+	w = httptest.NewRecorder()
+
+	handler.SearchPosts(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Empty query
+	r = httptest.NewRequest("GET", "/api/search", nil)
+	w = httptest.NewRecorder()
+	st.EXPECT().SearchPosts(r.Context(), posts.SearchQuery{}).Return(nil, errs.ErrBadSearchQuery)
+
+	handler.SearchPosts(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Unknown error
+	r = httptest.NewRequest("GET", "/api/search?q=kartik", nil)
+	w = httptest.NewRecorder()
+	st.EXPECT().SearchPosts(r.Context(), posts.SearchQuery{Text: "kartik"}).Return(nil, errs.ErrUnknownError)
+
+	handler.SearchPosts(w, r)
+	resp = w.Result()
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, string(body), errs.ErrUnknownError.Error())
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestGetPostStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, query string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/post/"+postID+"/stats"+query, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID": postID,
+		})
+	}
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	query := posts.StatsQuery{Interval: posts.Daily, From: from, To: from.Add(24*time.Hour - time.Nanosecond)}
+	stats := &posts.PostStats{
+		PostID:   fakeID,
+		Interval: posts.Daily,
+		Total:    posts.StatsCounts{Views: 7},
+		Buckets:  []*posts.StatsBucket{{Start: from, StatsCounts: posts.StatsCounts{Views: 7}}},
+	}
+
+	// Success
+	r := newRequest(string(fakeID), "?interval=day&from=2024-03-01&to=2024-03-01")
+	w := httptest.NewRecorder()
+	st.EXPECT().GetPostStats(r.Context(), fakeID, query).Return(stats, nil)
+
+	handler.GetPostStats(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(stats) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad requests
+	for _, r = range []*http.Request{
+		newRequest("1", ""),
+		newRequest(string(fakeID), "?from=yesterday"),
+		newRequest(string(fakeID), "?to=tomorrow"),
+	} {
+		w = httptest.NewRecorder()
+
+		handler.GetPostStats(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+	}
+
+	for err, status := range map[error]int{
+		errs.ErrBadStatsQuery:   http.StatusBadRequest,
+		errs.ErrPostNotFound:    http.StatusNotFound,
+		errs.ErrNotAuthor:       http.StatusForbidden,
+		errs.ErrFeatureDisabled: http.StatusNotImplemented,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), "")
+		w = httptest.NewRecorder()
+		st.EXPECT().GetPostStats(r.Context(), fakeID, posts.StatsQuery{}).Return(nil, err)
+
+		handler.GetPostStats(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
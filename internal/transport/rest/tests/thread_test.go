@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+	"github.com/Benzogang-Tape/Reddit/internal/models/posts"
+	"github.com/Benzogang-Tape/Reddit/internal/storage/mocks"
+	"github.com/Benzogang-Tape/Reddit/internal/transport/rest"
+)
+
+func TestGetCommentThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, commentID string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/post/"+postID+"/"+commentID, nil)
+		return mux.SetURLVars(r, map[string]string{
+			"POST_ID":    postID,
+			"COMMENT_ID": commentID,
+		})
+	}
+	thread := &posts.PostComment{
+		ID:      fakeID,
+		Body:    "Parent",
+		Replies: []*posts.PostComment{{ID: fakeID, ParentID: fakeID, Depth: 1, Body: "Reply", MoreReplies: 3}},
+	}
+
+	// Success
+	r := newRequest(string(fakeID), string(fakeID))
+	w := httptest.NewRecorder()
+	st.EXPECT().GetCommentThread(r.Context(), fakeID, fakeID).Return(thread, nil)
+
+	handler.GetCommentThread(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(thread) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Bad ids
+	for _, r = range []*http.Request{
+		newRequest("1", string(fakeID)),
+		newRequest(string(fakeID), "1"),
+	} {
+		w = httptest.NewRecorder()
+
+		handler.GetCommentThread(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+	}
+
+	for err, status := range map[error]int{
+		errs.ErrPostNotFound:    http.StatusNotFound,
+		errs.ErrCommentNotFound: http.StatusNotFound,
+		errs.ErrUnknownError:    http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), string(fakeID))
+		w = httptest.NewRecorder()
+		st.EXPECT().GetCommentThread(r.Context(), fakeID, fakeID).Return(nil, err)
+
+		handler.GetCommentThread(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
+
+func TestAddReply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	reply := posts.Comment{Body: "Reply", ParentID: fakeID}
+
+	r := httptest.NewRequest("POST", "/api/post/"+string(fakeID), strings.NewReader(`{"comment": "Reply", "parentId": "`+string(fakeID)+`"}`))
+	r = mux.SetURLVars(r, map[string]string{
+		"POST_ID": string(fakeID),
+	})
+	w := httptest.NewRecorder()
+	st.EXPECT().AddComment(r.Context(), fakeID, reply).Return(nil, errs.ErrBadParentComment)
+
+	handler.AddComment(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, string(body), "parentId")
+}
+
+func TestGetComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	st := mocks.NewMockPostAPI(ctrl)
+	handler := rest.NewPostHandler(st, zap.NewNop().Sugar())
+	newRequest := func(postID, query string) *http.Request {
+		r := httptest.NewRequest("GET", "/api/post/"+postID+"/comments?"+query, nil)
+		return mux.SetURLVars(r, map[string]string{"POST_ID": postID})
+	}
+	page := &posts.CommentPage{Comments: postList[0].Comments, After: fakeID}
+
+	// Success
+	r := newRequest(string(fakeID), "sort=top&limit=10&after="+string(fakeID))
+	w := httptest.NewRecorder()
+	query := posts.CommentQuery{Sort: posts.SortTop, Limit: 10, After: fakeID}
+	st.EXPECT().GetComments(r.Context(), fakeID, query).Return(page, nil)
+
+	handler.GetComments(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	expectedData, _ := json.Marshal(page) //nolint:errcheck
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, expectedData, body)
+
+	// Defaults
+	r = newRequest(string(fakeID), "")
+	w = httptest.NewRecorder()
+	query = posts.CommentQuery{Sort: posts.SortBest, Limit: posts.DefaultCommentLimit}
+	st.EXPECT().GetComments(r.Context(), fakeID, query).Return(page, nil)
+
+	handler.GetComments(w, r)
+	resp = w.Result() //nolint:bodyclose
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Bad id and queries
+	for _, r = range []*http.Request{
+		newRequest("1", ""),
+		newRequest(string(fakeID), "sort=random"),
+		newRequest(string(fakeID), "limit=many"),
+		newRequest(string(fakeID), "after=1"),
+	} {
+		w = httptest.NewRecorder()
+
+		handler.GetComments(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, r.URL.String())
+	}
+
+	for err, status := range map[error]int{
+		errs.ErrPostNotFound:     http.StatusNotFound,
+		errs.ErrBadCommentCursor: http.StatusBadRequest,
+		errs.ErrUnknownError:     http.StatusInternalServerError,
+	} {
+		r = newRequest(string(fakeID), "")
+		w = httptest.NewRecorder()
+		st.EXPECT().GetComments(r.Context(), fakeID, gomock.Any()).Return(nil, err)
+
+		handler.GetComments(w, r)
+		resp = w.Result() //nolint:bodyclose
+
+		assert.Equal(t, status, resp.StatusCode, err.Error())
+	}
+}
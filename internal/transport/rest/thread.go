@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Benzogang-Tape/Reddit/internal/models/errs"
+)
+
+// GetCommentThread godoc
+//
+//	@Summary		Get comment thread
+//	@Description	Get a comment with the tree of the replies to it. That is how a thread is continued past the "continue thread" stub of a comment (moreReplies)
+//	@Tags			commenting-posts
+//	@ID				get-comment-thread
+//	@Produce		json
+//	@Param			POST_ID		path		string				true	"Post uuid"		minlength(36)	maxlength(36)
+//	@Param			COMMENT_ID	path		string				true	"Comment uuid"	minlength(36)	maxlength(36)
+//	@Success		200			{object}	posts.PostComment	"Thread successfully received"
+//	@Failure		400			{object}	errs.SimpleErr		"Bad uuid"
+//	@Failure		404			{object}	errs.SimpleErr		"No posts or comment with the provided id were found"
+//	@Failure		500			{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/{COMMENT_ID} [get]
+func (p *PostHandler) GetCommentThread(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	postID, err := validateID("POST_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	commentID, err := validateID("COMMENT_ID", params)
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidCommentID.Error()))
+		return
+	}
+
+	thread, err := p.service.GetCommentThread(r.Context(), postID, commentID)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrCommentNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrCommentNotFound.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(thread, w)
+}
+
+// GetComments godoc
+//
+//	@Summary		Get comments
+//	@Description	Get a page of the comment trees of a post. The next page is loaded with the cursor (after) of the previous one
+//	@Tags			commenting-posts
+//	@ID				get-comments
+//	@Produce		json
+//	@Param			POST_ID	path		string				true	"Post uuid"										minlength(36)								maxlength(36)
+//	@Param			sort	query		string				false	"Order of the comments and of the replies"		Enums(best, top, new, controversial, old)	default(best)
+//	@Param			limit	query		int					false	"Number of top-level comments on the page"		minimum(1)									maximum(200)	default(50)
+//	@Param			after	query		string				false	"Cursor of the page, the after of the previous"	minlength(36)								maxlength(36)
+//	@Success		200		{object}	posts.CommentPage	"Comments successfully received"
+//	@Failure		400		{object}	errs.SimpleErr		"Bad uuid, query or cursor"
+//	@Failure		404		{object}	errs.SimpleErr		"No posts with the provided id were found"
+//	@Failure		500		{object}	errs.SimpleErr		"Internal server error"
+//	@Router			/post/{POST_ID}/comments [get]
+func (p *PostHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	postID, err := validateID("POST_ID", mux.Vars(r))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrInvalidPostID.Error()))
+		return
+	}
+	query, err := parseCommentQuery(r.URL.Query())
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadCommentQuery.Error()))
+		return
+	}
+
+	page, err := p.service.GetComments(r.Context(), postID, query)
+	switch {
+	case errors.Is(err, errs.ErrPostNotFound):
+		sendErrorResponse(w, http.StatusNotFound, errs.NewSimpleErr(errs.ErrPostNotFound.Error()))
+		return
+	case errors.Is(err, errs.ErrBadCommentCursor):
+		sendErrorResponse(w, http.StatusBadRequest, errs.NewSimpleErr(errs.ErrBadCommentCursor.Error()))
+		return
+	case err != nil:
+		sendErrorResponse(w, http.StatusInternalServerError, errs.NewSimpleErr(errs.ErrUnknownError.Error()))
+		return
+	}
+
+	sendResponse(page, w)
+}
@@ -0,0 +1,227 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	JPEG = "image/jpeg"
+	PNG  = "image/png"
+	GIF  = "image/gif"
+
+	jpegQuality = 90
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrTooManyPixels   = errors.New("image resolution is too high")
+	ErrTooManyFrames   = errors.New("animation has too many frames")
+	errMalformedGIF    = errors.New("gif: malformed data")
+)
+
+// Image is a decoded image that has been re-encoded without any metadata
+type Image struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+	frame       image.Image
+}
+
+// DetectContentType sniffs the image type by its content, ignoring whatever the client claims
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case JPEG, PNG, GIF:
+		return contentType, nil
+	default:
+		return contentType, ErrUnsupportedType
+	}
+}
+
+// Sanitize decodes the image and encodes it again. Encoders of the standard library never write
+// EXIF, XMP or comments, so the result carries nothing but pixels. Animated GIFs stay animated
+// as long as they have at most maxFrames frames and all the frames together fit into maxPixels.
+func Sanitize(data []byte, maxPixels, maxFrames int) (*Image, error) {
+	contentType, err := DetectContentType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img := &Image{
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}
+	buf := &bytes.Buffer{}
+	switch contentType {
+	case GIF:
+		// Every frame is decoded into a canvas of its own, so the limits are checked before anything is decoded
+		frames, err := countFrames(data)
+		switch {
+		case err != nil:
+			return nil, err
+		case frames > maxFrames:
+			return nil, ErrTooManyFrames
+		case frames*cfg.Width*cfg.Height > maxPixels:
+			return nil, ErrTooManyPixels
+		}
+		animation, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if err = gif.EncodeAll(buf, &gif.GIF{
+			Image:     animation.Image,
+			Delay:     animation.Delay,
+			LoopCount: animation.LoopCount,
+			Disposal:  animation.Disposal,
+			Config:    animation.Config,
+		}); err != nil {
+			return nil, err
+		}
+		img.frame = animation.Image[0]
+	default:
+		frame, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if err = encode(buf, frame, contentType); err != nil {
+			return nil, err
+		}
+		img.frame = frame
+	}
+	img.Data = buf.Bytes()
+
+	return img, nil
+}
+
+// Thumbnail scales the (first frame of the) image down to fit into a square with the given side.
+// Thumbnails of GIFs are PNGs.
+func (img *Image) Thumbnail(side int) (*Image, error) {
+	bounds := img.frame.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > side || height > side {
+		if width > height {
+			width, height = side, max(height*side/width, 1)
+		} else {
+			width, height = max(width*side/height, 1), side
+		}
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(thumb, thumb.Bounds(), img.frame, bounds, draw.Over, nil)
+
+	contentType := img.ContentType
+	if contentType == GIF {
+		contentType = PNG
+	}
+	buf := &bytes.Buffer{}
+	if err := encode(buf, thumb, contentType); err != nil {
+		return nil, err
+	}
+
+	return &Image{
+		Data:        buf.Bytes(),
+		ContentType: contentType,
+		Width:       width,
+		Height:      height,
+		frame:       thumb,
+	}, nil
+}
+
+// Extension returns the file extension matching the image type
+func Extension(contentType string) string {
+	switch contentType {
+	case JPEG:
+		return ".jpg"
+	case PNG:
+		return ".png"
+	case GIF:
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// countFrames walks the blocks of the GIF and counts its image descriptors without decoding any of them.
+// See https://www.w3.org/Graphics/GIF/spec-gif89a.txt
+func countFrames(data []byte) (int, error) {
+	const (
+		headerLen     = 13 // Signature, version and logical screen descriptor
+		descriptorLen = 10
+		extension     = 0x21
+		descriptor    = 0x2C
+		trailer       = 0x3B
+	)
+	// colorTableLen is the size of the color table following a block with the flags
+	colorTableLen := func(flags byte) int {
+		if flags&0x80 == 0 {
+			return 0
+		}
+		return 3 << (flags&0x07 + 1)
+	}
+
+	if len(data) < headerLen {
+		return 0, errMalformedGIF
+	}
+	pos := headerLen + colorTableLen(data[10])
+	frames := 0
+	for pos < len(data) {
+		switch data[pos] {
+		case extension:
+			pos += 2 // Introducer and label
+		case descriptor:
+			if pos+descriptorLen > len(data) {
+				return 0, errMalformedGIF
+			}
+			pos += descriptorLen + colorTableLen(data[pos+descriptorLen-1])
+			pos++ // LZW minimum code size
+			frames++
+		case trailer:
+			return frames, nil
+		default:
+			return 0, errMalformedGIF
+		}
+
+		// Data sub-blocks up to the terminating empty one
+		for {
+			if pos >= len(data) {
+				return 0, errMalformedGIF
+			}
+			size := int(data[pos])
+			pos += size + 1
+			if size == 0 {
+				break
+			}
+		}
+	}
+
+	return 0, errMalformedGIF
+}
+
+func encode(buf *bytes.Buffer, img image.Image, contentType string) error {
+	switch contentType {
+	case JPEG:
+		return jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality})
+	case PNG:
+		return png.Encode(buf, img)
+	default:
+		return ErrUnsupportedType
+	}
+}
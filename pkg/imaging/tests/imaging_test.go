@@ -0,0 +1,160 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Benzogang-Tape/Reddit/pkg/imaging"
+)
+
+const (
+	maxPixels = 1_000_000
+	maxFrames = 10
+)
+
+func newPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+
+	return buf.Bytes()
+}
+
+func newJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil))
+
+	return buf.Bytes()
+}
+
+func newGIF(t *testing.T, width, height, frames int) []byte {
+	t.Helper()
+	animation := &gif.GIF{}
+	for i := range frames {
+		frame := image.NewPaletted(image.Rect(0, 0, width, height), palette.Plan9)
+		frame.SetColorIndex(i%width, 0, uint8(i))
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 10)
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, gif.EncodeAll(buf, animation))
+
+	return buf.Bytes()
+}
+
+func TestSanitize(t *testing.T) {
+	for _, tc := range []struct {
+		data        []byte
+		contentType string
+	}{
+		{newPNG(t, 640, 480), imaging.PNG},
+		{newJPEG(t, 640, 480), imaging.JPEG},
+		{newGIF(t, 640, 480, 1), imaging.GIF},
+	} {
+		img, err := imaging.Sanitize(tc.data, maxPixels, maxFrames)
+		require.NoError(t, err, tc.contentType)
+		assert.Equal(t, tc.contentType, img.ContentType)
+		assert.Equal(t, 640, img.Width)
+		assert.Equal(t, 480, img.Height)
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(img.Data))
+		require.NoError(t, err)
+		assert.Equal(t, "image/"+format, tc.contentType)
+		assert.Equal(t, 640, cfg.Width)
+		assert.Equal(t, 480, cfg.Height)
+	}
+
+	// The type is sniffed from the content
+	_, err := imaging.Sanitize([]byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), maxPixels, maxFrames)
+	assert.ErrorIs(t, err, imaging.ErrUnsupportedType)
+
+	// Broken image
+	data := newPNG(t, 640, 480)
+	_, err = imaging.Sanitize(data[:len(data)/2], maxPixels, maxFrames)
+	assert.Error(t, err)
+
+	// Resolution
+	_, err = imaging.Sanitize(newPNG(t, 2000, 1000), maxPixels, maxFrames)
+	assert.ErrorIs(t, err, imaging.ErrTooManyPixels)
+}
+
+func TestSanitizeAnimation(t *testing.T) {
+	img, err := imaging.Sanitize(newGIF(t, 100, 100, maxFrames), maxPixels, maxFrames)
+	require.NoError(t, err)
+	animation, err := gif.DecodeAll(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Len(t, animation.Image, maxFrames)
+
+	// Too many frames
+	_, err = imaging.Sanitize(newGIF(t, 10, 10, maxFrames+1), maxPixels, maxFrames)
+	assert.ErrorIs(t, err, imaging.ErrTooManyFrames)
+
+	// Every frame fits, but all of them together do not
+	_, err = imaging.Sanitize(newGIF(t, 500, 500, 5), maxPixels, maxFrames)
+	assert.ErrorIs(t, err, imaging.ErrTooManyPixels)
+
+	// Cut off before the trailer
+	data := newGIF(t, 100, 100, 3)
+	for _, size := range []int{len(data) - 1, len(data) / 2, 20, 12} {
+		_, err = imaging.Sanitize(data[:size], maxPixels, maxFrames)
+		assert.Error(t, err, size)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	const side = 320
+	for _, tc := range []struct {
+		width, height           int
+		thumbWidth, thumbHeight int
+	}{
+		{640, 480, 320, 240},
+		{100, 400, 80, 320},
+		{320, 320, 320, 320},
+		{50, 30, 50, 30},
+		{2000, 1, 320, 1},
+	} {
+		img, err := imaging.Sanitize(newPNG(t, tc.width, tc.height), maxPixels, maxFrames)
+		require.NoError(t, err)
+
+		thumb, err := img.Thumbnail(side)
+		require.NoError(t, err)
+		assert.Equal(t, imaging.PNG, thumb.ContentType)
+		assert.Equal(t, tc.thumbWidth, thumb.Width, "%dx%d", tc.width, tc.height)
+		assert.Equal(t, tc.thumbHeight, thumb.Height, "%dx%d", tc.width, tc.height)
+		cfg, err := png.DecodeConfig(bytes.NewReader(thumb.Data))
+		require.NoError(t, err)
+		assert.Equal(t, thumb.Width, cfg.Width)
+		assert.Equal(t, thumb.Height, cfg.Height)
+	}
+
+	// JPEGs stay JPEGs, GIFs become PNGs of their first frame
+	img, err := imaging.Sanitize(newJPEG(t, 640, 480), maxPixels, maxFrames)
+	require.NoError(t, err)
+	thumb, err := img.Thumbnail(side)
+	require.NoError(t, err)
+	assert.Equal(t, imaging.JPEG, thumb.ContentType)
+	_, err = jpeg.Decode(bytes.NewReader(thumb.Data))
+	assert.NoError(t, err)
+
+	img, err = imaging.Sanitize(newGIF(t, 640, 480, 3), maxPixels, maxFrames)
+	require.NoError(t, err)
+	thumb, err = img.Thumbnail(side)
+	require.NoError(t, err)
+	assert.Equal(t, imaging.PNG, thumb.ContentType)
+	assert.Equal(t, 320, thumb.Width)
+	assert.Equal(t, 240, thumb.Height)
+	assert.Equal(t, ".png", imaging.Extension(thumb.ContentType))
+}
@@ -0,0 +1,235 @@
+package linkpreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	MaxTitleLength       = 300
+	MaxDescriptionLength = 1000
+	MaxURLLength         = 2048
+
+	oEmbedJSON = "application/json+oembed"
+)
+
+var (
+	ErrNotHTML   = errors.New("link does not point to an html page")
+	ErrNoPreview = errors.New("page has no preview metadata")
+)
+
+// Preview is the summary of a web page built from its OpenGraph and oEmbed metadata
+type Preview struct {
+	Title        string
+	Description  string
+	ThumbnailURL string
+	SiteName     string
+}
+
+// pageMeta is the metadata found in the head of a page. The og: tags take precedence over the twitter: ones,
+// which in turn take precedence over the plain <title> and <meta name="description">
+type pageMeta struct {
+	og, twitter, fallback Preview
+	oEmbedURL             string
+}
+
+type oEmbed struct {
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// Extract fetches the page and builds its preview. The oEmbed endpoint advertised by the page is only
+// queried when the page itself lacks a title or a thumbnail
+func Extract(ctx context.Context, fetcher Fetcher, rawURL string) (*Preview, error) {
+	page, err := fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if !isHTML(page.ContentType) {
+		return nil, ErrNotHTML
+	}
+
+	meta := parseHead(page.Body)
+	preview := meta.preview()
+	if meta.oEmbedURL != "" && (preview.Title == "" || preview.ThumbnailURL == "") {
+		if embed, err := fetchOEmbed(ctx, fetcher, resolve(page.URL, meta.oEmbedURL)); err == nil {
+			preview.Title = firstNonEmpty(preview.Title, embed.Title)
+			preview.SiteName = firstNonEmpty(preview.SiteName, embed.ProviderName)
+			preview.ThumbnailURL = firstNonEmpty(preview.ThumbnailURL, embed.ThumbnailURL)
+		}
+	}
+
+	preview.Title = truncate(preview.Title, MaxTitleLength)
+	preview.Description = truncate(preview.Description, MaxDescriptionLength)
+	preview.SiteName = truncate(preview.SiteName, MaxTitleLength)
+	preview.ThumbnailURL = resolve(page.URL, preview.ThumbnailURL)
+	if preview.Title == "" && preview.Description == "" && preview.ThumbnailURL == "" {
+		return nil, ErrNoPreview
+	}
+
+	return &preview, nil
+}
+
+func fetchOEmbed(ctx context.Context, fetcher Fetcher, rawURL string) (*oEmbed, error) {
+	if rawURL == "" {
+		return nil, ErrNoPreview
+	}
+	page, err := fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	embed := new(oEmbed)
+	if err = json.Unmarshal(page.Body, embed); err != nil {
+		return nil, err
+	}
+
+	return embed, nil
+}
+
+func parseHead(body []byte) *pageMeta {
+	meta := new(pageMeta)
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	inTitle := false
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.TextToken:
+			if inTitle && meta.fallback.Title == "" {
+				meta.fallback.Title = string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return meta
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = true
+			case atom.Body:
+				return meta
+			case atom.Meta:
+				if hasAttr {
+					meta.addMeta(attributes(tokenizer))
+				}
+			case atom.Link:
+				if hasAttr {
+					meta.addLink(attributes(tokenizer))
+				}
+			}
+		}
+	}
+}
+
+func (m *pageMeta) addMeta(attrs map[string]string) {
+	key := strings.ToLower(firstNonEmpty(attrs["property"], attrs["name"]))
+	content := attrs["content"]
+	switch key {
+	case "og:title":
+		m.og.Title = content
+	case "og:description":
+		m.og.Description = content
+	case "og:image", "og:image:url", "og:image:secure_url":
+		m.og.ThumbnailURL = firstNonEmpty(m.og.ThumbnailURL, content)
+	case "og:site_name":
+		m.og.SiteName = content
+	case "twitter:title":
+		m.twitter.Title = content
+	case "twitter:description":
+		m.twitter.Description = content
+	case "twitter:image", "twitter:image:src":
+		m.twitter.ThumbnailURL = firstNonEmpty(m.twitter.ThumbnailURL, content)
+	case "description":
+		m.fallback.Description = content
+	}
+}
+
+func (m *pageMeta) addLink(attrs map[string]string) {
+	if strings.EqualFold(attrs["rel"], "alternate") && strings.EqualFold(attrs["type"], oEmbedJSON) && m.oEmbedURL == "" {
+		m.oEmbedURL = attrs["href"]
+	}
+}
+
+func (m *pageMeta) preview() Preview {
+	return Preview{
+		Title:        firstNonEmpty(m.og.Title, m.twitter.Title, m.fallback.Title),
+		Description:  firstNonEmpty(m.og.Description, m.twitter.Description, m.fallback.Description),
+		ThumbnailURL: firstNonEmpty(m.og.ThumbnailURL, m.twitter.ThumbnailURL),
+		SiteName:     m.og.SiteName,
+	}
+}
+
+func attributes(tokenizer *html.Tokenizer) map[string]string {
+	attrs := make(map[string]string)
+	for {
+		key, val, more := tokenizer.TagAttr()
+		attrs[strings.ToLower(string(key))] = string(val)
+		if !more {
+			return attrs
+		}
+	}
+}
+
+// resolve makes the reference absolute and drops anything that is not a plain http(s) link
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || len(ref) > MaxURLLength {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		refURL = base.ResolveReference(refURL)
+	}
+	if checkScheme(refURL) != nil || refURL.Host == "" {
+		return ""
+	}
+
+	return refURL.String()
+}
+
+func isHTML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func truncate(s string, limit int) string {
+	s = strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	return string([]rune(s)[:limit])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,127 @@
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+const DefaultCacheSize = 4096
+
+// Renderer turns CommonMark into HTML that is safe to embed in a page.
+// Raw HTML of the source is dropped and the output is filtered through a strict allowlist
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+
+	mu        sync.Mutex
+	cache     map[[sha256.Size]byte]*list.Element
+	recent    *list.List // Most recently used entries first
+	cacheSize int
+}
+
+type cacheEntry struct {
+	key  [sha256.Size]byte
+	html string
+}
+
+// NewRenderer creates a renderer keeping up to cacheSize recent renderings, DefaultCacheSize if not positive
+func NewRenderer(cacheSize int) *Renderer {
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+
+	return &Renderer{
+		md: goldmark.New(
+			goldmark.WithExtensions(extension.Strikethrough, extension.Table, extension.Linkify, mentions{}),
+			goldmark.WithRendererOptions(html.WithHardWraps()),
+		),
+		policy:    newPolicy(),
+		cache:     make(map[[sha256.Size]byte]*list.Element),
+		recent:    list.New(),
+		cacheSize: cacheSize,
+	}
+}
+
+// newPolicy allows the elements CommonMark produces and nothing else. Links may only lead
+// to http, https and mailto addresses or to the pages of the app and never pass the referrer or the ranking on
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(
+		"p", "br", "hr", "em", "strong", "del", "code", "pre", "blockquote",
+		"ul", "ol", "li", "h1", "h2", "h3", "h4", "h5", "h6",
+		"table", "thead", "tbody", "tr", "th", "td",
+	)
+	policy.AllowAttrs("start").Matching(regexp.MustCompile(`^[0-9]+$`)).OnElements("ol")
+	policy.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|right|center)$`)).OnElements("th", "td")
+	policy.AllowAttrs("href").OnElements("a")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.AllowRelativeURLs(true)
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.RequireNoReferrerOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+
+	return policy
+}
+
+// Render returns the sanitized HTML of the source
+func (r *Renderer) Render(source string) string {
+	return r.RenderMentions(source, nil)
+}
+
+// RenderMentions returns the sanitized HTML of the source with the @username and /u/username references
+// to the given users linked to their pages. The references match the usernames in any case.
+// References to anyone else stay plain text
+func (r *Renderer) RenderMentions(source string, usernames []string) string {
+	if source == "" {
+		return ""
+	}
+
+	usernames = slices.Sorted(slices.Values(usernames))
+	key := sha256.Sum256([]byte(strings.Join(append(usernames, source), "\x00")))
+	r.mu.Lock()
+	if elem, ok := r.cache[key]; ok {
+		r.recent.MoveToFront(elem)
+		r.mu.Unlock()
+		return elem.Value.(*cacheEntry).html //nolint:forcetypeassert
+	}
+	r.mu.Unlock()
+
+	var buf bytes.Buffer
+	pc := parser.NewContext()
+	if len(usernames) != 0 {
+		linked := make(map[string]string, len(usernames))
+		for _, username := range usernames {
+			linked[strings.ToLower(username)] = username
+		}
+		pc.Set(mentionsKey, linked)
+	}
+	if err := r.md.Convert([]byte(source), &buf, parser.WithContext(pc)); err != nil {
+		// Conversion only fails on write errors, which a buffer never returns
+		return r.policy.Sanitize(source)
+	}
+	rendered := r.policy.SanitizeBytes(buf.Bytes())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cache[key]; !ok {
+		r.cache[key] = r.recent.PushFront(&cacheEntry{key: key, html: string(rendered)})
+		if r.recent.Len() > r.cacheSize {
+			oldest := r.recent.Remove(r.recent.Back()).(*cacheEntry) //nolint:forcetypeassert
+			delete(r.cache, oldest.key)
+		}
+	}
+
+	return string(rendered)
+}
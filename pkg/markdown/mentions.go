@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// UserPath is the page of a user, the mentions link to it
+const UserPath = "/u/"
+
+// mentionsKey holds the usernames that may be linked while a source is rendered, keyed by their lower case
+var mentionsKey = parser.NewContextKey()
+
+// mentions turns the @username and /u/username references to the users in the set of the render into links to their pages
+type mentions struct{}
+
+func (mentions) Extend(md goldmark.Markdown) {
+	md.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(mentionParser{}, 999)))
+}
+
+type mentionParser struct{}
+
+func (mentionParser) Trigger() []byte {
+	return []byte{'@', '/'}
+}
+
+func (mentionParser) Parse(_ ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	usernames, ok := pc.Get(mentionsKey).(map[string]string)
+	// A reference must not continue a word, a path or an address
+	prev := block.PrecendingCharacter()
+	if !ok || pc.IsInLinkLabel() || isUsernameChar(prev) || prev == '/' || prev == '@' {
+		return nil
+	}
+
+	line, segment := block.PeekLine()
+	prefix := 1
+	if line[0] == '/' {
+		if len(line) < len(UserPath) || string(line[:len(UserPath)]) != UserPath {
+			return nil
+		}
+		prefix = len(UserPath)
+	}
+	end := prefix
+	for end < len(line) && isUsernameChar(rune(line[end])) {
+		end++
+	}
+	username, ok := usernames[strings.ToLower(string(line[prefix:end]))]
+	if !ok {
+		return nil
+	}
+
+	link := ast.NewLink()
+	link.Destination = []byte(UserPath + username)
+	link.AppendChild(link, ast.NewTextSegment(segment.WithStop(segment.Start+end)))
+	block.Advance(end)
+
+	return link
+}
+
+// isUsernameChar reports whether the character may be a part of a username
+func isUsernameChar(c rune) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '-'
+}